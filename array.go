@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"sync"
 	"time"
 	"unsafe"
 )
@@ -38,6 +39,8 @@ type Array struct {
 	tiledbArray arrayHandle
 	context     *Context
 	uri         string
+	// openMutex is held by a pointer so that the methods with value receivers do not copy it.
+	openMutex *sync.Mutex
 }
 
 // ArrayMetadata defines metadata for the array
@@ -66,7 +69,7 @@ type NonEmptyDomain struct {
 }
 
 func newArrayFromHandle(tdbCtx *Context, arrayHandle arrayHandle) *Array {
-	return &Array{context: tdbCtx, tiledbArray: arrayHandle}
+	return &Array{context: tdbCtx, tiledbArray: arrayHandle, openMutex: &sync.Mutex{}}
 }
 
 // ConsolidateArray consolidates the fragments of an array into a single fragment.
@@ -83,7 +86,7 @@ func ConsolidateArray(tdbCtx *Context, uri string, config *Config) error {
 	runtime.KeepAlive(tdbCtx)
 	runtime.KeepAlive(config)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error consolidating tiledb array: %w", tdbCtx.LastError())
+		return fmt.Errorf("error consolidating tiledb array: %w", tdbCtx.lastError("tiledb_array_consolidate", ret))
 	}
 
 	runtime.KeepAlive(config)
@@ -98,7 +101,7 @@ func CreateArray(tdbCtx *Context, uri string, arraySchema *ArraySchema) error {
 	runtime.KeepAlive(tdbCtx)
 	runtime.KeepAlive(arraySchema)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error creating tiledb array: %w", tdbCtx.LastError())
+		return fmt.Errorf("error creating tiledb array: %w", tdbCtx.lastError("tiledb_array_create", ret))
 	}
 	return nil
 }
@@ -115,7 +118,7 @@ func VacuumArray(tdbCtx *Context, uri string, config *Config) error {
 	runtime.KeepAlive(tdbCtx)
 	runtime.KeepAlive(config)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error vacuuming tiledb array: %w", tdbCtx.LastError())
+		return fmt.Errorf("error vacuuming tiledb array: %w", tdbCtx.lastError("tiledb_array_vacuum", ret))
 	}
 
 	runtime.KeepAlive(config)
@@ -137,7 +140,7 @@ func NewArray(tdbCtx *Context, uri string) (*Array, error) {
 	var arrayPtr *C.tiledb_array_t
 	ret := C.tiledb_array_alloc(tdbCtx.tiledbContext.Get(), curi, &arrayPtr)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error creating tiledb array: %w", tdbCtx.LastError())
+		return nil, fmt.Errorf("error creating tiledb array: %w", tdbCtx.lastError("tiledb_array_alloc", ret))
 	}
 	array := newArrayFromHandle(tdbCtx, newArrayHandle(arrayPtr))
 	array.uri = uri
@@ -151,6 +154,9 @@ func NewArray(tdbCtx *Context, uri string) (*Array, error) {
 // can safely be called many times on the same object; if it has already
 // been freed, it will not be freed again.
 func (a *Array) Free() {
+	// Wait for an open abandoned by OpenContext to finish.
+	a.openMutex.Lock()
+	defer a.openMutex.Unlock()
 	a.tiledbArray.Free()
 }
 
@@ -186,7 +192,7 @@ func WithEndTimestamp(endTimestamp uint64) ArrayOpenOption {
 		ret := C.tiledb_array_set_open_timestamp_end(tdbArray.context.tiledbContext.Get(), tdbArray.tiledbArray.Get(), C.uint64_t(endTimestamp))
 		runtime.KeepAlive(tdbArray)
 		if ret != C.TILEDB_OK {
-			return fmt.Errorf("error setting end timestamp option: %w", tdbArray.context.LastError())
+			return fmt.Errorf("error setting end timestamp option: %w", tdbArray.context.lastError("tiledb_array_set_open_timestamp_end", ret))
 		}
 		return nil
 	}
@@ -198,7 +204,7 @@ func WithStartTimestamp(startTimestamp uint64) ArrayOpenOption {
 		ret := C.tiledb_array_set_open_timestamp_start(tdbArray.context.tiledbContext.Get(), tdbArray.tiledbArray.Get(), C.uint64_t(startTimestamp))
 		runtime.KeepAlive(tdbArray)
 		if ret != C.TILEDB_OK {
-			return fmt.Errorf("error setting start timestamp option: %w", tdbArray.context.LastError())
+			return fmt.Errorf("error setting start timestamp option: %w", tdbArray.context.lastError("tiledb_array_set_open_timestamp_start", ret))
 		}
 		return nil
 	}
@@ -215,16 +221,26 @@ array_read for reads and another one array_write for writes, and interleave
 creation and submission of queries for both these array objects.
 */
 func (a *Array) OpenWithOptions(queryType QueryType, opts ...ArrayOpenOption) error {
+	a.openMutex.Lock()
+	defer a.openMutex.Unlock()
+	return a.openWithOptions(queryType, opts...)
+}
+
+func (a *Array) openWithOptions(queryType QueryType, opts ...ArrayOpenOption) error {
 	for _, opt := range opts {
 		if err := opt(a); err != nil {
 			return err
 		}
 	}
+	return a.open(queryType)
+}
 
+func (a *Array) open(queryType QueryType) error {
 	ret := C.tiledb_array_open(a.context.tiledbContext.Get(), a.tiledbArray.Get(), C.tiledb_query_type_t(queryType))
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error opening tiledb array for querying: %w", a.context.LastError())
+		err := a.context.lastError("tiledb_array_open", ret)
+		return fmt.Errorf("error opening tiledb array for querying: %w", notFoundError(a.context, a.uri, TILEDB_ARRAY, err))
 	}
 	return nil
 }
@@ -240,12 +256,9 @@ array_read for reads and another one array_write for writes, and interleave
 creation and submission of queries for both these array objects.
 */
 func (a *Array) Open(queryType QueryType) error {
-	ret := C.tiledb_array_open(a.context.tiledbContext.Get(), a.tiledbArray.Get(), C.tiledb_query_type_t(queryType))
-	runtime.KeepAlive(a)
-	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error opening tiledb array for querying: %w", a.context.LastError())
-	}
-	return nil
+	a.openMutex.Lock()
+	defer a.openMutex.Unlock()
+	return a.open(queryType)
 }
 
 /*
@@ -259,17 +272,36 @@ func (a *Array) Reopen() error {
 	ret := C.tiledb_array_reopen(a.context.tiledbContext.Get(), a.tiledbArray.Get())
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error reopening tiledb array for querying: %w", a.context.LastError())
+		return fmt.Errorf("error reopening tiledb array for querying: %w", a.context.lastError("tiledb_array_reopen", ret))
 	}
 	return nil
 }
 
+// IsOpen returns true if the array is open or false if the array is closed.
+func (a *Array) IsOpen() (bool, error) {
+	var isOpen C.int32_t
+
+	ret := C.tiledb_array_is_open(a.context.tiledbContext.Get(), a.tiledbArray.Get(), &isOpen)
+	runtime.KeepAlive(a)
+	if ret != C.TILEDB_OK {
+		return false, fmt.Errorf("error checking if array is open: %w", a.context.lastError("tiledb_array_is_open", ret))
+	}
+
+	return isOpen > 0, nil
+}
+
 // Close closes a tiledb array. This is automatically called on garbage collection.
 func (a *Array) Close() error {
+	a.openMutex.Lock()
+	defer a.openMutex.Unlock()
+	return a.close()
+}
+
+func (a *Array) close() error {
 	ret := C.tiledb_array_close(a.context.tiledbContext.Get(), a.tiledbArray.Get())
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error closing tiledb array for querying: %w", a.context.LastError())
+		return fmt.Errorf("error closing tiledb array for querying: %w", a.context.lastError("tiledb_array_close", ret))
 	}
 	return nil
 }
@@ -300,7 +332,7 @@ func (a *Array) Schema() (*ArraySchema, error) {
 	ret := C.tiledb_array_get_schema(a.context.tiledbContext.Get(), a.tiledbArray.Get(), &arraySchemaPtr)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting schema for tiledb array: %w", a.context.LastError())
+		return nil, fmt.Errorf("error getting schema for tiledb array: %w", a.context.lastError("tiledb_array_get_schema", ret))
 	}
 	return newArraySchemaFromHandle(a.context, newArraySchemaHandle(arraySchemaPtr)), nil
 }
@@ -311,7 +343,7 @@ func (a *Array) QueryType() (QueryType, error) {
 	ret := C.tiledb_array_get_query_type(a.context.tiledbContext.Get(), a.tiledbArray.Get(), &queryType)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return -1, fmt.Errorf("error getting QueryType for tiledb array: %w", a.context.LastError())
+		return -1, fmt.Errorf("error getting QueryType for tiledb array: %w", a.context.lastError("tiledb_array_get_query_type", ret))
 	}
 	return QueryType(queryType), nil
 }
@@ -347,7 +379,7 @@ func (a *Array) OpenStartTimestamp() (uint64, error) {
 	ret := C.tiledb_array_get_open_timestamp_start(a.context.tiledbContext.Get(), a.tiledbArray.Get(), &start)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting start timestamp for tiledb array: %w", a.context.LastError())
+		return 0, fmt.Errorf("error getting start timestamp for tiledb array: %w", a.context.lastError("tiledb_array_get_open_timestamp_start", ret))
 	}
 	return uint64(start), nil
 }
@@ -358,7 +390,7 @@ func (a *Array) OpenEndTimestamp() (uint64, error) {
 	ret := C.tiledb_array_get_open_timestamp_end(a.context.tiledbContext.Get(), a.tiledbArray.Get(), &end)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting end timestamp for tiledb array: %w", a.context.LastError())
+		return 0, fmt.Errorf("error getting end timestamp for tiledb array: %w", a.context.lastError("tiledb_array_get_open_timestamp_end", ret))
 	}
 	return uint64(end), nil
 }
@@ -399,7 +431,7 @@ func getNonEmptyDomainForDim(dimension *Dimension, bounds interface{}) (*NonEmpt
 		return makeNonEmptyDomain(name, ds)
 	case []any:
 		if dimensionType != TILEDB_STRING_ASCII {
-			return nil, fmt.Errorf(
+			return nil, errorf(ErrSchemaMismatch,
 				"type mismatch between non-empty domain type (%T) and dimension type (%v); expected %v",
 				ds[0], dimensionType, TILEDB_STRING_ASCII,
 			)
@@ -466,7 +498,7 @@ func (a *Array) NonEmptyDomain() ([]NonEmptyDomain, bool, error) {
 				tmpDimensionPtr, &isEmpty)
 			runtime.KeepAlive(a)
 			if ret != C.TILEDB_OK {
-				return fmt.Errorf("error in getting non empty domain for dimension: %w", a.context.LastError())
+				return fmt.Errorf("error in getting non empty domain for dimension: %w", a.context.lastError("tiledb_array_get_non_empty_domain_from_index", ret))
 			}
 
 			if isEmpty == 1 {
@@ -564,7 +596,7 @@ func (a *Array) NonEmptyDomainMap() (map[string]interface{}, error) {
 				tmpDimensionPtr, &isEmpty)
 			runtime.KeepAlive(a)
 			if ret != C.TILEDB_OK {
-				return nil, fmt.Errorf("error in getting non empty domain for dimension: %w", a.context.LastError())
+				return nil, fmt.Errorf("error in getting non empty domain for dimension: %w", a.context.lastError("tiledb_array_get_non_empty_domain_from_index", ret))
 			}
 
 			if isEmpty == 1 {
@@ -608,7 +640,7 @@ func (a *Array) NonEmptyDomainVarFromName(dimName string) (*NonEmptyDomain, bool
 	}
 
 	if !hasDim {
-		return nil, false, fmt.Errorf("Dimension: %s was not found in domain", dimName)
+		return nil, false, errorf(ErrNotFound, "Dimension: %s was not found in domain", dimName)
 	}
 
 	dimension, err := domain.DimensionFromName(dimName)
@@ -644,7 +676,7 @@ func (a *Array) NonEmptyDomainVarFromName(dimName string) (*NonEmptyDomain, bool
 		&isEmpty)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, false, fmt.Errorf("error in getting non empty domain size for dimension %s for array: %w", dimName, a.context.LastError())
+		return nil, false, fmt.Errorf("error in getting non empty domain size for dimension %s for array: %w", dimName, a.context.lastError("tiledb_array_get_non_empty_domain_var_size_from_name", ret))
 	}
 
 	if isEmpty == 1 {
@@ -674,7 +706,7 @@ func (a *Array) NonEmptyDomainVarFromName(dimName string) (*NonEmptyDomain, bool
 		&isEmpty)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, false, fmt.Errorf("error in getting non empty domain for dimension %s for array: %w", dimName, a.context.LastError())
+		return nil, false, fmt.Errorf("error in getting non empty domain for dimension %s for array: %w", dimName, a.context.lastError("tiledb_array_get_non_empty_domain_var_from_name", ret))
 	}
 
 	if isEmpty == 1 {
@@ -736,7 +768,7 @@ func (a *Array) NonEmptyDomainVarFromIndex(dimIdx uint) (*NonEmptyDomain, bool,
 		&isEmpty)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, false, fmt.Errorf("error in getting non empty domain size for dimension %d for array: %w", dimIdx, a.context.LastError())
+		return nil, false, fmt.Errorf("error in getting non empty domain size for dimension %d for array: %w", dimIdx, a.context.lastError("tiledb_array_get_non_empty_domain_var_size_from_index", ret))
 	}
 
 	if isEmpty == 1 {
@@ -766,7 +798,7 @@ func (a *Array) NonEmptyDomainVarFromIndex(dimIdx uint) (*NonEmptyDomain, bool,
 		&isEmpty)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, false, fmt.Errorf("error in getting non empty domain for dimension index %d for array: %w", dimIdx, a.context.LastError())
+		return nil, false, fmt.Errorf("error in getting non empty domain for dimension index %d for array: %w", dimIdx, a.context.lastError("tiledb_array_get_non_empty_domain_var_from_index", ret))
 	}
 
 	if isEmpty == 1 {
@@ -827,7 +859,7 @@ func (a Array) GetNonEmptyDomainSliceFromName(dimName string) (*Dimension, inter
 	}
 
 	if !hasDim {
-		return nil, nil, nil, fmt.Errorf("dimension: %s was not found in domain", dimName)
+		return nil, nil, nil, errorf(ErrNotFound, "dimension: %s was not found in domain", dimName)
 	}
 
 	dimension, err := domain.DimensionFromName(dimName)
@@ -865,7 +897,7 @@ func (a *Array) NonEmptyDomainFromIndex(dimIdx uint) (*NonEmptyDomain, bool, err
 		tmpDimensionPtr, &isEmpty)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, false, fmt.Errorf("error in getting non empty domain for dimension: %w", a.context.LastError())
+		return nil, false, fmt.Errorf("error in getting non empty domain for dimension: %w", a.context.lastError("tiledb_array_get_non_empty_domain_from_index", ret))
 	}
 
 	if isEmpty == 1 {
@@ -900,7 +932,7 @@ func (a *Array) NonEmptyDomainFromName(dimName string) (*NonEmptyDomain, bool, e
 		tmpDimensionPtr, &isEmpty)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, false, fmt.Errorf("error in getting non empty domain for dimension: %w", a.context.LastError())
+		return nil, false, fmt.Errorf("error in getting non empty domain for dimension: %w", a.context.lastError("tiledb_array_get_non_empty_domain_from_name", ret))
 	}
 
 	if isEmpty == 1 {
@@ -940,14 +972,15 @@ func (a *Array) PutCharMetadata(key string, charData string) error {
 	runtime.KeepAlive(a)
 
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error adding char metadata to array: %w", a.context.LastError())
+		return fmt.Errorf("error adding char metadata to array: %w", a.context.lastError("tiledb_array_put_metadata", ret))
 	}
 
 	return nil
 }
 
 // PutMetadata puts a metadata key-value item to an open array. The array must
-// be opened in WRITE mode, otherwise the function will error out.
+// be opened in WRITE mode, otherwise the function will error out. Times are
+// written as TILEDB_DATETIME_NS timestamps.
 func (a *Array) PutMetadata(key string, value interface{}) error {
 	switch value := value.(type) {
 	case int:
@@ -1002,6 +1035,18 @@ func (a *Array) PutMetadata(key string, value interface{}) error {
 		return arrayPutScalarMetadata(a, TILEDB_BOOL, key, value)
 	case []bool:
 		return arrayPutSliceMetadata(a, TILEDB_BOOL, key, value)
+	case time.Time:
+		timestamp, err := TimestampFromTime(TILEDB_DATETIME_NS, value)
+		if err != nil {
+			return fmt.Errorf("can't write %q metadata: %w", key, err)
+		}
+		return arrayPutScalarMetadata(a, TILEDB_DATETIME_NS, key, timestamp)
+	case []time.Time:
+		timestamps, err := TimestampsFromTimes(TILEDB_DATETIME_NS, value)
+		if err != nil {
+			return fmt.Errorf("can't write %q metadata: %w", key, err)
+		}
+		return arrayPutSliceMetadata(a, TILEDB_DATETIME_NS, key, timestamps)
 	case string:
 		valPtr := unsafe.Pointer(C.CString(value))
 		defer C.free(valPtr)
@@ -1034,7 +1079,7 @@ func arrayPutMetadata(a *Array, dt Datatype, key string, valuePtr unsafe.Pointer
 	)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("could not add metadata to array: %w", a.context.LastError())
+		return fmt.Errorf("could not add metadata to array: %w", a.context.lastError("tiledb_array_put_metadata", ret))
 	}
 	return nil
 }
@@ -1048,7 +1093,7 @@ func (a *Array) DeleteMetadata(key string) error {
 	ret := C.tiledb_array_delete_metadata(a.context.tiledbContext.Get(), a.tiledbArray.Get(), ckey)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error deleting metadata from array: %w", a.context.LastError())
+		return fmt.Errorf("error deleting metadata from array: %w", a.context.lastError("tiledb_array_delete_metadata", ret))
 	}
 	return nil
 }
@@ -1066,12 +1111,12 @@ func (a *Array) GetMetadata(key string) (Datatype, uint, interface{}, error) {
 	ret := C.tiledb_array_get_metadata(a.context.tiledbContext.Get(), a.tiledbArray.Get(), ckey, &cType, &cValueNum, &cvalue)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return 0, 0, nil, fmt.Errorf("error getting metadata from array: %w, key: %s", a.context.LastError(), key)
+		return 0, 0, nil, fmt.Errorf("error getting metadata from array: %w, key: %s", a.context.lastError("tiledb_array_get_metadata", ret), key)
 	}
 
 	valueNum := uint(cValueNum)
 	if valueNum == 0 {
-		return 0, 0, nil, fmt.Errorf("error getting metadata from array, key: %s does not exist", key)
+		return 0, 0, nil, errorf(ErrNotFound, "error getting metadata from array, key: %s does not exist", key)
 	}
 
 	datatype := Datatype(cType)
@@ -1091,7 +1136,7 @@ func (a *Array) GetMetadataNum() (uint64, error) {
 	ret := C.tiledb_array_get_metadata_num(a.context.tiledbContext.Get(), a.tiledbArray.Get(), &cNum)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting number of metadata from array: %w", a.context.LastError())
+		return 0, fmt.Errorf("error getting number of metadata from array: %w", a.context.lastError("tiledb_array_get_metadata_num", ret))
 	}
 
 	return uint64(cNum), nil
@@ -1123,12 +1168,12 @@ func (a *Array) GetMetadataFromIndexWithValueLimit(index uint64, limit *uint) (*
 		a.tiledbArray.Get(), cIndex, &cKey, &cKeyLen, &cType, &cValueNum, &cvalue)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting metadata from array: %s, Index: %d", a.context.LastError(), index)
+		return nil, fmt.Errorf("error getting metadata from array, Index: %d: %w", index, a.context.lastError("tiledb_array_get_metadata_from_index", ret))
 	}
 
 	valueNum := uint(cValueNum)
 	if valueNum == 0 {
-		return nil, fmt.Errorf("error getting metadata from array, Index: %d does not exist", index)
+		return nil, errorf(ErrNotFound, "error getting metadata from array, Index: %d does not exist", index)
 	}
 
 	datatype := Datatype(cType)
@@ -1186,7 +1231,7 @@ func (a *Array) SetConfig(config *Config) error {
 	runtime.KeepAlive(a)
 	runtime.KeepAlive(config)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting config on array: %w", a.context.LastError())
+		return fmt.Errorf("error setting config on array: %w", a.context.lastError("tiledb_array_set_config", ret))
 	}
 
 	return nil
@@ -1198,7 +1243,7 @@ func (a *Array) Config() (*Config, error) {
 	ret := C.tiledb_array_get_config(a.context.tiledbContext.Get(), a.tiledbArray.Get(), &configPtr)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting config from array: %w", a.context.LastError())
+		return nil, fmt.Errorf("error getting config from array: %w", a.context.lastError("tiledb_array_get_config", ret))
 	}
 
 	return newConfigFromHandle(newConfigHandle(configPtr)), nil
@@ -1213,7 +1258,7 @@ func DeleteFragments(tdbCtx *Context, uri string, startTimestamp, endTimestamp u
 		C.uint64_t(startTimestamp), C.uint64_t(endTimestamp))
 	runtime.KeepAlive(tdbCtx)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error deleting fragments from array: %w", tdbCtx.LastError())
+		return fmt.Errorf("error deleting fragments from array: %w", tdbCtx.lastError("tiledb_array_delete_fragments_v2", ret))
 	}
 
 	return nil
@@ -1230,7 +1275,7 @@ func DeleteFragmentsList(tdbCtx *Context, uri string, fragmentURIs []string) err
 	ret := C.tiledb_array_delete_fragments_list(tdbCtx.tiledbContext.Get(), curi, (**C.char)(slicePtr(list)), C.size_t(len(list)))
 	runtime.KeepAlive(tdbCtx)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error deleting fragments list from array: %w", tdbCtx.LastError())
+		return fmt.Errorf("error deleting fragments list from array: %w", tdbCtx.lastError("tiledb_array_delete_fragments_list", ret))
 	}
 
 	runtime.KeepAlive(list)
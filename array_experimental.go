@@ -44,7 +44,7 @@ func GetConsolidationPlan(arr *Array, fragmentSize uint64) (*ConsolidationPlan,
 	ret := C.tiledb_consolidation_plan_create_with_mbr(arr.context.tiledbContext.Get(), arr.tiledbArray.Get(), C.uint64_t(fragmentSize), &consolidationPlanPtr)
 	runtime.KeepAlive(arr)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting consolidation plan for array: %w", arr.context.LastError())
+		return nil, fmt.Errorf("error getting consolidation plan for array: %w", arr.context.lastError("tiledb_consolidation_plan_create_with_mbr", ret))
 	}
 
 	return newConsolidationPlanFromHandle(arr.context, newConsolidationPlanHandle(consolidationPlanPtr)), nil
@@ -66,7 +66,7 @@ func (cp *ConsolidationPlan) NumNodes() (uint64, error) {
 	ret := C.tiledb_consolidation_plan_get_num_nodes(cp.context.tiledbContext.Get(), cp.tiledbConsolidationPlan.Get(), &numNodes)
 	runtime.KeepAlive(cp)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting consolidation plan num nodes: %w", cp.context.LastError())
+		return 0, fmt.Errorf("error getting consolidation plan num nodes: %w", cp.context.lastError("tiledb_consolidation_plan_get_num_nodes", ret))
 	}
 
 	return uint64(numNodes), nil
@@ -79,7 +79,7 @@ func (cp *ConsolidationPlan) NumFragments(nodeIndex uint64) (uint64, error) {
 	ret := C.tiledb_consolidation_plan_get_num_fragments(cp.context.tiledbContext.Get(), cp.tiledbConsolidationPlan.Get(), C.uint64_t(nodeIndex), &numFragments)
 	runtime.KeepAlive(cp)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting consolidation plan num fragments: %w", cp.context.LastError())
+		return 0, fmt.Errorf("error getting consolidation plan num fragments: %w", cp.context.lastError("tiledb_consolidation_plan_get_num_fragments", ret))
 	}
 
 	return uint64(numFragments), nil
@@ -92,7 +92,7 @@ func (cp *ConsolidationPlan) FragmentURI(nodeIndex, fragmentIndex uint64) (strin
 	ret := C.tiledb_consolidation_plan_get_fragment_uri(cp.context.tiledbContext.Get(), cp.tiledbConsolidationPlan.Get(), C.uint64_t(nodeIndex), C.uint64_t(fragmentIndex), &curi)
 	runtime.KeepAlive(cp)
 	if ret != C.TILEDB_OK {
-		return "", fmt.Errorf("error getting consolidation plan fragment uri for node %d and fragment %d: %w", nodeIndex, fragmentIndex, cp.context.LastError())
+		return "", fmt.Errorf("error getting consolidation plan fragment uri for node %d and fragment %d: %w", nodeIndex, fragmentIndex, cp.context.lastError("tiledb_consolidation_plan_get_fragment_uri", ret))
 	}
 
 	return C.GoString(curi), nil
@@ -104,14 +104,14 @@ func (cp *ConsolidationPlan) DumpJSON() (string, error) {
 	ret := C.tiledb_consolidation_plan_dump_json_str(cp.context.tiledbContext.Get(), cp.tiledbConsolidationPlan.Get(), &cjson)
 	runtime.KeepAlive(cp)
 	if ret != C.TILEDB_OK {
-		return "", fmt.Errorf("error getting consolidation plan json dump: %w", cp.context.LastError())
+		return "", fmt.Errorf("error getting consolidation plan json dump: %w", cp.context.lastError("tiledb_consolidation_plan_dump_json_str", ret))
 	}
 
 	json := C.GoString(cjson)
 
 	ret = C.tiledb_consolidation_plan_free_json_str(&cjson)
 	if ret != C.TILEDB_OK {
-		return "", fmt.Errorf("error getting consolidation plan json dump: %w", cp.context.LastError())
+		return "", fmt.Errorf("error getting consolidation plan json dump: %w", cp.context.lastError("tiledb_consolidation_plan_free_json_str", ret))
 	}
 
 	return json, nil
@@ -134,7 +134,7 @@ func (a *Array) ConsolidateFragments(config *Config, fragmentList []string) erro
 	ret := C.tiledb_array_consolidate_fragments(a.context.tiledbContext.Get(), curi, (**C.char)(slicePtr(list)), C.uint64_t(len(list)), config.tiledbConfig.Get())
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error consolidating tiledb array fragment list: %w", a.context.LastError())
+		return fmt.Errorf("error consolidating tiledb array fragment list: %w", a.context.lastError("tiledb_array_consolidate_fragments", ret))
 	}
 
 	runtime.KeepAlive(config)
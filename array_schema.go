@@ -84,7 +84,7 @@ func newArraySchemaFromHandle(context *Context, handle arraySchemaHandle) *Array
 func (a *ArraySchema) MarshalJSON() ([]byte, error) {
 	bs, err := SerializeArraySchema(a, TILEDB_JSON, false)
 	if err != nil {
-		return nil, fmt.Errorf("error marshaling json for array schema: %w", a.context.LastError())
+		return nil, fmt.Errorf("error marshaling json for array schema: %w", err)
 	}
 	return bs, nil
 }
@@ -119,12 +119,12 @@ func (a *ArraySchema) UnmarshalJSON(b []byte) error {
 	// Wrap the input byte slice in a Buffer (does not copy)
 	buffer, err := NewBuffer(a.context)
 	if err != nil {
-		return fmt.Errorf("error unmarshaling json for array schema: %w", a.context.LastError())
+		return fmt.Errorf("error unmarshaling json for array schema: %w", err)
 	}
 	defer buffer.Free()
 	err = buffer.SetBuffer(bytesWithNullTerminator)
 	if err != nil {
-		return fmt.Errorf("error unmarshaling json for array schema: %w", a.context.LastError())
+		return fmt.Errorf("error unmarshaling json for array schema: %w", err)
 	}
 
 	// Deserialize into a new array schema
@@ -133,7 +133,7 @@ func (a *ArraySchema) UnmarshalJSON(b []byte) error {
 	ret := C.tiledb_deserialize_array_schema(a.context.tiledbContext.Get(), buffer.tiledbBuffer.Get(), C.TILEDB_JSON, cClientSide, &newCSchema)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error deserializing array schema: %w", a.context.LastError())
+		return fmt.Errorf("error deserializing array schema: %w", a.context.lastError("tiledb_deserialize_array_schema", ret))
 	}
 
 	// Replace the C schema object with the deserialized one.
@@ -148,7 +148,7 @@ func NewArraySchema(tdbCtx *Context, arrayType ArrayType) (*ArraySchema, error)
 	ret := C.tiledb_array_schema_alloc(tdbCtx.tiledbContext.Get(), C.tiledb_array_type_t(arrayType), &arraySchemaPtr)
 	runtime.KeepAlive(tdbCtx)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error creating tiledb arraySchema: %w", tdbCtx.LastError())
+		return nil, fmt.Errorf("error creating tiledb arraySchema: %w", tdbCtx.lastError("tiledb_array_schema_alloc", ret))
 	}
 	return newArraySchemaFromHandle(tdbCtx, newArraySchemaHandle(arraySchemaPtr)), nil
 }
@@ -169,7 +169,7 @@ func (a *ArraySchema) AddAttributes(attributes ...*Attribute) error {
 		runtime.KeepAlive(a)
 		runtime.KeepAlive(attribute)
 		if ret != C.TILEDB_OK {
-			return fmt.Errorf("error adding attributes to tiledb arraySchema: %w", a.context.LastError())
+			return fmt.Errorf("error adding attributes to tiledb arraySchema: %w", a.context.lastError("tiledb_array_schema_add_attribute", ret))
 		}
 	}
 	return nil
@@ -181,7 +181,7 @@ func (a *ArraySchema) AttributeNum() (uint, error) {
 	ret := C.tiledb_array_schema_get_attribute_num(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), &attrNum)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting attribute number for tiledb arraySchema: %w", a.context.LastError())
+		return 0, fmt.Errorf("error getting attribute number for tiledb arraySchema: %w", a.context.lastError("tiledb_array_schema_get_attribute_num", ret))
 	}
 	return uint(attrNum), nil
 }
@@ -196,7 +196,7 @@ func (a *ArraySchema) AttributeFromIndex(index uint) (*Attribute, error) {
 		&attributePtr)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting attribute %d for tiledb arraySchema: %w", index, a.context.LastError())
+		return nil, fmt.Errorf("error getting attribute %d for tiledb arraySchema: %w", index, a.context.lastError("tiledb_array_schema_get_attribute_from_index", ret))
 	}
 	return newAttributeFromHandle(a.context, newAttributeHandle(attributePtr)), nil
 }
@@ -211,7 +211,11 @@ func (a *ArraySchema) AttributeFromName(attrName string) (*Attribute, error) {
 	ret := C.tiledb_array_schema_get_attribute_from_name(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), cAttrName, &attributePtr)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting attribute %s for tiledb arraySchema: %w", attrName, a.context.LastError())
+		err := a.context.lastError("tiledb_array_schema_get_attribute_from_name", ret)
+		if hasAttr, hasErr := a.HasAttribute(attrName); hasErr == nil && !hasAttr {
+			return nil, errorf(ErrNotFound, "error getting attribute %s for tiledb arraySchema: %w", attrName, err)
+		}
+		return nil, fmt.Errorf("error getting attribute %s for tiledb arraySchema: %w", attrName, err)
 	}
 	return newAttributeFromHandle(a.context, newAttributeHandle(attributePtr)), nil
 }
@@ -224,7 +228,7 @@ func (a *ArraySchema) HasAttribute(attrName string) (bool, error) {
 	ret := C.tiledb_array_schema_has_attribute(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), cAttrName, &hasAttr)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return false, fmt.Errorf("error finding attribute %s in schema: %w", attrName, a.context.LastError())
+		return false, fmt.Errorf("error finding attribute %s in schema: %w", attrName, a.context.lastError("tiledb_array_schema_has_attribute", ret))
 	}
 
 	if hasAttr == 0 {
@@ -247,7 +251,7 @@ func (a *ArraySchema) SetAllowsDups(allowsDups bool) error {
 	runtime.KeepAlive(a)
 
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting allows dups for schema: %w", a.context.LastError())
+		return fmt.Errorf("error setting allows dups for schema: %w", a.context.lastError("tiledb_array_schema_set_allows_dups", ret))
 	}
 
 	return nil
@@ -260,7 +264,7 @@ func (a *ArraySchema) AllowsDups() (bool, error) {
 	ret := C.tiledb_array_schema_get_allows_dups(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), &allowsDups)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return false, fmt.Errorf("error getting allows dups for schema: %w", a.context.LastError())
+		return false, fmt.Errorf("error getting allows dups for schema: %w", a.context.lastError("tiledb_array_schema_get_allows_dups", ret))
 	}
 
 	if allowsDups == 0 {
@@ -295,7 +299,7 @@ func (a *ArraySchema) SetDomain(domain *Domain) error {
 	runtime.KeepAlive(a)
 	runtime.KeepAlive(domain)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting domain for tiledb arraySchema: %w", a.context.LastError())
+		return fmt.Errorf("error setting domain for tiledb arraySchema: %w", a.context.lastError("tiledb_array_schema_set_domain", ret))
 	}
 	return nil
 }
@@ -306,7 +310,7 @@ func (a *ArraySchema) Domain() (*Domain, error) {
 	ret := C.tiledb_array_schema_get_domain(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), &domainPtr)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error setting domain for tiledb arraySchema: %w", a.context.LastError())
+		return nil, fmt.Errorf("error setting domain for tiledb arraySchema: %w", a.context.lastError("tiledb_array_schema_get_domain", ret))
 	}
 
 	return newDomainFromHandle(a.context, newDomainHandle(domainPtr)), nil
@@ -317,7 +321,7 @@ func (a *ArraySchema) SetCapacity(capacity uint64) error {
 	ret := C.tiledb_array_schema_set_capacity(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), C.uint64_t(capacity))
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting capacity for tiledb arraySchema: %w", a.context.LastError())
+		return fmt.Errorf("error setting capacity for tiledb arraySchema: %w", a.context.lastError("tiledb_array_schema_set_capacity", ret))
 	}
 	return nil
 }
@@ -328,7 +332,7 @@ func (a *ArraySchema) Capacity() (uint64, error) {
 	ret := C.tiledb_array_schema_get_capacity(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), &capacity)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting capacity for tiledb arraySchema: %w", a.context.LastError())
+		return 0, fmt.Errorf("error getting capacity for tiledb arraySchema: %w", a.context.lastError("tiledb_array_schema_get_capacity", ret))
 	}
 	return uint64(capacity), nil
 }
@@ -338,7 +342,7 @@ func (a *ArraySchema) SetCellOrder(cellOrder Layout) error {
 	ret := C.tiledb_array_schema_set_cell_order(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), C.tiledb_layout_t(cellOrder))
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting cell order for tiledb arraySchema: %w", a.context.LastError())
+		return fmt.Errorf("error setting cell order for tiledb arraySchema: %w", a.context.lastError("tiledb_array_schema_set_cell_order", ret))
 	}
 	return nil
 }
@@ -349,7 +353,7 @@ func (a *ArraySchema) CellOrder() (Layout, error) {
 	ret := C.tiledb_array_schema_get_cell_order(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), &cellOrder)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return -1, fmt.Errorf("error getting cell order for tiledb arraySchema: %w", a.context.LastError())
+		return -1, fmt.Errorf("error getting cell order for tiledb arraySchema: %w", a.context.lastError("tiledb_array_schema_get_cell_order", ret))
 	}
 	return Layout(cellOrder), nil
 }
@@ -359,7 +363,7 @@ func (a *ArraySchema) SetTileOrder(tileOrder Layout) error {
 	ret := C.tiledb_array_schema_set_tile_order(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), C.tiledb_layout_t(tileOrder))
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting cell order for tiledb arraySchema: %w", a.context.LastError())
+		return fmt.Errorf("error setting cell order for tiledb arraySchema: %w", a.context.lastError("tiledb_array_schema_set_tile_order", ret))
 	}
 	return nil
 }
@@ -370,7 +374,7 @@ func (a *ArraySchema) TileOrder() (Layout, error) {
 	ret := C.tiledb_array_schema_get_tile_order(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), &cellOrder)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return -1, fmt.Errorf("error getting cell order for tiledb arraySchema: %w", a.context.LastError())
+		return -1, fmt.Errorf("error getting cell order for tiledb arraySchema: %w", a.context.lastError("tiledb_array_schema_get_tile_order", ret))
 	}
 	return Layout(cellOrder), nil
 }
@@ -381,7 +385,7 @@ func (a *ArraySchema) SetCoordsFilterList(filterList *FilterList) error {
 	runtime.KeepAlive(a)
 	runtime.KeepAlive(filterList)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting coordinates filter list for tiledb arraySchema: %w", a.context.LastError())
+		return fmt.Errorf("error setting coordinates filter list for tiledb arraySchema: %w", a.context.lastError("tiledb_array_schema_set_coords_filter_list", ret))
 	}
 	return nil
 }
@@ -392,7 +396,7 @@ func (a *ArraySchema) CoordsFilterList() (*FilterList, error) {
 	ret := C.tiledb_array_schema_get_coords_filter_list(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), &filterListPtr)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting coordinates filter list for tiledb arraySchema: %w", a.context.LastError())
+		return nil, fmt.Errorf("error getting coordinates filter list for tiledb arraySchema: %w", a.context.lastError("tiledb_array_schema_get_coords_filter_list", ret))
 	}
 
 	return newFilterListFromHandle(a.context, newFilterListHandle(filterListPtr)), nil
@@ -405,7 +409,7 @@ func (a *ArraySchema) SetOffsetsFilterList(filterList *FilterList) error {
 	runtime.KeepAlive(filterList)
 	ret := C.tiledb_array_schema_set_offsets_filter_list(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), filterList.tiledbFilterList.Get())
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting offsets filter list for tiledb arraySchema: %w", a.context.LastError())
+		return fmt.Errorf("error setting offsets filter list for tiledb arraySchema: %w", a.context.lastError("tiledb_array_schema_set_offsets_filter_list", ret))
 	}
 	return nil
 }
@@ -417,7 +421,7 @@ func (a *ArraySchema) OffsetsFilterList() (*FilterList, error) {
 	ret := C.tiledb_array_schema_get_offsets_filter_list(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), &filterListPtr)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting offsets filter list for tiledb arraySchema: %w", a.context.LastError())
+		return nil, fmt.Errorf("error getting offsets filter list for tiledb arraySchema: %w", a.context.lastError("tiledb_array_schema_get_offsets_filter_list", ret))
 	}
 
 	return newFilterListFromHandle(a.context, newFilterListHandle(filterListPtr)), nil
@@ -428,7 +432,7 @@ func (a *ArraySchema) Check() error {
 	ret := C.tiledb_array_schema_check(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get())
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error in checking arraySchema: %w", a.context.LastError())
+		return fmt.Errorf("error in checking arraySchema: %w", a.context.lastError("tiledb_array_schema_check", ret))
 	}
 	return nil
 }
@@ -441,7 +445,8 @@ func LoadArraySchema(context *Context, path string) (*ArraySchema, error) {
 	ret := C.tiledb_array_schema_load(context.tiledbContext.Get(), cpath, &arraySchemaPtr)
 	runtime.KeepAlive(context)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error in loading arraySchema from %s: %w", path, context.LastError())
+		err := context.lastError("tiledb_array_schema_load", ret)
+		return nil, fmt.Errorf("error in loading arraySchema from %s: %w", path, notFoundError(context, path, TILEDB_ARRAY, err))
 	}
 	return newArraySchemaFromHandle(context, newArraySchemaHandle(arraySchemaPtr)), nil
 }
@@ -452,7 +457,7 @@ func (a *ArraySchema) DumpToString() (string, error) {
 	ret := C.tiledb_array_schema_dump_str(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), &cStr)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return "", fmt.Errorf("error dumping array schema to string: %w", a.context.LastError())
+		return "", fmt.Errorf("error dumping array schema to string: %w", a.context.lastError("tiledb_array_schema_dump_str", ret))
 	}
 	defer C.tiledb_string_free(&cStr)
 
@@ -495,7 +500,7 @@ func (a *ArraySchema) Type() (ArrayType, error) {
 	ret := C.tiledb_array_schema_get_array_type(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), &arrayType)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return TILEDB_DENSE, fmt.Errorf("error fetching array schema type: %w", a.context.LastError())
+		return TILEDB_DENSE, fmt.Errorf("error fetching array schema type: %w", a.context.lastError("tiledb_array_schema_get_array_type", ret))
 	}
 
 	return ArrayType(arrayType), nil
@@ -0,0 +1,482 @@
+package tiledb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+/*
+DiffSchemas returns the ArraySchemaEvolution that evolves an array with schema oldSchema
+to newSchema. It covers the changes that schema evolution supports:
+
+  - attributes of oldSchema that are not in newSchema are dropped;
+  - attributes of newSchema that are not in oldSchema are added, along with the
+    enumerations they use that oldSchema does not have;
+  - enumerations whose values in newSchema extend their values in oldSchema are extended;
+  - enumerations that newSchema no longer uses are dropped;
+  - the current domain is expanded to the current domain of newSchema.
+
+Any other difference, such as a change of the domain, tile extent or filters of a dimension,
+of the datatype, filters or fill value of an attribute, of the cell or tile order or of the
+capacity, is reported as an error. The enumerations of both schemas must be loaded, for example
+with Array.LoadAllEnumerations, and the caller is responsible for freeing the returned
+evolution.
+*/
+func DiffSchemas(oldSchema, newSchema *ArraySchema) (*ArraySchemaEvolution, error) {
+	oldType, err := oldSchema.Type()
+	if err != nil {
+		return nil, err
+	}
+	newType, err := newSchema.Type()
+	if err != nil {
+		return nil, err
+	}
+	if oldType != newType {
+		return nil, fmt.Errorf("error diffing schemas: array type changed from %v to %v", oldType, newType)
+	}
+
+	oldDims, oldAttrs, err := schemaFieldInfos(oldSchema)
+	if err != nil {
+		return nil, err
+	}
+	newDims, newAttrs, err := schemaFieldInfos(newSchema)
+	if err != nil {
+		return nil, err
+	}
+	if !reflect.DeepEqual(oldDims, newDims) {
+		return nil, errors.New("error diffing schemas: dimensions cannot be evolved")
+	}
+
+	oldProps, err := schemaProperties(oldSchema, oldDims, oldAttrs)
+	if err != nil {
+		return nil, err
+	}
+	newProps, err := schemaProperties(newSchema, newDims, newAttrs)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(oldProps))
+	for key := range oldProps {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		// The properties of dropped attributes are not compared.
+		if newValue, ok := newProps[key]; ok && !reflect.DeepEqual(oldProps[key], newValue) {
+			return nil, fmt.Errorf("error diffing schemas: the %s changed and cannot be evolved", key)
+		}
+	}
+
+	oldEnums, err := schemaEnumerationNames(oldSchema, oldAttrs)
+	if err != nil {
+		return nil, err
+	}
+	newEnums, err := schemaEnumerationNames(newSchema, newAttrs)
+	if err != nil {
+		return nil, err
+	}
+
+	ase, err := NewArraySchemaEvolution(newSchema.context)
+	if err != nil {
+		return nil, err
+	}
+	if err := diffSchemas(ase, oldSchema, newSchema, oldAttrs, newAttrs, oldEnums, newEnums); err != nil {
+		ase.Free()
+		return nil, err
+	}
+	return ase, nil
+}
+
+func diffSchemas(ase *ArraySchemaEvolution, oldSchema, newSchema *ArraySchema, oldAttrs, newAttrs []fieldInfo, oldEnums, newEnums map[string]string) error {
+	newAttrInfo := make(map[string]fieldInfo, len(newAttrs))
+	for _, info := range newAttrs {
+		newAttrInfo[info.name] = info
+	}
+	oldAttrInfo := make(map[string]fieldInfo, len(oldAttrs))
+	for _, info := range oldAttrs {
+		oldAttrInfo[info.name] = info
+	}
+
+	// Enumerations are referenced by name, and may be shared by several attributes.
+	oldEnumNames := make(map[string]bool)
+	for _, enumName := range oldEnums {
+		oldEnumNames[enumName] = true
+	}
+	newEnumNames := make(map[string]bool)
+	for _, enumName := range newEnums {
+		newEnumNames[enumName] = true
+	}
+
+	for _, info := range oldAttrs {
+		newInfo, ok := newAttrInfo[info.name]
+		if !ok {
+			if err := ase.DropAttribute(info.name); err != nil {
+				return err
+			}
+			continue
+		}
+		if info != newInfo {
+			return fmt.Errorf("error diffing schemas: attribute %s changed and cannot be evolved", info.name)
+		}
+		if oldEnums[info.name] != newEnums[info.name] {
+			return fmt.Errorf("error diffing schemas: enumeration of attribute %s changed from %q to %q", info.name, oldEnums[info.name], newEnums[info.name])
+		}
+	}
+
+	for _, enumName := range sortedNames(newEnumNames) {
+		if !oldEnumNames[enumName] {
+			enum, err := newSchema.EnumerationFromName(enumName)
+			if err != nil {
+				return err
+			}
+			err = ase.AddEnumeration(enum)
+			enum.Free()
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if err := diffEnumerations(ase, oldSchema, newSchema, enumName); err != nil {
+			return err
+		}
+	}
+
+	for _, info := range newAttrs {
+		if _, ok := oldAttrInfo[info.name]; ok {
+			continue
+		}
+		attribute, err := newSchema.AttributeFromName(info.name)
+		if err != nil {
+			return err
+		}
+		err = ase.AddAttribute(attribute)
+		attribute.Free()
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, enumName := range sortedNames(oldEnumNames) {
+		if !newEnumNames[enumName] {
+			if err := ase.DropEnumeration(enumName); err != nil {
+				return err
+			}
+		}
+	}
+
+	return diffCurrentDomains(ase, oldSchema, newSchema)
+}
+
+// diffEnumerations extends the enumeration named enumName if its values in newSchema
+// extend its values in oldSchema.
+func diffEnumerations(ase *ArraySchemaEvolution, oldSchema, newSchema *ArraySchema, enumName string) error {
+	oldEnum, err := oldSchema.EnumerationFromName(enumName)
+	if err != nil {
+		return err
+	}
+	defer oldEnum.Free()
+	newEnum, err := newSchema.EnumerationFromName(enumName)
+	if err != nil {
+		return err
+	}
+	defer newEnum.Free()
+
+	oldOrdered, err := oldEnum.IsOrdered()
+	if err != nil {
+		return err
+	}
+	newOrdered, err := newEnum.IsOrdered()
+	if err != nil {
+		return err
+	}
+	if oldOrdered != newOrdered {
+		return fmt.Errorf("error diffing schemas: ordering of enumeration %s changed", enumName)
+	}
+
+	oldValues, err := oldEnum.Values()
+	if err != nil {
+		return err
+	}
+	newValues, err := newEnum.Values()
+	if err != nil {
+		return err
+	}
+	oldSlice, newSlice := reflect.ValueOf(oldValues), reflect.ValueOf(newValues)
+	if oldSlice.Type() != newSlice.Type() || oldSlice.Len() > newSlice.Len() ||
+		!reflect.DeepEqual(oldValues, newSlice.Slice(0, oldSlice.Len()).Interface()) {
+		return fmt.Errorf("error diffing schemas: values of enumeration %s do not extend the existing values", enumName)
+	}
+	if oldSlice.Len() == newSlice.Len() {
+		return nil
+	}
+
+	return ase.ApplyExtendedEnumeration(newEnum)
+}
+
+// diffCurrentDomains expands the current domain to the current domain of newSchema if they differ.
+func diffCurrentDomains(ase *ArraySchemaEvolution, oldSchema, newSchema *ArraySchema) error {
+	oldRanges, err := currentDomainRanges(oldSchema)
+	if err != nil {
+		return err
+	}
+	newRanges, err := currentDomainRanges(newSchema)
+	if err != nil {
+		return err
+	}
+	if newRanges == nil {
+		if oldRanges != nil {
+			return errors.New("error diffing schemas: the current domain cannot be removed")
+		}
+		return nil
+	}
+	if reflect.DeepEqual(oldRanges, newRanges) {
+		return nil
+	}
+
+	cd, err := newSchema.CurrentDomain()
+	if err != nil {
+		return err
+	}
+	defer cd.Free()
+	return ase.ExpandCurrentDomain(cd)
+}
+
+// currentDomainRanges returns the ranges of the current domain of the schema, or nil if it is empty.
+func currentDomainRanges(schema *ArraySchema) ([]Range, error) {
+	cd, err := schema.CurrentDomain()
+	if err != nil {
+		return nil, err
+	}
+	defer cd.Free()
+
+	isEmpty, err := cd.IsEmpty()
+	if err != nil || isEmpty {
+		return nil, err
+	}
+
+	ndr, err := cd.NDRectangle()
+	if err != nil {
+		return nil, err
+	}
+	defer ndr.Free()
+
+	ndim, err := ndr.NDim()
+	if err != nil {
+		return nil, err
+	}
+	ranges := make([]Range, ndim)
+	for i := range ranges {
+		if ranges[i], err = ndr.Range(uint32(i)); err != nil {
+			return nil, err
+		}
+	}
+	return ranges, nil
+}
+
+// schemaProperties returns the properties of the schema and of its fields that schema evolution
+// cannot change, by description.
+func schemaProperties(schema *ArraySchema, dims, attrs []fieldInfo) (map[string]any, error) {
+	props := make(map[string]any)
+	var err error
+	if props["cell order"], err = schema.CellOrder(); err != nil {
+		return nil, err
+	}
+	if props["tile order"], err = schema.TileOrder(); err != nil {
+		return nil, err
+	}
+	if props["capacity"], err = schema.Capacity(); err != nil {
+		return nil, err
+	}
+	if props["duplicates allowance"], err = schema.AllowsDups(); err != nil {
+		return nil, err
+	}
+	coordsFilters, err := schema.CoordsFilterList()
+	if err != nil {
+		return nil, err
+	}
+	props["coordinates filters"], err = filterListProperties(coordsFilters)
+	coordsFilters.Free()
+	if err != nil {
+		return nil, err
+	}
+	offsetsFilters, err := schema.OffsetsFilterList()
+	if err != nil {
+		return nil, err
+	}
+	props["offsets filters"], err = filterListProperties(offsetsFilters)
+	offsetsFilters.Free()
+	if err != nil {
+		return nil, err
+	}
+
+	domain, err := schema.Domain()
+	if err != nil {
+		return nil, err
+	}
+	defer domain.Free()
+	for _, info := range dims {
+		dimension, err := domain.DimensionFromName(info.name)
+		if err != nil {
+			return nil, err
+		}
+		err = dimensionProperties(props, dimension, info.name)
+		dimension.Free()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, info := range attrs {
+		attribute, err := schema.AttributeFromName(info.name)
+		if err != nil {
+			return nil, err
+		}
+		err = attributeProperties(props, attribute, info)
+		attribute.Free()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return props, nil
+}
+
+// dimensionProperties adds the domain, tile extent and filters of the dimension to props.
+func dimensionProperties(props map[string]any, dimension *Dimension, name string) error {
+	var err error
+	if props["domain of dimension "+name], err = dimension.Domain(); err != nil {
+		return err
+	}
+	if props["tile extent of dimension "+name], err = dimension.Extent(); err != nil {
+		return err
+	}
+	filterList, err := dimension.FilterList()
+	if err != nil {
+		return err
+	}
+	defer filterList.Free()
+	props["filters of dimension "+name], err = filterListProperties(filterList)
+	return err
+}
+
+// attributeProperties adds the filters and the fill value of the attribute to props.
+func attributeProperties(props map[string]any, attribute *Attribute, info fieldInfo) error {
+	filterList, err := attribute.FilterList()
+	if err != nil {
+		return err
+	}
+	defer filterList.Free()
+	if props["filters of attribute "+info.name], err = filterListProperties(filterList); err != nil {
+		return err
+	}
+
+	if info.nullable {
+		value, size, valid, err := attribute.GetFillValueNullable()
+		if err != nil {
+			return err
+		}
+		props["fill value of attribute "+info.name] = []any{value, size, valid}
+		return nil
+	}
+	value, size, err := attribute.GetFillValue()
+	if err != nil {
+		return err
+	}
+	props["fill value of attribute "+info.name] = []any{value, size}
+	return nil
+}
+
+// filterListProperties returns the maximum chunk size of the filter list, followed by the type
+// and the options of each filter.
+func filterListProperties(filterList *FilterList) ([]any, error) {
+	maxChunkSize, err := filterList.MaxChunkSize()
+	if err != nil {
+		return nil, err
+	}
+	props := []any{maxChunkSize}
+
+	filters, err := filterList.Filters()
+	for _, filter := range filters {
+		defer filter.Free()
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, filter := range filters {
+		filterType, err := filter.Type()
+		if err != nil {
+			return nil, err
+		}
+		props = append(props, filterType)
+		for _, filterOption := range []FilterOption{
+			TILEDB_COMPRESSION_LEVEL, TILEDB_COMPRESSION_REINTERPRET_DATATYPE,
+			TILEDB_BIT_WIDTH_MAX_WINDOW, TILEDB_POSITIVE_DELTA_MAX_WINDOW,
+			TILEDB_SCALE_FLOAT_BYTEWIDTH, TILEDB_SCALE_FLOAT_FACTOR, TILEDB_SCALE_FLOAT_OFFSET,
+			TILEDB_WEBP_QUALITY, TILEDB_WEBP_INPUT_FORMAT, TILEDB_WEBP_LOSSLESS,
+		} {
+			if !filterAcceptsOption(filterType, filterOption) {
+				continue
+			}
+			value, err := filter.Option(filterOption)
+			if err != nil {
+				return nil, err
+			}
+			props = append(props, value)
+		}
+	}
+	return props, nil
+}
+
+// schemaFieldInfos returns the fieldInfo of the dimensions and of the attributes of the schema.
+func schemaFieldInfos(schema *ArraySchema) ([]fieldInfo, []fieldInfo, error) {
+	names, err := schemaFieldNames(schema)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var dims, attrs []fieldInfo
+	for _, name := range names {
+		info, err := schemaFieldInfo(schema, name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if info.isDimension {
+			dims = append(dims, info)
+		} else {
+			attrs = append(attrs, info)
+		}
+	}
+	return dims, attrs, nil
+}
+
+// schemaEnumerationNames returns the enumeration names of the attributes that have one, by attribute name.
+func schemaEnumerationNames(schema *ArraySchema, attrs []fieldInfo) (map[string]string, error) {
+	enums := make(map[string]string)
+	for _, info := range attrs {
+		attribute, err := schema.AttributeFromName(info.name)
+		if err != nil {
+			return nil, err
+		}
+		enumName, err := attribute.GetEnumerationName()
+		attribute.Free()
+		if err != nil {
+			return nil, err
+		}
+		if enumName != "" {
+			enums[info.name] = enumName
+		}
+	}
+	return enums, nil
+}
+
+// sortedNames returns the names of the set in increasing order, so that schemas are evolved
+// in the same order every time.
+func sortedNames(set map[string]bool) []string {
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
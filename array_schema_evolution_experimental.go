@@ -9,7 +9,10 @@ import "C"
 import (
 	"errors"
 	"fmt"
+	"math"
 	"runtime"
+	"slices"
+	"strings"
 	"unsafe"
 )
 
@@ -45,7 +48,7 @@ func NewArraySchemaEvolution(tdbCtx *Context) (*ArraySchemaEvolution, error) {
 	runtime.KeepAlive(tdbCtx)
 	if ret != C.TILEDB_OK {
 		return nil, fmt.Errorf("error creating tiledb arraySchemaEvolution: %w",
-			tdbCtx.LastError())
+			tdbCtx.lastError("tiledb_array_schema_evolution_alloc", ret))
 	}
 
 	return newArraySchemaEvolutionFromHandle(tdbCtx, newArraySchemaEvolutionHandle(arraySchemaEvolutionPtr)), nil
@@ -80,7 +83,7 @@ func (ase *ArraySchemaEvolution) AddAttribute(attribute *Attribute) error {
 	if ret != C.TILEDB_OK {
 		return fmt.Errorf(
 			"error adding attribute %s to tiledb arraySchemaEvolution: %w",
-			name, ase.context.LastError())
+			name, ase.context.lastError("tiledb_array_schema_evolution_add_attribute", ret))
 	}
 
 	return nil
@@ -96,7 +99,7 @@ func (ase *ArraySchemaEvolution) DropAttribute(name string) error {
 	runtime.KeepAlive(ase)
 	if ret != C.TILEDB_OK {
 		return fmt.Errorf("error dropping tiledb attribute: %w",
-			ase.context.LastError())
+			ase.context.lastError("tiledb_array_schema_evolution_drop_attribute", ret))
 	}
 
 	return nil
@@ -112,8 +115,163 @@ func (ase *ArraySchemaEvolution) Evolve(uri string) error {
 	runtime.KeepAlive(ase)
 	if ret != C.TILEDB_OK {
 		return fmt.Errorf("error evolving schema for array %s: %w", uri,
-			ase.context.LastError())
+			ase.context.lastError("tiledb_array_evolve", ret))
 	}
 
 	return nil
 }
+
+// ExpandCurrentDomain expands the current domain of the array to cd. The new current
+// domain must contain the existing one and be within the domain of the array.
+func (ase *ArraySchemaEvolution) ExpandCurrentDomain(cd *CurrentDomain) error {
+	ret := C.tiledb_array_schema_evolution_expand_current_domain(
+		ase.context.tiledbContext.Get(), ase.tiledbArraySchemaEvolution.Get(),
+		cd.currentDomain.Get())
+	runtime.KeepAlive(ase)
+	runtime.KeepAlive(cd)
+	if ret != C.TILEDB_OK {
+		return fmt.Errorf("error expanding current domain: %w",
+			ase.context.lastError("tiledb_array_schema_evolution_expand_current_domain", ret))
+	}
+
+	return nil
+}
+
+// SetTimestampRange sets the timestamp range of the schema produced by the evolution.
+// Both ends are in milliseconds since the Unix epoch. Arrays opened at an end timestamp
+// earlier than lo keep using the previous schema.
+func (ase *ArraySchemaEvolution) SetTimestampRange(lo, hi uint64) error {
+	ret := C.tiledb_array_schema_evolution_set_timestamp_range(
+		ase.context.tiledbContext.Get(), ase.tiledbArraySchemaEvolution.Get(),
+		C.uint64_t(lo), C.uint64_t(hi))
+	runtime.KeepAlive(ase)
+	if ret != C.TILEDB_OK {
+		return fmt.Errorf("error setting timestamp range of arraySchemaEvolution: %w",
+			ase.context.lastError("tiledb_array_schema_evolution_set_timestamp_range", ret))
+	}
+
+	return nil
+}
+
+// EvolveSchema evolves the schema of the array at timestamp, in milliseconds since the
+// Unix epoch. If the array is open, it is reopened with the same query type and start
+// timestamp, and an end timestamp no earlier than timestamp, so that it uses the
+// evolved schema.
+func (a *Array) EvolveSchema(ase *ArraySchemaEvolution, timestamp uint64) error {
+	uri, err := a.URI()
+	if err != nil {
+		return err
+	}
+
+	isOpen, err := a.IsOpen()
+	if err != nil {
+		return err
+	}
+	var queryType QueryType
+	var start, end uint64
+	if isOpen {
+		if queryType, err = a.QueryType(); err != nil {
+			return err
+		}
+		if start, err = a.OpenStartTimestamp(); err != nil {
+			return err
+		}
+		if end, err = a.OpenEndTimestamp(); err != nil {
+			return err
+		}
+	}
+
+	if err := ase.SetTimestampRange(timestamp, timestamp); err != nil {
+		return err
+	}
+	if err := ase.Evolve(uri); err != nil {
+		return err
+	}
+
+	if !isOpen {
+		return nil
+	}
+	if err := a.Close(); err != nil {
+		return err
+	}
+	return a.OpenWithOptions(queryType, WithStartTimestamp(start), WithEndTimestamp(max(end, timestamp)))
+}
+
+// SchemaHistory returns every schema the array has had, from the oldest to the current
+// one. Starting from the current schema, each schema is loaded by opening the array just
+// before the start of the timestamp range of the following one, with the enumerations of
+// all the schemas loaded, until TileDB reports that the array did not exist yet. The
+// array does not need to be open. The caller is responsible for freeing the returned schemas.
+func (a *Array) SchemaHistory() ([]*ArraySchema, error) {
+	uri, err := a.URI()
+	if err != nil {
+		return nil, err
+	}
+
+	var schemas []*ArraySchema
+	freeSchemas := func() {
+		for _, schema := range schemas {
+			schema.Free()
+		}
+	}
+	// TileDB opens arrays at the current time for the maximum timestamp, which would miss
+	// schemas evolved in the future.
+	timestamp := uint64(math.MaxUint64 - 1)
+	for {
+		schema, err := loadArraySchemaAt(a.context, uri, timestamp)
+		if err != nil {
+			if len(schemas) > 0 && isNoSchemaError(err) {
+				// No schema precedes the oldest one.
+				break
+			}
+			freeSchemas()
+			return nil, err
+		}
+		lo, hi, err := schema.TimestampRange()
+		if err != nil {
+			schema.Free()
+			freeSchemas()
+			return nil, err
+		}
+		if len(schemas) > 0 {
+			if _, nextHi, _ := schemas[len(schemas)-1].TimestampRange(); nextHi == hi {
+				schema.Free()
+				break
+			}
+		}
+		schemas = append(schemas, schema)
+		if lo == 0 {
+			break
+		}
+		timestamp = lo - 1
+	}
+
+	slices.Reverse(schemas)
+	return schemas, nil
+}
+
+// isNoSchemaError returns whether err is the error TileDB reports when opening an existing
+// array at a timestamp before its oldest schema, as if the array did not exist yet.
+func isNoSchemaError(err error) bool {
+	var tdbErr *Error
+	return errors.As(err, &tdbErr) && tdbErr.Op == "tiledb_array_open" && strings.Contains(tdbErr.Message, "does not exist")
+}
+
+// loadArraySchemaAt returns the schema of the array at uri as of timestamp.
+func loadArraySchemaAt(tdbCtx *Context, uri string, timestamp uint64) (*ArraySchema, error) {
+	array, err := NewArray(tdbCtx, uri)
+	if err != nil {
+		return nil, err
+	}
+	defer array.Free()
+
+	if err := array.OpenWithOptions(TILEDB_READ, WithEndTimestamp(timestamp)); err != nil {
+		return nil, err
+	}
+	defer array.Close()
+
+	if err := array.LoadEnumerationsAllSchemas(); err != nil {
+		return nil, err
+	}
+	return array.Schema()
+}
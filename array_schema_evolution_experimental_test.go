@@ -1,7 +1,10 @@
 package tiledb
 
 import (
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -136,3 +139,219 @@ func TestArraySchemaEvolution(t *testing.T) {
 
 	require.NoError(t, arr.Close())
 }
+
+// buildEvolutionTestSchema builds a sparse schema with an int32 dimension d, the
+// given int32 attributes and a uint8 attribute color using the colors enumeration.
+func buildEvolutionTestSchema(t *testing.T, context *Context, colors []string, attrs ...string) *ArraySchema {
+	dimension, err := NewDimension(context, "d", TILEDB_INT32, []int32{1, 100}, int32(10))
+	require.NoError(t, err)
+	return buildEvolutionTestSchemaWithDimension(t, context, dimension, colors, attrs...)
+}
+
+func buildEvolutionTestSchemaWithDimension(t *testing.T, context *Context, dimension *Dimension, colors []string, attrs ...string) *ArraySchema {
+	domain, err := NewDomain(context)
+	require.NoError(t, err)
+	require.NoError(t, domain.AddDimensions(dimension))
+
+	schema, err := NewArraySchema(context, TILEDB_SPARSE)
+	require.NoError(t, err)
+	require.NoError(t, schema.SetDomain(domain))
+
+	enum, err := NewOrderedEnumeration(context, "colors", colors)
+	require.NoError(t, err)
+	require.NoError(t, schema.AddEnumeration(enum))
+	color, err := NewAttribute(context, "color", TILEDB_UINT8)
+	require.NoError(t, err)
+	require.NoError(t, color.SetEnumerationName("colors"))
+	require.NoError(t, schema.AddAttributes(color))
+
+	for _, name := range attrs {
+		attribute, err := NewAttribute(context, name, TILEDB_INT32)
+		require.NoError(t, err)
+		require.NoError(t, schema.AddAttributes(attribute))
+	}
+	return schema
+}
+
+func TestArraySchemaEvolutionTimestamps(t *testing.T) {
+	context, err := NewContext(nil)
+	require.NoError(t, err)
+
+	uri := t.TempDir()
+	require.NoError(t, CreateArray(context, uri, buildEvolutionTestSchema(t, context, []string{"red"}, "a1")))
+
+	array, err := NewArray(context, uri)
+	require.NoError(t, err)
+	defer array.Free()
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+
+	// Evolve the open array in the future; it is reopened to see the new schema.
+	evolvedAt := uint64(time.Now().UnixMilli()) + 10_000
+	evolution, err := NewArraySchemaEvolution(context)
+	require.NoError(t, err)
+	a2, err := NewAttribute(context, "a2", TILEDB_INT32)
+	require.NoError(t, err)
+	require.NoError(t, evolution.AddAttribute(a2))
+	require.NoError(t, array.EvolveSchema(evolution, evolvedAt))
+
+	isOpen, err := array.IsOpen()
+	require.NoError(t, err)
+	assert.True(t, isOpen)
+	schema, err := array.Schema()
+	require.NoError(t, err)
+	hasAttr, err := schema.HasAttribute("a2")
+	require.NoError(t, err)
+	assert.True(t, hasAttr)
+
+	// The previous schema is still used before the evolution.
+	before, err := NewArray(context, uri)
+	require.NoError(t, err)
+	defer before.Free()
+	require.NoError(t, before.OpenWithOptions(TILEDB_READ, WithEndTimestamp(evolvedAt-1)))
+	defer before.Close()
+	beforeSchema, err := before.Schema()
+	require.NoError(t, err)
+	hasAttr, err = beforeSchema.HasAttribute("a2")
+	require.NoError(t, err)
+	assert.False(t, hasAttr)
+
+	history, err := array.SchemaHistory()
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	for i, expected := range []bool{false, true} {
+		hasAttr, err := history[i].HasAttribute("a2")
+		require.NoError(t, err)
+		assert.Equal(t, expected, hasAttr)
+		history[i].Free()
+	}
+}
+
+func TestIsNoSchemaError(t *testing.T) {
+	noSchema := &Error{Code: TILEDB_ERR, Op: "tiledb_array_open", Message: "[TileDB::ArrayDirectory] Error: Cannot open array; Array does not exist."}
+	assert.True(t, isNoSchemaError(fmt.Errorf("error opening tiledb array for querying: %w", noSchema)))
+	assert.False(t, isNoSchemaError(&Error{Code: TILEDB_ERR, Op: "tiledb_array_open", Message: "[TileDB::S3] Error: connection reset"}))
+	assert.False(t, isNoSchemaError(errors.New("array does not exist")))
+}
+
+func TestDiffSchemas(t *testing.T) {
+	context, err := NewContext(nil)
+	require.NoError(t, err)
+
+	uri := t.TempDir()
+	require.NoError(t, CreateArray(context, uri, buildEvolutionTestSchema(t, context, []string{"red", "green"}, "a1", "a2")))
+
+	array, err := NewArray(context, uri)
+	require.NoError(t, err)
+	defer array.Free()
+	require.NoError(t, array.Open(TILEDB_READ))
+	require.NoError(t, array.LoadAllEnumerations())
+	oldSchema, err := array.Schema()
+	require.NoError(t, err)
+	require.NoError(t, array.Close())
+
+	newSchema := buildEvolutionTestSchema(t, context, []string{"red", "green", "blue"}, "a1", "a3")
+	evolution, err := DiffSchemas(oldSchema, newSchema)
+	require.NoError(t, err)
+	defer evolution.Free()
+	require.NoError(t, evolution.Evolve(uri))
+
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+	require.NoError(t, array.LoadAllEnumerations())
+	evolvedSchema, err := array.Schema()
+	require.NoError(t, err)
+
+	names, err := schemaFieldNames(evolvedSchema)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"d", "color", "a1", "a3"}, names)
+
+	colors, err := evolvedSchema.EnumerationFromName("colors")
+	require.NoError(t, err)
+	values, err := colors.Values()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"red", "green", "blue"}, values)
+
+	// The evolved schema only differs from the new one by the timestamp range.
+	_, err = DiffSchemas(evolvedSchema, newSchema)
+	require.NoError(t, err)
+
+	t.Run("Errors", func(t *testing.T) {
+		// Enumeration values can only be appended.
+		_, err := DiffSchemas(newSchema, buildEvolutionTestSchema(t, context, []string{"blue"}, "a1", "a3"))
+		assert.Error(t, err)
+
+		changed := buildEvolutionTestSchema(t, context, []string{"red", "green", "blue"}, "a3")
+		a1, err := NewAttribute(context, "a1", TILEDB_INT64)
+		require.NoError(t, err)
+		require.NoError(t, changed.AddAttributes(a1))
+		_, err = DiffSchemas(newSchema, changed)
+		assert.Error(t, err)
+
+		// Only attributes, enumerations and the current domain can be evolved.
+		colors := []string{"red", "green", "blue"}
+		zstd := func() *FilterList {
+			filter, err := NewZstdFilter(context, 7)
+			require.NoError(t, err)
+			filterList, err := NewFilterList(context)
+			require.NoError(t, err)
+			require.NoError(t, filterList.AddFilter(filter))
+			return filterList
+		}
+		for _, change := range []struct {
+			property string
+			build    func() *ArraySchema
+		}{
+			{"domain of dimension d", func() *ArraySchema {
+				dimension, err := NewDimension(context, "d", TILEDB_INT32, []int32{1, 200}, int32(10))
+				require.NoError(t, err)
+				return buildEvolutionTestSchemaWithDimension(t, context, dimension, colors, "a1", "a3")
+			}},
+			{"tile extent of dimension d", func() *ArraySchema {
+				dimension, err := NewDimension(context, "d", TILEDB_INT32, []int32{1, 100}, int32(20))
+				require.NoError(t, err)
+				return buildEvolutionTestSchemaWithDimension(t, context, dimension, colors, "a1", "a3")
+			}},
+			{"filters of dimension d", func() *ArraySchema {
+				dimension, err := NewDimension(context, "d", TILEDB_INT32, []int32{1, 100}, int32(10))
+				require.NoError(t, err)
+				require.NoError(t, dimension.SetFilterList(zstd()))
+				return buildEvolutionTestSchemaWithDimension(t, context, dimension, colors, "a1", "a3")
+			}},
+			{"filters of attribute a1", func() *ArraySchema {
+				schema := buildEvolutionTestSchema(t, context, colors, "a3")
+				a1, err := NewAttribute(context, "a1", TILEDB_INT32)
+				require.NoError(t, err)
+				require.NoError(t, a1.SetFilterList(zstd()))
+				require.NoError(t, schema.AddAttributes(a1))
+				return schema
+			}},
+			{"fill value of attribute a1", func() *ArraySchema {
+				schema := buildEvolutionTestSchema(t, context, colors, "a3")
+				a1, err := NewAttribute(context, "a1", TILEDB_INT32)
+				require.NoError(t, err)
+				require.NoError(t, a1.SetFillValue(int32(7)))
+				require.NoError(t, schema.AddAttributes(a1))
+				return schema
+			}},
+			{"cell order", func() *ArraySchema {
+				schema := buildEvolutionTestSchema(t, context, colors, "a1", "a3")
+				require.NoError(t, schema.SetCellOrder(TILEDB_COL_MAJOR))
+				return schema
+			}},
+			{"tile order", func() *ArraySchema {
+				schema := buildEvolutionTestSchema(t, context, colors, "a1", "a3")
+				require.NoError(t, schema.SetTileOrder(TILEDB_COL_MAJOR))
+				return schema
+			}},
+			{"capacity", func() *ArraySchema {
+				schema := buildEvolutionTestSchema(t, context, colors, "a1", "a3")
+				require.NoError(t, schema.SetCapacity(42))
+				return schema
+			}},
+		} {
+			_, err := DiffSchemas(newSchema, change.build())
+			assert.ErrorContains(t, err, "the "+change.property+" changed", change.property)
+		}
+	})
+}
@@ -18,7 +18,30 @@ func (a *ArraySchema) TimestampRange() (uint64, uint64, error) {
 	ret := C.tiledb_array_schema_timestamp_range(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), &lo, &hi)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return 0, 0, fmt.Errorf("error getting timestamp range: %w", a.context.LastError())
+		return 0, 0, fmt.Errorf("error getting timestamp range: %w", a.context.lastError("tiledb_array_schema_timestamp_range", ret))
 	}
 	return uint64(lo), uint64(hi), nil
 }
+
+// SetCurrentDomain sets the current domain of the array schema.
+func (a *ArraySchema) SetCurrentDomain(cd *CurrentDomain) error {
+	ret := C.tiledb_array_schema_set_current_domain(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), cd.currentDomain.Get())
+	runtime.KeepAlive(a)
+	runtime.KeepAlive(cd)
+	if ret != C.TILEDB_OK {
+		return fmt.Errorf("error setting current domain: %w", a.context.lastError("tiledb_array_schema_set_current_domain", ret))
+	}
+	return nil
+}
+
+// CurrentDomain gets the current domain of the array schema. The current domain is empty
+// if none was set.
+func (a *ArraySchema) CurrentDomain() (*CurrentDomain, error) {
+	var cdPtr *C.tiledb_current_domain_t
+	ret := C.tiledb_array_schema_get_current_domain(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), &cdPtr)
+	runtime.KeepAlive(a)
+	if ret != C.TILEDB_OK {
+		return nil, fmt.Errorf("error getting current domain: %w", a.context.lastError("tiledb_array_schema_get_current_domain", ret))
+	}
+	return newCurrentDomainFromHandle(a.context, newCurrentDomainHandle(cdPtr)), nil
+}
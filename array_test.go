@@ -409,6 +409,31 @@ func TestArray_Metadata(t *testing.T) {
 		assert.EqualValues(t, 1, valNum)
 		assert.Equal(t, "", value.(string))
 	})
+
+	t.Run("times", func(t *testing.T) {
+		a, err := newTestArray(t)
+		require.NoError(t, err)
+		then := time.Date(2024, 3, 15, 12, 30, 45, 123456789, time.UTC)
+		times := []time.Time{then, then.Add(time.Hour)}
+		require.NoError(t, a.Open(TILEDB_WRITE))
+		require.NoError(t, a.PutMetadata(testKey, then))
+		require.NoError(t, a.PutMetadata("times", times))
+		require.NoError(t, a.Close())
+		require.NoError(t, a.Open(TILEDB_READ))
+		defer a.Close()
+
+		dataType, valNum, value, err := a.GetMetadata(testKey)
+		require.NoError(t, err)
+		assert.Equal(t, TILEDB_DATETIME_NS, dataType)
+		assert.EqualValues(t, 1, valNum)
+		assert.Equal(t, then, value)
+
+		dataType, valNum, value, err = a.GetMetadata("times")
+		require.NoError(t, err)
+		assert.Equal(t, TILEDB_DATETIME_NS, dataType)
+		assert.EqualValues(t, 2, valNum)
+		assert.Equal(t, times, value)
+	})
 }
 
 func TestDeleteFragments(t *testing.T) {
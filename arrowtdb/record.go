@@ -0,0 +1,401 @@
+package arrowtdb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/bitutil"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	tiledb "github.com/TileDB-Inc/TileDB-Go"
+)
+
+// sliceBytes returns the memory of a slice as a []byte without copying.
+func sliceBytes(slice interface{}) []byte {
+	v := reflect.ValueOf(slice)
+	if v.Len() == 0 {
+		return []byte{}
+	}
+	return unsafe.Slice((*byte)(v.UnsafePointer()), v.Len()*int(v.Type().Elem().Size()))
+}
+
+// nullBitmap packs TileDB validity values into an Arrow null bitmap and returns it with the null count.
+func nullBitmap(validity []uint8, n int) (*memory.Buffer, int) {
+	if validity == nil {
+		return nil, 0
+	}
+	bitmap := make([]byte, bitutil.BytesForBits(int64(n)))
+	nulls := 0
+	for i := 0; i < n; i++ {
+		if validity[i] != 0 {
+			bitutil.SetBit(bitmap, i)
+		} else {
+			nulls++
+		}
+	}
+	return memory.NewBufferBytes(bitmap), nulls
+}
+
+// column wraps the results of a field of a batch in an Arrow array. The data and, for
+// strings and binaries, the offsets are shared with the batch.
+func column(info fieldInfo, dt arrow.DataType, batch *tiledb.ResultBatch, n int) arrow.Array {
+	bitmap, nulls := nullBitmap(batch.Validity(info.name), n)
+	dataBytes := sliceBytes(batch.Data(info.name))
+
+	var data *array.Data
+	switch dt := dt.(type) {
+	case *arrow.BooleanType:
+		bools := batch.Data(info.name).([]bool)
+		bits := make([]byte, bitutil.BytesForBits(int64(n)))
+		for i := 0; i < n; i++ {
+			if bools[i] {
+				bitutil.SetBit(bits, i)
+			}
+		}
+		data = array.NewData(dt, n, []*memory.Buffer{bitmap, memory.NewBufferBytes(bits)}, nil, nulls, 0)
+	case *arrow.LargeStringType, *arrow.LargeBinaryType:
+		// TileDB offsets are byte offsets without the final end offset, which fits
+		// in the spare capacity of the offsets buffer.
+		offsets := append(batch.Offsets(info.name)[:n], uint64(len(dataBytes)))
+		data = array.NewData(dt, n, []*memory.Buffer{bitmap, memory.NewBufferBytes(sliceBytes(offsets)), memory.NewBufferBytes(dataBytes)}, nil, nulls, 0)
+	case *arrow.LargeListType:
+		// Arrow list offsets count elements rather than bytes, so they are converted in place.
+		size := info.datatype.Size()
+		offsets := append(batch.Offsets(info.name)[:n], uint64(len(dataBytes)))
+		for i := range offsets {
+			offsets[i] /= size
+		}
+		values := array.NewData(dt.Elem(), len(dataBytes)/int(size), []*memory.Buffer{nil, memory.NewBufferBytes(dataBytes)}, nil, 0, 0)
+		defer values.Release()
+		data = array.NewData(dt, n, []*memory.Buffer{bitmap, memory.NewBufferBytes(sliceBytes(offsets))}, []arrow.ArrayData{values}, nulls, 0)
+	case *arrow.FixedSizeListType:
+		values := array.NewData(dt.Elem(), n*int(dt.Len()), []*memory.Buffer{nil, memory.NewBufferBytes(dataBytes)}, nil, 0, 0)
+		defer values.Release()
+		data = array.NewData(dt, n, []*memory.Buffer{bitmap}, []arrow.ArrayData{values}, nulls, 0)
+	default:
+		data = array.NewData(dt, n, []*memory.Buffer{bitmap, memory.NewBufferBytes(dataBytes)}, nil, nulls, 0)
+	}
+	defer data.Release()
+
+	return array.MakeFromData(data)
+}
+
+/*
+RecordReader reads the results of a query as Arrow records, one per submission of the
+query. It implements array.RecordReader over a tiledb.ResultIterator, which handles
+TILEDB_INCOMPLETE statuses and grows the buffers when a single cell does not fit.
+
+Every record takes over the buffers its results were read into, so its data and, for
+strings and binaries, its offsets are shared with TileDB rather than copied, and it remains
+valid after later calls to Next once retained. Validity values and booleans are repacked as
+bitmaps.
+
+	reader, err := arrowtdb.NewRecordReader(query, nil)
+	if err != nil {
+		return err
+	}
+	defer reader.Release()
+	for reader.Next() {
+		process(reader.Record())
+	}
+	if err := reader.Err(); err != nil {
+		return err
+	}
+*/
+type RecordReader struct {
+	refCount atomic.Int64
+	it       *tiledb.ResultIterator
+	fields   []fieldInfo
+	schema   *arrow.Schema
+	rec      arrow.Record
+	err      error
+}
+
+var _ array.RecordReader = (*RecordReader)(nil)
+
+// NewRecordReader returns a RecordReader of the given dimensions and attributes of the
+// query, mapped as described in Schema. If no field names are given, all dimensions and
+// attributes of the array are read. opts configure the underlying ResultIterator. The
+// layout, subarray and query condition must be set on the query beforehand, and the query
+// must not be used otherwise until the reader is released.
+func NewRecordReader(query *tiledb.Query, fieldNames []string, opts ...tiledb.ResultIteratorOption) (*RecordReader, error) {
+	arr, err := query.Array()
+	if err != nil {
+		return nil, err
+	}
+	schema, err := arr.Schema()
+	if err != nil {
+		return nil, fmt.Errorf("could not get array schema for RecordReader: %w", err)
+	}
+	defer schema.Free()
+
+	var infos []fieldInfo
+	if len(fieldNames) == 0 {
+		if infos, err = schemaFields(schema); err != nil {
+			return nil, fmt.Errorf("could not get fields for RecordReader: %w", err)
+		}
+	}
+	for _, name := range fieldNames {
+		info, err := schemaField(schema, name)
+		if err != nil {
+			return nil, fmt.Errorf("could not get field %s for RecordReader: %w", name, err)
+		}
+		infos = append(infos, info)
+	}
+
+	names := make([]string, 0, len(infos))
+	fields := make([]arrow.Field, 0, len(infos))
+	for _, info := range infos {
+		f, err := field(info)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, info.name)
+		fields = append(fields, f)
+	}
+
+	it, err := query.Iterate(names, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &RecordReader{it: it, fields: infos, schema: arrow.NewSchema(fields, nil)}
+	r.refCount.Store(1)
+	return r, nil
+}
+
+// Retain increases the reference count of the reader.
+func (r *RecordReader) Retain() {
+	r.refCount.Add(1)
+}
+
+// Release decreases the reference count of the reader. When it reaches zero, the current
+// record and the buffers of the underlying ResultIterator are released.
+func (r *RecordReader) Release() {
+	if r.refCount.Add(-1) == 0 {
+		if r.rec != nil {
+			r.rec.Release()
+			r.rec = nil
+		}
+		r.it.Release()
+	}
+}
+
+// Schema returns the Arrow schema of the records.
+func (r *RecordReader) Schema() *arrow.Schema {
+	return r.schema
+}
+
+// Next reads the next record. It returns false when there are no more records or an error
+// occurred; use Err to distinguish the two.
+func (r *RecordReader) Next() bool {
+	if r.rec != nil {
+		r.rec.Release()
+		r.rec = nil
+	}
+	if r.err != nil || !r.it.Next() {
+		if r.err == nil {
+			r.err = r.it.Err()
+		}
+		return false
+	}
+
+	batch, err := r.it.DetachBatch()
+	if err != nil {
+		r.err = err
+		return false
+	}
+
+	n := int(batch.NumCells())
+	columns := make([]arrow.Array, 0, len(r.fields))
+	for i, info := range r.fields {
+		columns = append(columns, column(info, r.schema.Field(i).Type, batch, n))
+	}
+	r.rec = array.NewRecord(r.schema, columns, int64(n))
+	for _, c := range columns {
+		c.Release()
+	}
+	return true
+}
+
+// Record returns the record read by the last call to Next. It is released by the next call
+// to Next unless it is retained.
+func (r *RecordReader) Record() arrow.Record {
+	return r.rec
+}
+
+// Err returns the error, if any, that stopped the reader.
+func (r *RecordReader) Err() error {
+	return r.err
+}
+
+// typeCompatible returns whether an Arrow array of type actual can be written to a field of type expected.
+func typeCompatible(expected, actual arrow.DataType) bool {
+	switch {
+	case arrow.TypeEqual(expected, actual):
+		return true
+	case expected.ID() == arrow.LARGE_STRING:
+		return actual.ID() == arrow.STRING
+	case expected.ID() == arrow.LARGE_BINARY:
+		return actual.ID() == arrow.BINARY
+	case expected.ID() == arrow.TIMESTAMP && actual.ID() == arrow.TIMESTAMP:
+		// TileDB datetimes carry no time zone.
+		return expected.(*arrow.TimestampType).Unit == actual.(*arrow.TimestampType).Unit
+	case expected.ID() == arrow.LARGE_LIST && actual.ID() == arrow.LARGE_LIST:
+		return arrow.TypeEqual(expected.(*arrow.LargeListType).Elem(), actual.(*arrow.LargeListType).Elem())
+	case expected.ID() == arrow.FIXED_SIZE_LIST && actual.ID() == arrow.FIXED_SIZE_LIST:
+		e, a := expected.(*arrow.FixedSizeListType), actual.(*arrow.FixedSizeListType)
+		return e.Len() == a.Len() && arrow.TypeEqual(e.Elem(), a.Elem())
+	}
+	return false
+}
+
+// fixedWidthBytes returns the bytes of count values of width bytes of an Arrow array, starting at value start.
+func fixedWidthBytes(data arrow.ArrayData, start, count int, width uint64) []byte {
+	buf := data.Buffers()[1]
+	if buf == nil {
+		return nil
+	}
+	begin := uint64(data.Offset()+start) * width
+	return buf.Bytes()[begin : begin+uint64(count)*width]
+}
+
+// tiledbOffsets converts the n+1 offsets of an Arrow array into TileDB byte offsets starting at zero.
+// Arrow offsets count values of size bytes. It also returns the first and last Arrow offsets.
+// int64 byte offsets starting at zero are reinterpreted without copying.
+func tiledbOffsets[T int32 | int64](offsets []T, size uint64) ([]uint64, uint64, uint64) {
+	n := len(offsets) - 1
+	start, end := uint64(offsets[0]), uint64(offsets[n])
+	if offsets64, ok := any(offsets).([]int64); ok && start == 0 && size == 1 {
+		return unsafe.Slice((*uint64)(unsafe.Pointer(unsafe.SliceData(offsets64))), n), start, end
+	}
+	result := make([]uint64, n)
+	for i := range result {
+		result[i] = (uint64(offsets[i]) - start) * size
+	}
+	return result, start, end
+}
+
+// buffers returns the query buffers of a field for an Arrow array.
+func buffers(info fieldInfo, arr arrow.Array) (data []byte, offsets []uint64, validity []uint8, err error) {
+	n := arr.Len()
+	arrData := arr.Data()
+	size := info.datatype.Size()
+
+	switch a := arr.(type) {
+	case *array.Boolean:
+		data = make([]byte, n)
+		for i := 0; i < n; i++ {
+			if a.Value(i) {
+				data[i] = 1
+			}
+		}
+	case *array.String:
+		offsets, _, _ = tiledbOffsets(a.ValueOffsets(), 1)
+		data = a.ValueBytes()
+	case *array.LargeString:
+		offsets, _, _ = tiledbOffsets(a.ValueOffsets(), 1)
+		data = a.ValueBytes()
+	case *array.Binary:
+		offsets, _, _ = tiledbOffsets(a.ValueOffsets(), 1)
+		data = a.ValueBytes()
+	case *array.LargeBinary:
+		offsets, _, _ = tiledbOffsets(a.ValueOffsets(), 1)
+		data = a.ValueBytes()
+	case *array.LargeList:
+		var start, end uint64
+		offsets, start, end = tiledbOffsets(a.Offsets()[arrData.Offset():arrData.Offset()+n+1], size)
+		data = fixedWidthBytes(a.ListValues().Data(), int(start), int(end-start), size)
+	case *array.FixedSizeBinary:
+		data = fixedWidthBytes(arrData, 0, n, uint64(info.cellValNum))
+	case *array.FixedSizeList:
+		cellValNum := int(info.cellValNum)
+		data = fixedWidthBytes(a.ListValues().Data(), arrData.Offset()*cellValNum, n*cellValNum, size)
+	default:
+		data = fixedWidthBytes(arrData, 0, n, size)
+	}
+
+	if info.nullable {
+		validity = make([]uint8, n)
+		for i := 0; i < n; i++ {
+			if arr.IsValid(i) {
+				validity[i] = 1
+			}
+		}
+	} else if arr.NullN() > 0 {
+		return nil, nil, nil, fmt.Errorf("column has %d nulls but %s is not nullable", arr.NullN(), info.name)
+	}
+
+	return data, offsets, validity, nil
+}
+
+// emptyData is the data buffer set for var-sized fields where every cell is empty or null.
+// TileDB does not access the contents of an empty buffer, so all writes share it.
+var emptyData [1]byte
+
+// WriteRecord sets the query buffers of every column of rec and submits the query.
+// Columns are matched to dimensions and attributes by name and must have the Arrow types
+// returned by Schema, except that utf8 and binary columns with int32 offsets are also
+// accepted. Fixed-size values are passed to TileDB without copying. The layout, and for
+// dense arrays the subarray, must be set on the query beforehand.
+func WriteRecord(query *tiledb.Query, rec arrow.Record) error {
+	if rec.NumRows() == 0 {
+		return errors.New("error writing Arrow record: no rows to write")
+	}
+
+	arr, err := query.Array()
+	if err != nil {
+		return err
+	}
+	schema, err := arr.Schema()
+	if err != nil {
+		return fmt.Errorf("could not get array schema for WriteRecord: %w", err)
+	}
+	defer schema.Free()
+
+	for i, column := range rec.Columns() {
+		name := rec.ColumnName(i)
+		info, err := schemaField(schema, name)
+		if err != nil {
+			return fmt.Errorf("cannot write column %s: %w", name, err)
+		}
+		expected, err := fieldType(info)
+		if err != nil {
+			return fmt.Errorf("cannot write column %s: %w", name, err)
+		}
+		if !typeCompatible(expected, column.DataType()) {
+			return fmt.Errorf("%w: cannot write column %s: expected Arrow type %v, got %v", tiledb.ErrSchemaMismatch, name, expected, column.DataType())
+		}
+
+		data, offsets, validity, err := buffers(info, column)
+		if err != nil {
+			return fmt.Errorf("cannot write column %s: %w", name, err)
+		}
+
+		if len(data) > 0 {
+			_, err = query.SetDataBufferUnsafe(name, unsafe.Pointer(&data[0]), uint64(len(data)))
+		} else {
+			_, err = query.SetDataBufferUnsafe(name, unsafe.Pointer(&emptyData[0]), 0)
+		}
+		if err != nil {
+			return fmt.Errorf("error setting buffers for %s: %w", name, err)
+		}
+		if offsets != nil {
+			if _, err := query.SetOffsetsBuffer(name, offsets); err != nil {
+				return fmt.Errorf("error setting buffers for %s: %w", name, err)
+			}
+		}
+		if validity != nil {
+			if _, err := query.SetValidityBuffer(name, validity); err != nil {
+				return fmt.Errorf("error setting buffers for %s: %w", name, err)
+			}
+		}
+	}
+
+	return query.Submit()
+}
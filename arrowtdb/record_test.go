@@ -0,0 +1,240 @@
+package arrowtdb
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tiledb "github.com/TileDB-Inc/TileDB-Go"
+)
+
+var (
+	testRows = []int32{1, 2, 2}
+	testA1   = []int32{1, 2, 3}
+	testA2   = []string{"i", "ama", "string"}
+	testA3   = []int64{1623763941, 1623762932, 1623765583}
+)
+
+// createTestArray creates a sparse array with int32 dimensions rows and cols, an int32
+// attribute a1, a var-sized string attribute a2 and a datetime attribute a3, and writes
+// three cells to it. The array is returned closed.
+func createTestArray(t *testing.T) *tiledb.Array {
+	tdbCtx, err := tiledb.NewContext(nil)
+	require.NoError(t, err)
+
+	rows, err := tiledb.NewDimension(tdbCtx, "rows", tiledb.TILEDB_INT32, []int32{1, 4}, int32(2))
+	require.NoError(t, err)
+	cols, err := tiledb.NewDimension(tdbCtx, "cols", tiledb.TILEDB_INT32, []int32{1, 4}, int32(2))
+	require.NoError(t, err)
+	domain, err := tiledb.NewDomain(tdbCtx)
+	require.NoError(t, err)
+	require.NoError(t, domain.AddDimensions(rows, cols))
+
+	a1, err := tiledb.NewAttribute(tdbCtx, "a1", tiledb.TILEDB_INT32)
+	require.NoError(t, err)
+	a2, err := tiledb.NewAttribute(tdbCtx, "a2", tiledb.TILEDB_STRING_ASCII)
+	require.NoError(t, err)
+	require.NoError(t, a2.SetCellValNum(tiledb.TILEDB_VAR_NUM))
+	a3, err := tiledb.NewAttribute(tdbCtx, "a3", tiledb.TILEDB_DATETIME_SEC)
+	require.NoError(t, err)
+
+	schema, err := tiledb.NewArraySchema(tdbCtx, tiledb.TILEDB_SPARSE)
+	require.NoError(t, err)
+	defer schema.Free()
+	require.NoError(t, schema.SetDomain(domain))
+	require.NoError(t, schema.AddAttributes(a1, a2, a3))
+
+	uri := t.TempDir()
+	require.NoError(t, tiledb.CreateArray(tdbCtx, uri, schema))
+	arr, err := tiledb.NewArray(tdbCtx, uri)
+	require.NoError(t, err)
+	t.Cleanup(arr.Free)
+
+	require.NoError(t, arr.Open(tiledb.TILEDB_WRITE))
+	query, err := tiledb.NewQuery(tdbCtx, arr)
+	require.NoError(t, err)
+	defer query.Free()
+	require.NoError(t, query.SetLayout(tiledb.TILEDB_UNORDERED))
+	_, err = query.SetDataBuffer("rows", testRows)
+	require.NoError(t, err)
+	_, err = query.SetDataBuffer("cols", []int32{1, 1, 2})
+	require.NoError(t, err)
+	_, err = query.SetDataBuffer("a1", testA1)
+	require.NoError(t, err)
+	_, err = query.SetDataBuffer("a2", []byte("iamastring"))
+	require.NoError(t, err)
+	_, err = query.SetOffsetsBuffer("a2", []uint64{0, 1, 4})
+	require.NoError(t, err)
+	_, err = query.SetDataBuffer("a3", testA3)
+	require.NoError(t, err)
+	require.NoError(t, query.Submit())
+	require.NoError(t, arr.Close())
+
+	return arr
+}
+
+// newReadQuery opens arr for reading and returns a row-major read query on it.
+func newReadQuery(t *testing.T, arr *tiledb.Array) *tiledb.Query {
+	require.NoError(t, arr.Open(tiledb.TILEDB_READ))
+	t.Cleanup(func() { _ = arr.Close() })
+
+	query, err := tiledb.NewQuery(arr.Context(), arr)
+	require.NoError(t, err)
+	t.Cleanup(query.Free)
+	require.NoError(t, query.SetLayout(tiledb.TILEDB_ROW_MAJOR))
+	return query
+}
+
+func TestRecordReader(t *testing.T) {
+	query := newReadQuery(t, createTestArray(t))
+
+	reader, err := NewRecordReader(query, []string{"rows", "a1", "a2", "a3"})
+	require.NoError(t, err)
+	defer reader.Release()
+	assert.Equal(t, 4, reader.Schema().NumFields())
+
+	require.True(t, reader.Next())
+	rec := reader.Record()
+	require.Equal(t, int64(3), rec.NumRows())
+	assert.Equal(t, testRows, rec.Column(0).(*array.Int32).Int32Values())
+	assert.Equal(t, testA1, rec.Column(1).(*array.Int32).Int32Values())
+
+	a2 := rec.Column(2).(*array.LargeString)
+	assert.Equal(t, testA2, []string{a2.Value(0), a2.Value(1), a2.Value(2)})
+
+	a3 := rec.Column(3).(*array.Timestamp)
+	for i, v := range testA3 {
+		assert.Equal(t, arrow.Timestamp(v), a3.Value(i))
+	}
+
+	assert.False(t, reader.Next())
+	require.NoError(t, reader.Err())
+	assert.Nil(t, reader.Record())
+
+	status, err := query.Status()
+	require.NoError(t, err)
+	assert.Equal(t, tiledb.TILEDB_COMPLETED, status)
+}
+
+func TestRecordReaderIncomplete(t *testing.T) {
+	query := newReadQuery(t, createTestArray(t))
+
+	// Buffers of a single cell return one record per cell.
+	reader, err := NewRecordReader(query, []string{"a1", "a2"}, tiledb.WithInitialBufferBytes(4))
+	require.NoError(t, err)
+	defer reader.Release()
+
+	var records []arrow.Record
+	defer func() {
+		for _, rec := range records {
+			rec.Release()
+		}
+	}()
+	for reader.Next() {
+		rec := reader.Record()
+		rec.Retain()
+		records = append(records, rec)
+	}
+	require.NoError(t, reader.Err())
+	assert.Greater(t, len(records), 1)
+
+	// Retained records keep their values after later calls to Next.
+	var a1 []int32
+	var a2 []string
+	for _, rec := range records {
+		a1 = append(a1, rec.Column(0).(*array.Int32).Int32Values()...)
+		strings := rec.Column(1).(*array.LargeString)
+		for i := 0; i < strings.Len(); i++ {
+			a2 = append(a2, strings.Value(i))
+		}
+	}
+	assert.Equal(t, testA1, a1)
+	assert.Equal(t, testA2, a2)
+}
+
+func TestRecordReaderUnknownField(t *testing.T) {
+	query := newReadQuery(t, createTestArray(t))
+
+	_, err := NewRecordReader(query, []string{"a4"})
+	assert.ErrorIs(t, err, tiledb.ErrNotFound)
+}
+
+func TestWriteRecord(t *testing.T) {
+	tdbCtx, err := tiledb.NewContext(nil)
+	require.NoError(t, err)
+
+	dimension, err := tiledb.NewDimension(tdbCtx, "d", tiledb.TILEDB_INT32, []int32{1, 10}, int32(5))
+	require.NoError(t, err)
+	a1, err := tiledb.NewAttribute(tdbCtx, "a1", tiledb.TILEDB_FLOAT64)
+	require.NoError(t, err)
+	require.NoError(t, a1.SetNullable(true))
+	a2, err := tiledb.NewAttribute(tdbCtx, "a2", tiledb.TILEDB_STRING_UTF8)
+	require.NoError(t, err)
+	require.NoError(t, a2.SetCellValNum(tiledb.TILEDB_VAR_NUM))
+	domain, err := tiledb.NewDomain(tdbCtx)
+	require.NoError(t, err)
+	require.NoError(t, domain.AddDimensions(dimension))
+	schema, err := tiledb.NewArraySchema(tdbCtx, tiledb.TILEDB_SPARSE)
+	require.NoError(t, err)
+	defer schema.Free()
+	require.NoError(t, schema.SetDomain(domain))
+	require.NoError(t, schema.AddAttributes(a1, a2))
+
+	arrowSchema, err := Schema(schema)
+	require.NoError(t, err)
+
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, arrowSchema)
+	defer builder.Release()
+	builder.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2, 3}, nil)
+	builder.Field(1).(*array.Float64Builder).AppendValues([]float64{1.5, 0, 3.5}, []bool{true, false, true})
+	builder.Field(2).(*array.LargeStringBuilder).AppendValues([]string{"a", "", "ccc"}, nil)
+	rec := builder.NewRecord()
+	defer rec.Release()
+
+	uri := t.TempDir()
+	require.NoError(t, tiledb.CreateArray(tdbCtx, uri, schema))
+	arr, err := tiledb.NewArray(tdbCtx, uri)
+	require.NoError(t, err)
+	defer arr.Free()
+
+	require.NoError(t, arr.Open(tiledb.TILEDB_WRITE))
+	query, err := tiledb.NewQuery(tdbCtx, arr)
+	require.NoError(t, err)
+	require.NoError(t, query.SetLayout(tiledb.TILEDB_UNORDERED))
+	require.NoError(t, WriteRecord(query, rec))
+	query.Free()
+	require.NoError(t, arr.Close())
+
+	reader, err := NewRecordReader(newReadQuery(t, arr), nil)
+	require.NoError(t, err)
+	defer reader.Release()
+	require.True(t, reader.Next())
+	assert.True(t, array.RecordEqual(rec, reader.Record()))
+	assert.False(t, reader.Next())
+	require.NoError(t, reader.Err())
+}
+
+func TestWriteRecordTypeMismatch(t *testing.T) {
+	arr := createTestArray(t)
+	require.NoError(t, arr.Open(tiledb.TILEDB_WRITE))
+	defer arr.Close()
+
+	query, err := tiledb.NewQuery(arr.Context(), arr)
+	require.NoError(t, err)
+	defer query.Free()
+
+	builder := array.NewInt64Builder(memory.DefaultAllocator)
+	defer builder.Release()
+	builder.AppendValues([]int64{1, 2}, nil)
+	a1 := builder.NewArray()
+	defer a1.Release()
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "a1", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	rec := array.NewRecord(schema, []arrow.Array{a1}, 2)
+	defer rec.Release()
+	assert.ErrorIs(t, WriteRecord(query, rec), tiledb.ErrSchemaMismatch)
+}
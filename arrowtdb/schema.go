@@ -0,0 +1,521 @@
+/*
+Package arrowtdb converts between TileDB arrays and Apache Arrow.
+
+It maps array schemas to Arrow schemas and back, reads the results of queries as
+Arrow records, and writes Arrow records to arrays. It is a module of its own, so
+that programs using the tiledb package alone do not depend on Arrow.
+*/
+package arrowtdb
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/apache/arrow-go/v18/arrow"
+
+	tiledb "github.com/TileDB-Inc/TileDB-Go"
+)
+
+// Keys of the Arrow field metadata used to preserve TileDB schema information
+// that has no Arrow equivalent.
+const (
+	MetadataDatatype   = "tiledb.datatype"
+	MetadataDimension  = "tiledb.dimension"
+	MetadataDomain     = "tiledb.domain"
+	MetadataTileExtent = "tiledb.tile_extent"
+)
+
+// fieldInfo describes a dimension or attribute of an array schema.
+type fieldInfo struct {
+	name        string
+	datatype    tiledb.Datatype
+	cellValNum  uint32
+	nullable    bool
+	isDimension bool
+}
+
+// isVar returns whether the cells of the field have a variable number of values.
+func (f fieldInfo) isVar() bool {
+	return f.cellValNum == tiledb.TILEDB_VAR_NUM
+}
+
+// dimensionInfo returns the description of a dimension.
+func dimensionInfo(dimension *tiledb.Dimension) (fieldInfo, error) {
+	info := fieldInfo{isDimension: true}
+	var err error
+	if info.name, err = dimension.Name(); err != nil {
+		return info, err
+	}
+	if info.datatype, err = dimension.Type(); err != nil {
+		return info, err
+	}
+	if info.cellValNum, err = dimension.CellValNum(); err != nil {
+		return info, err
+	}
+	return info, nil
+}
+
+// attributeInfo returns the description of an attribute.
+func attributeInfo(attribute *tiledb.Attribute) (fieldInfo, error) {
+	var info fieldInfo
+	var err error
+	if info.name, err = attribute.Name(); err != nil {
+		return info, err
+	}
+	if info.datatype, err = attribute.Type(); err != nil {
+		return info, err
+	}
+	if info.cellValNum, err = attribute.CellValNum(); err != nil {
+		return info, err
+	}
+	if info.nullable, err = attribute.Nullable(); err != nil {
+		return info, err
+	}
+	return info, nil
+}
+
+// schemaFields returns the descriptions of all dimensions followed by all attributes of the schema.
+func schemaFields(schema *tiledb.ArraySchema) ([]fieldInfo, error) {
+	domain, err := schema.Domain()
+	if err != nil {
+		return nil, err
+	}
+	defer domain.Free()
+
+	ndim, err := domain.NDim()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]fieldInfo, 0, ndim)
+	for i := uint(0); i < ndim; i++ {
+		dimension, err := domain.DimensionFromIndex(i)
+		if err != nil {
+			return nil, err
+		}
+		info, err := dimensionInfo(dimension)
+		dimension.Free()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, info)
+	}
+
+	attributes, err := schema.Attributes()
+	if err != nil {
+		return nil, err
+	}
+	for _, attribute := range attributes {
+		info, err := attributeInfo(attribute)
+		attribute.Free()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, info)
+	}
+
+	return fields, nil
+}
+
+// schemaField returns the description of the dimension or attribute name of the schema.
+func schemaField(schema *tiledb.ArraySchema, name string) (fieldInfo, error) {
+	domain, err := schema.Domain()
+	if err != nil {
+		return fieldInfo{}, err
+	}
+	defer domain.Free()
+
+	hasDim, err := domain.HasDimension(name)
+	if err != nil {
+		return fieldInfo{}, err
+	}
+	if hasDim {
+		dimension, err := domain.DimensionFromName(name)
+		if err != nil {
+			return fieldInfo{}, err
+		}
+		defer dimension.Free()
+		return dimensionInfo(dimension)
+	}
+
+	hasAttr, err := schema.HasAttribute(name)
+	if err != nil {
+		return fieldInfo{}, err
+	}
+	if hasAttr {
+		attribute, err := schema.AttributeFromName(name)
+		if err != nil {
+			return fieldInfo{}, err
+		}
+		defer attribute.Free()
+		return attributeInfo(attribute)
+	}
+
+	return fieldInfo{}, fmt.Errorf("%w: attribute/dimension %s does not exist", tiledb.ErrNotFound, name)
+}
+
+// elemType returns the Arrow type of a single value of datatype.
+func elemType(datatype tiledb.Datatype) (arrow.DataType, error) {
+	switch datatype {
+	case tiledb.TILEDB_DATETIME_SEC:
+		return arrow.FixedWidthTypes.Timestamp_s, nil
+	case tiledb.TILEDB_DATETIME_MS:
+		return arrow.FixedWidthTypes.Timestamp_ms, nil
+	case tiledb.TILEDB_DATETIME_US:
+		return arrow.FixedWidthTypes.Timestamp_us, nil
+	case tiledb.TILEDB_DATETIME_NS:
+		return arrow.FixedWidthTypes.Timestamp_ns, nil
+	}
+
+	switch datatype.ReflectKind() {
+	case reflect.Int8:
+		return arrow.PrimitiveTypes.Int8, nil
+	case reflect.Int16:
+		return arrow.PrimitiveTypes.Int16, nil
+	case reflect.Int32:
+		return arrow.PrimitiveTypes.Int32, nil
+	case reflect.Int64:
+		return arrow.PrimitiveTypes.Int64, nil
+	case reflect.Uint8:
+		return arrow.PrimitiveTypes.Uint8, nil
+	case reflect.Uint16:
+		return arrow.PrimitiveTypes.Uint16, nil
+	case reflect.Uint32:
+		return arrow.PrimitiveTypes.Uint32, nil
+	case reflect.Uint64:
+		return arrow.PrimitiveTypes.Uint64, nil
+	case reflect.Float32:
+		return arrow.PrimitiveTypes.Float32, nil
+	case reflect.Float64:
+		return arrow.PrimitiveTypes.Float64, nil
+	case reflect.Bool:
+		return arrow.FixedWidthTypes.Boolean, nil
+	}
+	return nil, fmt.Errorf("datatype %v has no Arrow equivalent", datatype)
+}
+
+// isStringDatatype returns whether cells of datatype map to Arrow utf8 strings.
+func isStringDatatype(datatype tiledb.Datatype) bool {
+	return datatype == tiledb.TILEDB_STRING_ASCII || datatype == tiledb.TILEDB_STRING_UTF8 || datatype == tiledb.TILEDB_CHAR
+}
+
+// isBinaryDatatype returns whether cells of datatype map to Arrow binary values.
+func isBinaryDatatype(datatype tiledb.Datatype) bool {
+	return datatype == tiledb.TILEDB_BLOB || datatype == tiledb.TILEDB_GEOM_WKB || datatype == tiledb.TILEDB_GEOM_WKT
+}
+
+// fieldType returns the Arrow type of the cells of a field.
+func fieldType(info fieldInfo) (arrow.DataType, error) {
+	switch {
+	case info.isVar() && isStringDatatype(info.datatype):
+		return arrow.BinaryTypes.LargeString, nil
+	case info.isVar() && isBinaryDatatype(info.datatype):
+		return arrow.BinaryTypes.LargeBinary, nil
+	case info.cellValNum > 1 && !info.isVar() && (isStringDatatype(info.datatype) || isBinaryDatatype(info.datatype)):
+		return &arrow.FixedSizeBinaryType{ByteWidth: int(info.cellValNum)}, nil
+	}
+
+	elem, err := elemType(info.datatype)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case info.isVar():
+		if elem.ID() == arrow.BOOL {
+			return nil, errors.New("var-sized boolean fields have no Arrow equivalent")
+		}
+		return arrow.LargeListOf(elem), nil
+	case info.cellValNum > 1:
+		if elem.ID() == arrow.BOOL {
+			return nil, errors.New("boolean fields with more than one value per cell have no Arrow equivalent")
+		}
+		return arrow.FixedSizeListOf(int32(info.cellValNum), elem), nil
+	}
+	return elem, nil
+}
+
+// field returns the Arrow field of a TileDB field. The datatype is preserved in the field metadata.
+func field(info fieldInfo) (arrow.Field, error) {
+	dt, err := fieldType(info)
+	if err != nil {
+		return arrow.Field{}, fmt.Errorf("cannot convert %s to Arrow: %w", info.name, err)
+	}
+
+	keys := []string{MetadataDatatype}
+	values := []string{info.datatype.String()}
+	if info.isDimension {
+		keys = append(keys, MetadataDimension)
+		values = append(values, "true")
+	}
+
+	return arrow.Field{
+		Name:     info.name,
+		Type:     dt,
+		Nullable: info.nullable,
+		Metadata: arrow.NewMetadata(keys, values),
+	}, nil
+}
+
+// datatypeFromField returns the TileDB datatype and cell value number of an Arrow field.
+// The datatype stored in the field metadata, if any, takes precedence over the one inferred
+// from the Arrow type.
+func datatypeFromField(field arrow.Field) (tiledb.Datatype, uint32, error) {
+	datatype, cellValNum, err := datatypeFromType(field.Type)
+	if err != nil {
+		return datatype, cellValNum, fmt.Errorf("cannot convert %s from Arrow: %w", field.Name, err)
+	}
+	if name, ok := field.Metadata.GetValue(MetadataDatatype); ok {
+		if datatype, err = tiledb.DatatypeFromString(name); err != nil {
+			return datatype, cellValNum, fmt.Errorf("cannot convert %s from Arrow: %w", field.Name, err)
+		}
+	}
+	return datatype, cellValNum, nil
+}
+
+func datatypeFromType(dt arrow.DataType) (tiledb.Datatype, uint32, error) {
+	switch dt := dt.(type) {
+	case *arrow.StringType, *arrow.LargeStringType:
+		return tiledb.TILEDB_STRING_UTF8, tiledb.TILEDB_VAR_NUM, nil
+	case *arrow.BinaryType, *arrow.LargeBinaryType:
+		return tiledb.TILEDB_BLOB, tiledb.TILEDB_VAR_NUM, nil
+	case *arrow.FixedSizeBinaryType:
+		return tiledb.TILEDB_BLOB, uint32(dt.ByteWidth), nil
+	case *arrow.ListType:
+		datatype, _, err := datatypeFromType(dt.Elem())
+		return datatype, tiledb.TILEDB_VAR_NUM, err
+	case *arrow.LargeListType:
+		datatype, _, err := datatypeFromType(dt.Elem())
+		return datatype, tiledb.TILEDB_VAR_NUM, err
+	case *arrow.FixedSizeListType:
+		datatype, _, err := datatypeFromType(dt.Elem())
+		return datatype, uint32(dt.Len()), err
+	case *arrow.TimestampType:
+		switch dt.Unit {
+		case arrow.Second:
+			return tiledb.TILEDB_DATETIME_SEC, 1, nil
+		case arrow.Millisecond:
+			return tiledb.TILEDB_DATETIME_MS, 1, nil
+		case arrow.Microsecond:
+			return tiledb.TILEDB_DATETIME_US, 1, nil
+		case arrow.Nanosecond:
+			return tiledb.TILEDB_DATETIME_NS, 1, nil
+		}
+	}
+
+	switch dt.ID() {
+	case arrow.INT8:
+		return tiledb.TILEDB_INT8, 1, nil
+	case arrow.INT16:
+		return tiledb.TILEDB_INT16, 1, nil
+	case arrow.INT32:
+		return tiledb.TILEDB_INT32, 1, nil
+	case arrow.INT64:
+		return tiledb.TILEDB_INT64, 1, nil
+	case arrow.UINT8:
+		return tiledb.TILEDB_UINT8, 1, nil
+	case arrow.UINT16:
+		return tiledb.TILEDB_UINT16, 1, nil
+	case arrow.UINT32:
+		return tiledb.TILEDB_UINT32, 1, nil
+	case arrow.UINT64:
+		return tiledb.TILEDB_UINT64, 1, nil
+	case arrow.FLOAT32:
+		return tiledb.TILEDB_FLOAT32, 1, nil
+	case arrow.FLOAT64:
+		return tiledb.TILEDB_FLOAT64, 1, nil
+	case arrow.BOOL:
+		return tiledb.TILEDB_BOOL, 1, nil
+	}
+	return tiledb.TILEDB_ANY, 0, fmt.Errorf("no TileDB equivalent for Arrow type %v", dt)
+}
+
+// dimensionMetadata returns the domain and tile extent of a dimension encoded as JSON.
+// String dimensions have neither and return empty strings.
+func dimensionMetadata(dimension *tiledb.Dimension) (string, string, error) {
+	domain, err := dimension.Domain()
+	if err != nil {
+		return "", "", err
+	}
+	if domain == nil {
+		return "", "", nil
+	}
+	extent, err := dimension.Extent()
+	if err != nil {
+		return "", "", err
+	}
+
+	domainJSON, err := json.Marshal(domain)
+	if err != nil {
+		return "", "", err
+	}
+	extentJSON, err := json.Marshal(extent)
+	if err != nil {
+		return "", "", err
+	}
+	return string(domainJSON), string(extentJSON), nil
+}
+
+/*
+Schema returns the Arrow schema of an array schema, with one field per dimension
+followed by one field per attribute. Fields are mapped as follows:
+
+  - integers, floats and booleans map to the Arrow type of the same width
+  - TILEDB_DATETIME_SEC, _MS, _US and _NS map to UTC timestamps of the same unit;
+    other datetimes and times map to int64
+  - var-sized TILEDB_STRING_ASCII, TILEDB_STRING_UTF8 and TILEDB_CHAR map to
+    large_utf8 (utf8 with int64 offsets), and var-sized TILEDB_BLOB and geometries
+    map to large_binary
+  - other var-sized fields map to large lists, and fields with more than one
+    value per cell map to fixed size binaries or fixed size lists
+  - nullable attributes map to nullable fields
+
+The TileDB datatype of each field, and the domain and tile extent of each
+dimension, are kept in the field metadata so that NewArraySchema can restore them.
+*/
+func Schema(schema *tiledb.ArraySchema) (*arrow.Schema, error) {
+	infos, err := schemaFields(schema)
+	if err != nil {
+		return nil, fmt.Errorf("could not get fields for Arrow schema: %w", err)
+	}
+
+	domain, err := schema.Domain()
+	if err != nil {
+		return nil, fmt.Errorf("could not get domain for Arrow schema: %w", err)
+	}
+	defer domain.Free()
+
+	fields := make([]arrow.Field, 0, len(infos))
+	for _, info := range infos {
+		f, err := field(info)
+		if err != nil {
+			return nil, err
+		}
+
+		if info.isDimension {
+			dimension, err := domain.DimensionFromName(info.name)
+			if err != nil {
+				return nil, err
+			}
+			domainJSON, extentJSON, err := dimensionMetadata(dimension)
+			dimension.Free()
+			if err != nil {
+				return nil, fmt.Errorf("could not get domain of %s for Arrow schema: %w", info.name, err)
+			}
+			if domainJSON != "" {
+				f.Metadata = arrow.NewMetadata(
+					append(f.Metadata.Keys(), MetadataDomain, MetadataTileExtent),
+					append(f.Metadata.Values(), domainJSON, extentJSON))
+			}
+		}
+
+		fields = append(fields, f)
+	}
+
+	return arrow.NewSchema(fields, nil), nil
+}
+
+// dimensionFromField creates the dimension described by an Arrow field.
+func dimensionFromField(tdbCtx *tiledb.Context, field arrow.Field, datatype tiledb.Datatype, cellValNum uint32) (*tiledb.Dimension, error) {
+	domainJSON, hasDomain := field.Metadata.GetValue(MetadataDomain)
+	if !hasDomain {
+		if datatype == tiledb.TILEDB_STRING_ASCII && cellValNum == tiledb.TILEDB_VAR_NUM {
+			return tiledb.NewStringDimension(tdbCtx, field.Name)
+		}
+		return nil, fmt.Errorf("dimension %s has no %s metadata", field.Name, MetadataDomain)
+	}
+	extentJSON, _ := field.Metadata.GetValue(MetadataTileExtent)
+
+	valueType := datatype.ReflectType()
+	if valueType == nil {
+		return nil, fmt.Errorf("dimension %s has unsupported datatype %v", field.Name, datatype)
+	}
+	domain := reflect.New(reflect.SliceOf(valueType))
+	if err := json.Unmarshal([]byte(domainJSON), domain.Interface()); err != nil {
+		return nil, fmt.Errorf("could not parse domain of dimension %s: %w", field.Name, err)
+	}
+	extent := reflect.New(valueType)
+	if err := json.Unmarshal([]byte(extentJSON), extent.Interface()); err != nil {
+		return nil, fmt.Errorf("could not parse tile extent of dimension %s: %w", field.Name, err)
+	}
+
+	return tiledb.NewDimension(tdbCtx, field.Name, datatype, domain.Elem().Interface(), extent.Elem().Interface())
+}
+
+// NewArraySchema creates an array schema from an Arrow schema. Fields with the
+// tiledb.dimension metadata key become dimensions, in order, and the remaining fields become
+// attributes. Dimensions must carry their domain and tile extent in the field metadata, as
+// written by Schema, unless they are var-sized string dimensions.
+func NewArraySchema(tdbCtx *tiledb.Context, arrayType tiledb.ArrayType, schema *arrow.Schema) (*tiledb.ArraySchema, error) {
+	domain, err := tiledb.NewDomain(tdbCtx)
+	if err != nil {
+		return nil, err
+	}
+	defer domain.Free()
+
+	var dimensions []*tiledb.Dimension
+	var attributes []*tiledb.Attribute
+	defer func() {
+		for _, d := range dimensions {
+			d.Free()
+		}
+		for _, a := range attributes {
+			a.Free()
+		}
+	}()
+
+	for _, field := range schema.Fields() {
+		datatype, cellValNum, err := datatypeFromField(field)
+		if err != nil {
+			return nil, err
+		}
+
+		if isDim, _ := field.Metadata.GetValue(MetadataDimension); isDim == "true" {
+			dimension, err := dimensionFromField(tdbCtx, field, datatype, cellValNum)
+			if err != nil {
+				return nil, err
+			}
+			dimensions = append(dimensions, dimension)
+			continue
+		}
+
+		attribute, err := tiledb.NewAttribute(tdbCtx, field.Name, datatype)
+		if err != nil {
+			return nil, err
+		}
+		attributes = append(attributes, attribute)
+		if err := attribute.SetCellValNum(cellValNum); err != nil {
+			return nil, err
+		}
+		if field.Nullable {
+			if err := attribute.SetNullable(true); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(dimensions) == 0 {
+		return nil, errors.New("error creating array schema from Arrow: no field is marked as a dimension")
+	}
+	if err := domain.AddDimensions(dimensions...); err != nil {
+		return nil, err
+	}
+
+	arraySchema, err := tiledb.NewArraySchema(tdbCtx, arrayType)
+	if err != nil {
+		return nil, err
+	}
+	if err := arraySchema.SetDomain(domain); err != nil {
+		arraySchema.Free()
+		return nil, err
+	}
+	if len(attributes) > 0 {
+		if err := arraySchema.AddAttributes(attributes...); err != nil {
+			arraySchema.Free()
+			return nil, err
+		}
+	}
+
+	return arraySchema, nil
+}
@@ -0,0 +1,77 @@
+package arrowtdb
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	tiledb "github.com/TileDB-Inc/TileDB-Go"
+)
+
+func TestSchema(t *testing.T) {
+	arr := createTestArray(t)
+
+	schema, err := arr.Schema()
+	require.NoError(t, err)
+	defer schema.Free()
+
+	arrowSchema, err := Schema(schema)
+	require.NoError(t, err)
+	require.Equal(t, 5, arrowSchema.NumFields())
+
+	rows := arrowSchema.Field(0)
+	assert.Equal(t, "rows", rows.Name)
+	assert.Equal(t, arrow.PrimitiveTypes.Int32, rows.Type)
+	domain, _ := rows.Metadata.GetValue(MetadataDomain)
+	assert.Equal(t, "[1,4]", domain)
+	extent, _ := rows.Metadata.GetValue(MetadataTileExtent)
+	assert.Equal(t, "2", extent)
+
+	assert.Equal(t, arrow.BinaryTypes.LargeString, arrowSchema.Field(3).Type)
+	assert.Equal(t, arrow.FixedWidthTypes.Timestamp_s, arrowSchema.Field(4).Type)
+
+	// Converting back restores the datatypes, domains and extents.
+	roundTrip, err := NewArraySchema(arr.Context(), tiledb.TILEDB_SPARSE, arrowSchema)
+	require.NoError(t, err)
+	defer roundTrip.Free()
+
+	a2, err := roundTrip.AttributeFromName("a2")
+	require.NoError(t, err)
+	defer a2.Free()
+	datatype, err := a2.Type()
+	require.NoError(t, err)
+	assert.Equal(t, tiledb.TILEDB_STRING_ASCII, datatype)
+
+	roundTripArrow, err := Schema(roundTrip)
+	require.NoError(t, err)
+	assert.True(t, arrowSchema.Equal(roundTripArrow))
+
+	_, err = NewArraySchema(arr.Context(), tiledb.TILEDB_SPARSE, arrow.NewSchema(arrowSchema.Fields()[3:], nil))
+	assert.Error(t, err)
+}
+
+func TestSchemaDatetimeDimension(t *testing.T) {
+	tdbCtx, err := tiledb.NewContext(nil)
+	require.NoError(t, err)
+
+	dimension, err := tiledb.NewDimension(tdbCtx, "t", tiledb.TILEDB_DATETIME_MS, []int64{0, 1000}, int64(100))
+	require.NoError(t, err)
+	domain, err := tiledb.NewDomain(tdbCtx)
+	require.NoError(t, err)
+	require.NoError(t, domain.AddDimensions(dimension))
+	schema, err := tiledb.NewArraySchema(tdbCtx, tiledb.TILEDB_SPARSE)
+	require.NoError(t, err)
+	defer schema.Free()
+	require.NoError(t, schema.SetDomain(domain))
+
+	arrowSchema, err := Schema(schema)
+	require.NoError(t, err)
+	field := arrowSchema.Field(0)
+	assert.Equal(t, arrow.FixedWidthTypes.Timestamp_ms, field.Type)
+	domainJSON, _ := field.Metadata.GetValue(MetadataDomain)
+	assert.Equal(t, "[0,1000]", domainJSON)
+	extentJSON, _ := field.Metadata.GetValue(MetadataTileExtent)
+	assert.Equal(t, "100", extentJSON)
+}
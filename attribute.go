@@ -54,7 +54,7 @@ func NewAttribute(context *Context, name string, datatype Datatype) (*Attribute,
 	ret := C.tiledb_attribute_alloc(context.tiledbContext.Get(), cname, C.tiledb_datatype_t(datatype), &attributePtr)
 	runtime.KeepAlive(context)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error creating tiledb attribute: %w", context.LastError())
+		return nil, fmt.Errorf("error creating tiledb attribute: %w", context.lastError("tiledb_attribute_alloc", ret))
 	}
 
 	return newAttributeFromHandle(context, newAttributeHandle(attributePtr)), nil
@@ -74,13 +74,22 @@ func (a *Attribute) Context() *Context {
 	return a.context
 }
 
-// SetFilterList sets the attribute filterList.
+// SetFilterList sets the attribute filterList. It returns an error if the filters
+// cannot be applied to the datatype of the attribute; see FilterList.Validate.
 func (a *Attribute) SetFilterList(filterlist *FilterList) error {
+	datatype, err := a.Type()
+	if err != nil {
+		return err
+	}
+	if err := filterlist.Validate(datatype); err != nil {
+		return fmt.Errorf("error setting tiledb attribute filter list: %w", err)
+	}
+
 	ret := C.tiledb_attribute_set_filter_list(a.context.tiledbContext.Get(), a.tiledbAttribute.Get(), filterlist.tiledbFilterList.Get())
 	runtime.KeepAlive(a)
 	runtime.KeepAlive(filterlist)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting tiledb attribute filter list: %w", a.context.LastError())
+		return fmt.Errorf("error setting tiledb attribute filter list: %w", a.context.lastError("tiledb_attribute_set_filter_list", ret))
 	}
 	return nil
 }
@@ -91,7 +100,7 @@ func (a *Attribute) FilterList() (*FilterList, error) {
 	ret := C.tiledb_attribute_get_filter_list(a.context.tiledbContext.Get(), a.tiledbAttribute.Get(), &filterListPtr)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting tiledb attribute filter list: %w", a.context.LastError())
+		return nil, fmt.Errorf("error getting tiledb attribute filter list: %w", a.context.lastError("tiledb_attribute_get_filter_list", ret))
 	}
 
 	return newFilterListFromHandle(a.context, newFilterListHandle(filterListPtr)), nil
@@ -105,7 +114,7 @@ func (a *Attribute) SetCellValNum(val uint32) error {
 		a.tiledbAttribute.Get(), C.uint32_t(val))
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting tiledb attribute cell val num: %w", a.context.LastError())
+		return fmt.Errorf("error setting tiledb attribute cell val num: %w", a.context.lastError("tiledb_attribute_set_cell_val_num", ret))
 	}
 	return nil
 }
@@ -117,7 +126,7 @@ func (a *Attribute) CellValNum() (uint32, error) {
 	ret := C.tiledb_attribute_get_cell_val_num(a.context.tiledbContext.Get(), a.tiledbAttribute.Get(), &cellValNum)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting tiledb attribute cell val num: %w", a.context.LastError())
+		return 0, fmt.Errorf("error getting tiledb attribute cell val num: %w", a.context.lastError("tiledb_attribute_get_cell_val_num", ret))
 	}
 
 	return uint32(cellValNum), nil
@@ -129,7 +138,7 @@ func (a *Attribute) CellSize() (uint64, error) {
 	ret := C.tiledb_attribute_get_cell_size(a.context.tiledbContext.Get(), a.tiledbAttribute.Get(), &cellSize)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting tiledb attribute cell size: %w", a.context.LastError())
+		return 0, fmt.Errorf("error getting tiledb attribute cell size: %w", a.context.lastError("tiledb_attribute_get_cell_size", ret))
 	}
 
 	return uint64(cellSize), nil
@@ -211,7 +220,7 @@ func attributeSetFillValueInternal(a *Attribute, value unsafe.Pointer, valueSize
 	)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("could not set attribute fill value: %w", a.context.LastError())
+		return fmt.Errorf("could not set attribute fill value: %w", a.context.lastError("tiledb_attribute_set_fill_value", ret))
 	}
 	return nil
 }
@@ -297,7 +306,7 @@ func attributeSetFillValueNullableInternal(a *Attribute, value unsafe.Pointer, v
 	)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("could not set attribute fill value: %w", a.context.LastError())
+		return fmt.Errorf("could not set attribute fill value: %w", a.context.lastError("tiledb_attribute_set_fill_value_nullable", ret))
 	}
 	return nil
 }
@@ -314,18 +323,18 @@ func (a *Attribute) GetFillValue() (interface{}, uint64, error) {
 
 	ret := C.tiledb_attribute_get_fill_value(a.context.tiledbContext.Get(), a.tiledbAttribute.Get(), &cvalue, &fillValueSize)
 	if ret != C.TILEDB_OK {
-		return nil, 0, fmt.Errorf("error getting tiledb attribute fill value: %w", a.context.LastError())
+		return nil, 0, fmt.Errorf("error getting tiledb attribute fill value: %w", a.context.lastError("tiledb_attribute_get_fill_value", ret))
 	}
 
 	attrDataType, err := a.Type()
 	if err != nil {
-		return nil, 0, fmt.Errorf("error getting tiledb attribute fill value: %w", a.context.LastError())
+		return nil, 0, fmt.Errorf("error getting tiledb attribute fill value: %w", err)
 	}
 
 	value, err := attrDataType.GetValue(1, cvalue)
 	runtime.KeepAlive(a)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error getting tiledb attribute fill value: %w", a.context.LastError())
+		return nil, 0, fmt.Errorf("error getting tiledb attribute fill value: %w", err)
 	}
 
 	return value, uint64(fillValueSize), nil
@@ -344,18 +353,18 @@ func (a *Attribute) GetFillValueNullable() (interface{}, uint64, bool, error) {
 
 	ret := C.tiledb_attribute_get_fill_value_nullable(a.context.tiledbContext.Get(), a.tiledbAttribute.Get(), &cvalue, &fillValueSize, &cvalid)
 	if ret != C.TILEDB_OK {
-		return nil, 0, false, fmt.Errorf("error getting tiledb attribute fill value: %w", a.context.LastError())
+		return nil, 0, false, fmt.Errorf("error getting tiledb attribute fill value: %w", a.context.lastError("tiledb_attribute_get_fill_value_nullable", ret))
 	}
 
 	attrDataType, err := a.Type()
 	if err != nil {
-		return nil, 0, false, fmt.Errorf("error getting tiledb attribute fill value: %w", a.context.LastError())
+		return nil, 0, false, fmt.Errorf("error getting tiledb attribute fill value: %w", err)
 	}
 
 	value, err := attrDataType.GetValue(1, cvalue)
 	runtime.KeepAlive(a)
 	if err != nil {
-		return nil, 0, false, fmt.Errorf("error getting tiledb attribute fill value: %w", a.context.LastError())
+		return nil, 0, false, fmt.Errorf("error getting tiledb attribute fill value: %w", err)
 	}
 
 	return value, uint64(fillValueSize), cvalid == 1, nil
@@ -366,7 +375,7 @@ func (a *Attribute) Name() (string, error) {
 	var cName *C.char // a must be kept alive while cName is being accessed.
 	ret := C.tiledb_attribute_get_name(a.context.tiledbContext.Get(), a.tiledbAttribute.Get(), &cName)
 	if ret != C.TILEDB_OK {
-		return "", fmt.Errorf("error getting tiledb attribute name: %w", a.context.LastError())
+		return "", fmt.Errorf("error getting tiledb attribute name: %w", a.context.lastError("tiledb_attribute_get_name", ret))
 	}
 
 	name := C.GoString(cName)
@@ -381,7 +390,7 @@ func (a *Attribute) Type() (Datatype, error) {
 	ret := C.tiledb_attribute_get_type(a.context.tiledbContext.Get(), a.tiledbAttribute.Get(), &attrType)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting tiledb attribute type: %w", a.context.LastError())
+		return 0, fmt.Errorf("error getting tiledb attribute type: %w", a.context.lastError("tiledb_attribute_get_type", ret))
 	}
 	return Datatype(attrType), nil
 }
@@ -392,7 +401,7 @@ func (a *Attribute) DumpToString() (string, error) {
 	ret := C.tiledb_attribute_dump_str(a.context.tiledbContext.Get(), a.tiledbAttribute.Get(), &cStr)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return "", fmt.Errorf("error dumping attribute to string: %w", a.context.LastError())
+		return "", fmt.Errorf("error dumping attribute to string: %w", a.context.lastError("tiledb_attribute_dump_str", ret))
 	}
 	defer C.tiledb_string_free(&cStr)
 
@@ -439,7 +448,7 @@ func (a *Attribute) SetNullable(nullable bool) error {
 		a.tiledbAttribute.Get(), cNullable)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting tiledb attribute nullable: %w", a.context.LastError())
+		return fmt.Errorf("error setting tiledb attribute nullable: %w", a.context.lastError("tiledb_attribute_set_nullable", ret))
 	}
 	return nil
 }
@@ -450,7 +459,7 @@ func (a *Attribute) Nullable() (bool, error) {
 	ret := C.tiledb_attribute_get_nullable(a.context.tiledbContext.Get(), a.tiledbAttribute.Get(), &nullable)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return false, fmt.Errorf("error getting tiledb attribute nullable: %w", a.context.LastError())
+		return false, fmt.Errorf("error getting tiledb attribute nullable: %w", a.context.lastError("tiledb_attribute_get_nullable", ret))
 	}
 
 	return nullable == 1, nil
@@ -75,7 +75,7 @@ func NewBuffer(context *Context) (*Buffer, error) {
 	ret := C.tiledb_buffer_alloc(context.tiledbContext.Get(), &bufferPtr)
 	runtime.KeepAlive(context)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error creating tiledb buffer: %w", context.LastError())
+		return nil, fmt.Errorf("error creating tiledb buffer: %w", context.lastError("tiledb_buffer_alloc", ret))
 	}
 
 	return newBufferFromHandle(context, newBufferHandle(bufferPtr)), nil
@@ -100,7 +100,7 @@ func (b *Buffer) SetType(datatype Datatype) error {
 	ret := C.tiledb_buffer_set_type(b.context.tiledbContext.Get(), b.tiledbBuffer.Get(), C.tiledb_datatype_t(datatype))
 	runtime.KeepAlive(b)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting datatype for tiledb buffer: %w", b.context.LastError())
+		return fmt.Errorf("error setting datatype for tiledb buffer: %w", b.context.lastError("tiledb_buffer_set_type", ret))
 	}
 	return nil
 }
@@ -112,7 +112,7 @@ func (b *Buffer) Type() (Datatype, error) {
 	runtime.KeepAlive(b)
 
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting tiledb buffer type: %w", b.context.LastError())
+		return 0, fmt.Errorf("error getting tiledb buffer type: %w", b.context.lastError("tiledb_buffer_get_type", ret))
 	}
 
 	return Datatype(bufferType), nil
@@ -149,7 +149,7 @@ func (b *Buffer) ReadAt(p []byte, off int64) (int, error) {
 
 	ret := C.tiledb_buffer_get_data(b.context.tiledbContext.Get(), b.tiledbBuffer.Get(), &cbuffer, &csize)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting tiledb buffer data: %w", b.context.LastError())
+		return 0, fmt.Errorf("error getting tiledb buffer data: %w", b.context.lastError("tiledb_buffer_get_data", ret))
 	}
 
 	if uintptr(off) >= uintptr(csize) || cbuffer == nil {
@@ -178,7 +178,7 @@ func (b *Buffer) WriteTo(w io.Writer) (int64, error) {
 
 	ret := C.tiledb_buffer_get_data(b.context.tiledbContext.Get(), b.tiledbBuffer.Get(), &cbuffer, &csize)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting tiledb buffer data: %w", b.context.LastError())
+		return 0, fmt.Errorf("error getting tiledb buffer data: %w", b.context.lastError("tiledb_buffer_get_data", ret))
 	}
 
 	if cbuffer == nil || csize == 0 {
@@ -218,7 +218,7 @@ func (b *Buffer) SetBuffer(buffer []byte) error {
 	ret := C.tiledb_buffer_set_data(b.context.tiledbContext.Get(), b.tiledbBuffer.Get(), cbuffer, C.uint64_t(len(buffer)))
 	runtime.KeepAlive(b)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting tiledb buffer: %w", b.context.LastError())
+		return fmt.Errorf("error setting tiledb buffer: %w", b.context.lastError("tiledb_buffer_set_data", ret))
 	}
 
 	return nil
@@ -231,7 +231,7 @@ func (b *Buffer) dataCopy() ([]byte, error) {
 
 	ret := C.tiledb_buffer_get_data(b.context.tiledbContext.Get(), b.tiledbBuffer.Get(), &cbuffer, &csize)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting tiledb buffer data: %w", b.context.LastError())
+		return nil, fmt.Errorf("error getting tiledb buffer data: %w", b.context.lastError("tiledb_buffer_get_data", ret))
 	}
 
 	if cbuffer == nil {
@@ -254,7 +254,7 @@ func (b *Buffer) Len() (uint64, error) {
 	ret := C.tiledb_buffer_get_data(b.context.tiledbContext.Get(), b.tiledbBuffer.Get(), &cbuffer, &csize)
 	runtime.KeepAlive(b)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting tiledb buffer data: %w", b.context.LastError())
+		return 0, fmt.Errorf("error getting tiledb buffer data: %w", b.context.lastError("tiledb_buffer_get_data", ret))
 	}
 
 	return uint64(csize), nil
@@ -42,7 +42,7 @@ func NewBufferList(context *Context) (*BufferList, error) {
 	ret := C.tiledb_buffer_list_alloc(context.tiledbContext.Get(), &bufferListPtr)
 	runtime.KeepAlive(context)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error creating tiledb buffer list: %w", context.LastError())
+		return nil, fmt.Errorf("error creating tiledb buffer list: %w", context.lastError("tiledb_buffer_list_alloc", ret))
 	}
 
 	return newBufferListFromHandle(context, newBufferListHandle(bufferListPtr)), nil
@@ -99,7 +99,7 @@ func (b *BufferList) NumBuffers() (uint64, error) {
 	runtime.KeepAlive(b)
 
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting tiledb bufferList num buffers: %w", b.context.LastError())
+		return 0, fmt.Errorf("error getting tiledb bufferList num buffers: %w", b.context.lastError("tiledb_buffer_list_get_num_buffers", ret))
 	}
 
 	return uint64(numBuffers), nil
@@ -111,7 +111,7 @@ func (b *BufferList) GetBuffer(bufferIndex uint) (*Buffer, error) {
 	ret := C.tiledb_buffer_list_get_buffer(b.context.tiledbContext.Get(), b.tiledbBufferList.Get(), C.uint64_t(bufferIndex), &bufferPtr)
 	runtime.KeepAlive(b)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting tiledb buffer index %d from buffer list: %w", bufferIndex, b.context.LastError())
+		return nil, fmt.Errorf("error getting tiledb buffer index %d from buffer list: %w", bufferIndex, b.context.lastError("tiledb_buffer_list_get_buffer", ret))
 	}
 
 	return newBufferFromHandle(b.context, newBufferHandle(bufferPtr)), nil
@@ -124,7 +124,7 @@ func (b *BufferList) TotalSize() (uint64, error) {
 	runtime.KeepAlive(b)
 
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting tiledb bufferList num buffers: %w", b.context.LastError())
+		return 0, fmt.Errorf("error getting tiledb bufferList num buffers: %w", b.context.lastError("tiledb_buffer_list_get_total_size", ret))
 	}
 
 	return uint64(totalSize), nil
@@ -139,7 +139,7 @@ func (b *BufferList) Flatten() (*Buffer, error) {
 	runtime.KeepAlive(b)
 
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting tiledb bufferList num buffers: %w", b.context.LastError())
+		return nil, fmt.Errorf("error getting tiledb bufferList num buffers: %w", b.context.lastError("tiledb_buffer_list_flatten", ret))
 	}
 
 	return newBufferFromHandle(b.context, newBufferHandle(bufferPtr)), nil
@@ -0,0 +1,148 @@
+package tiledb
+
+import (
+	"math/bits"
+	"reflect"
+	"sync"
+)
+
+// bufferPoolKey identifies the buffers of a BufferPool that can be reused for each other.
+type bufferPoolKey struct {
+	datatype Datatype
+	capacity int
+}
+
+// BufferPoolStats reports the activity of a BufferPool.
+type BufferPoolStats struct {
+	// Hits is the number of buffers returned by Get that were reused.
+	Hits uint64
+	// Misses is the number of buffers returned by Get that were allocated.
+	Misses uint64
+	// RetainedBuffers is the number of buffers held by the pool.
+	RetainedBuffers int
+	// RetainedBytes is the total size of the buffers held by the pool.
+	RetainedBytes uint64
+}
+
+/*
+BufferPool recycles query buffers to reduce allocations when many queries are run.
+
+Buffers are keyed by Datatype and capacity, which is rounded up to a power of two so that
+buffers of similar sizes can be reused for each other. The pool retains the buffers returned
+by Put up to a total size in bytes; buffers that do not fit are left to the garbage collector.
+The pool keeps track of the buffers handed out by Get until they are returned, and drops any
+other buffer given to Put, such as the Slice view of a HostBuffer.
+A BufferPool is safe for concurrent use. A nil *BufferPool allocates every buffer and retains none.
+
+The buffers of ResultIterator and Query.AllocateBuffers are drawn from a pool with the
+WithBufferPool and WithAllocationPool options, and returned to it by their Release method.
+*/
+type BufferPool struct {
+	mu       sync.Mutex
+	maxBytes uint64
+	buffers  map[bufferPoolKey][]interface{}
+	// handedOut holds the addresses of the buffers returned by Get and not returned yet.
+	// They are kept as uintptr so that the pool does not keep the buffers alive.
+	handedOut map[uintptr]struct{}
+	stats     BufferPoolStats
+}
+
+// NewBufferPool returns a BufferPool retaining up to maxBytes bytes of buffers.
+func NewBufferPool(maxBytes uint64) *BufferPool {
+	return &BufferPool{maxBytes: maxBytes, buffers: make(map[bufferPoolKey][]interface{}), handedOut: make(map[uintptr]struct{})}
+}
+
+// Get returns a slice of n values of the Go type of datatype. The contents of reused
+// buffers are not cleared.
+func (p *BufferPool) Get(datatype Datatype, n uint64) (interface{}, error) {
+	capacity := 1
+	if n > 1 {
+		capacity = 1 << bits.Len64(n-1)
+	}
+
+	if p != nil {
+		key := bufferPoolKey{datatype: datatype, capacity: capacity}
+		p.mu.Lock()
+		if free := p.buffers[key]; len(free) > 0 {
+			buffer := reflect.ValueOf(free[len(free)-1])
+			p.buffers[key] = free[:len(free)-1]
+			p.stats.Hits++
+			p.stats.RetainedBuffers--
+			p.stats.RetainedBytes -= uint64(capacity) * datatype.Size()
+			p.handedOut[buffer.Pointer()] = struct{}{}
+			p.mu.Unlock()
+			return buffer.Slice(0, int(n)).Interface(), nil
+		}
+		p.stats.Misses++
+		p.mu.Unlock()
+	}
+
+	buffer, _, err := datatype.MakeSlice(uint64(capacity))
+	if err != nil {
+		return nil, err
+	}
+	v := reflect.ValueOf(buffer)
+	if p != nil {
+		p.mu.Lock()
+		p.handedOut[v.Pointer()] = struct{}{}
+		p.mu.Unlock()
+	}
+	return v.Slice(0, int(n)).Interface(), nil
+}
+
+// Put returns a buffer of datatype obtained from Get to the pool. The buffer must not be used
+// afterwards. Buffers which were not allocated by Get, or do not fit in the pool, are dropped.
+func (p *BufferPool) Put(datatype Datatype, buffer interface{}) {
+	if p == nil || buffer == nil {
+		return
+	}
+	v := reflect.ValueOf(buffer)
+	if v.Kind() != reflect.Slice || v.Type().Elem() != datatype.ReflectType() {
+		return
+	}
+	capacity := v.Cap()
+	if capacity == 0 || capacity&(capacity-1) != 0 {
+		return
+	}
+	size := uint64(capacity) * datatype.Size()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.handedOut[v.Pointer()]; !ok {
+		return
+	}
+	delete(p.handedOut, v.Pointer())
+	if p.stats.RetainedBytes+size > p.maxBytes {
+		return
+	}
+	key := bufferPoolKey{datatype: datatype, capacity: capacity}
+	p.buffers[key] = append(p.buffers[key], v.Slice(0, capacity).Interface())
+	p.stats.RetainedBuffers++
+	p.stats.RetainedBytes += size
+}
+
+// disown forgets a buffer obtained from Get that will not be returned with Put, such as the
+// buffers of a batch detached from a ResultIterator.
+func (p *BufferPool) disown(buffer interface{}) {
+	if p == nil || buffer == nil {
+		return
+	}
+	v := reflect.ValueOf(buffer)
+	if v.Kind() != reflect.Slice {
+		return
+	}
+	p.mu.Lock()
+	delete(p.handedOut, v.Pointer())
+	p.mu.Unlock()
+}
+
+// Stats returns the hits, misses and retained buffers of the pool, which are all zero
+// for a nil pool.
+func (p *BufferPool) Stats() BufferPoolStats {
+	if p == nil {
+		return BufferPoolStats{}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats
+}
@@ -0,0 +1,97 @@
+package tiledb
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBufferPool(t *testing.T) {
+	pool := NewBufferPool(64)
+
+	buffer, err := pool.Get(TILEDB_INT32, 3)
+	require.NoError(t, err)
+	require.IsType(t, []int32{}, buffer)
+	assert.Len(t, buffer, 3)
+	assert.Equal(t, 4, cap(buffer.([]int32)))
+	pool.Put(TILEDB_INT32, buffer)
+	assert.Equal(t, BufferPoolStats{Misses: 1, RetainedBuffers: 1, RetainedBytes: 16}, pool.Stats())
+
+	// Buffers of the same datatype and capacity class are reused.
+	reused, err := pool.Get(TILEDB_INT32, 4)
+	require.NoError(t, err)
+	assert.Len(t, reused, 4)
+	_, err = pool.Get(TILEDB_INT64, 4)
+	require.NoError(t, err)
+	assert.Equal(t, BufferPoolStats{Hits: 1, Misses: 2}, pool.Stats())
+
+	// Buffers beyond the byte limit, or not allocated by the pool, are dropped.
+	large, err := pool.Get(TILEDB_INT64, 16)
+	require.NoError(t, err)
+	pool.Put(TILEDB_INT64, large)
+	pool.Put(TILEDB_INT32, make([]int32, 3))
+	pool.Put(TILEDB_INT32, make([]int32, 4))
+	pool.Put(TILEDB_INT64, reused)
+	pool.Put(TILEDB_INT32, 5)
+	assert.Zero(t, pool.Stats().RetainedBuffers)
+
+	// The memory of host buffers is never retained, even with a matching capacity.
+	host, err := NewHostBuffer[int32](4)
+	require.NoError(t, err)
+	pool.Put(TILEDB_INT32, host.Slice())
+	host.Free()
+	assert.Zero(t, pool.Stats().RetainedBuffers)
+
+	var nilPool *BufferPool
+	buffer, err = nilPool.Get(TILEDB_UINT8, 5)
+	require.NoError(t, err)
+	assert.Len(t, buffer, 5)
+	nilPool.Put(TILEDB_UINT8, buffer)
+	assert.Equal(t, BufferPoolStats{}, nilPool.Stats())
+}
+
+func TestBufferPoolConcurrent(t *testing.T) {
+	pool := NewBufferPool(1 << 20)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				buffer, err := pool.Get(TILEDB_FLOAT64, 100)
+				assert.NoError(t, err)
+				pool.Put(TILEDB_FLOAT64, buffer)
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := pool.Stats()
+	assert.EqualValues(t, 800, stats.Hits+stats.Misses)
+	assert.Equal(t, uint64(stats.RetainedBuffers)*128*8, stats.RetainedBytes)
+}
+
+func TestReadStructsBufferPool(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	defer array.Free()
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+
+	pool := NewBufferPool(1 << 20)
+	for range 2 {
+		query, err := NewQuery(array.context, array)
+		require.NoError(t, err)
+		require.NoError(t, query.SetLayout(TILEDB_ROW_MAJOR))
+		rows, err := ReadStructs[basicTestRow](query, WithBufferPool(pool))
+		require.NoError(t, err)
+		assert.Len(t, rows, 3)
+		query.Free()
+	}
+
+	stats := pool.Stats()
+	assert.NotZero(t, stats.Hits)
+	assert.NotZero(t, stats.RetainedBytes)
+}
@@ -0,0 +1,142 @@
+package tiledb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+/*
+NewCancellableQuery creates a query on array like NewQuery, with a Context of its own
+created with the configuration of the array's Context. SubmitContext cancels the work
+of such a query when its context.Context is done, without cancelling the work of other
+queries. The Context of the query is freed with it.
+*/
+func NewCancellableQuery(array *Array) (*Query, error) {
+	if array == nil {
+		return nil, errors.New("error creating cancellable tiledb query: passed array is nil")
+	}
+
+	config, err := array.context.Config()
+	if err != nil {
+		return nil, fmt.Errorf("error getting config for cancellable tiledb query: %w", err)
+	}
+	defer config.Free()
+
+	tdbCtx, err := NewContext(config)
+	if err != nil {
+		return nil, fmt.Errorf("error creating context for cancellable tiledb query: %w", err)
+	}
+
+	query, err := NewQuery(tdbCtx, array)
+	if err != nil {
+		tdbCtx.Free()
+		return nil, err
+	}
+	query.ownsContext = true
+	return query, nil
+}
+
+/*
+SubmitContext submits the query like Submit, and returns early when ctx is done. The
+returned error then matches ErrCancelled and ctx.Err(), i.e. context.Canceled or
+context.DeadlineExceeded.
+
+TileDB can only cancel the work of every query of a Context at once, with
+Context.CancelAllTasks. For a query created by NewCancellableQuery, whose Context is its
+own, SubmitContext cancels the tasks of that Context when ctx is done and returns once the
+work of the query stopped. If the query completed before it was cancelled, SubmitContext
+returns nil and its results are valid.
+
+The work of other queries cannot be cancelled without cancelling the other queries of their
+Context, so SubmitContext only stops waiting for it, and the work keeps running in the
+background until TileDB completes it. Until then, setting the buffers of the query and
+calling ResultBufferElements fail, while Submit, SubmitAsync and Free wait for it to finish.
+
+After a cancellation the results of the query are undefined, and it must be submitted again
+or freed.
+*/
+func (q *Query) SubmitContext(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return errorf(ErrCancelled, "error submitting query: %w", err)
+	}
+
+	q.submitMutex.Lock()
+	q.submitting.Store(true)
+	submit := func() error {
+		defer q.submitting.Store(false)
+		return q.submit()
+	}
+	var cancel func()
+	if q.ownsContext {
+		cancel = func() { _ = q.context.CancelAllTasks() }
+	}
+	return runCancellable(ctx, &q.submitMutex, submit, cancel, nil)
+}
+
+/*
+OpenContext opens the array like OpenWithOptions, and returns early when ctx is done. The
+returned error then matches ErrCancelled and ctx.Err(), i.e. context.Canceled or
+context.DeadlineExceeded, and the array is left closed.
+
+TileDB cannot cancel opening an array, so OpenContext only stops waiting for it: the open
+keeps running in the background until TileDB completes it. Open, Close and Free wait for it
+to finish, and the array is closed again if it was opened after the cancellation.
+*/
+func (a *Array) OpenContext(ctx context.Context, queryType QueryType, opts ...ArrayOpenOption) error {
+	if err := ctx.Err(); err != nil {
+		return errorf(ErrCancelled, "error opening tiledb array for querying: %w", err)
+	}
+
+	open := func() error {
+		return a.openWithOptions(queryType, opts...)
+	}
+	abandon := func(err error) {
+		if err == nil {
+			_ = a.close()
+		}
+	}
+
+	a.openMutex.Lock()
+	return runCancellable(ctx, a.openMutex, open, nil, abandon)
+}
+
+// runCancellable runs fn in a new goroutine, which unlocks mu when fn returns, and waits
+// for fn to return or for ctx to be done. mu must be locked by the caller.
+//
+// If ctx is done first and cancel is not nil, cancel is called to stop the work of fn, and
+// runCancellable waits for fn to return. Otherwise fn is abandoned: it keeps running, and
+// abandon, if not nil, is called with its error before mu is unlocked. In both cases the
+// returned error matches ErrCancelled and ctx.Err(), unless fn returned nil after cancel.
+func runCancellable(ctx context.Context, mu *sync.Mutex, fn func() error, cancel func(), abandon func(error)) error {
+	done := make(chan error)
+	abandoned := make(chan struct{})
+	go func() {
+		defer mu.Unlock()
+		err := fn()
+		select {
+		case done <- err:
+		case <-abandoned:
+			if abandon != nil {
+				abandon(err)
+			}
+		}
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+	}
+
+	if cancel != nil {
+		cancel()
+		if err := <-done; err != nil {
+			return errorf(ErrCancelled, "%w: %w", ctx.Err(), err)
+		}
+		return nil
+	}
+	close(abandoned)
+	return errorf(ErrCancelled, "%w", ctx.Err())
+}
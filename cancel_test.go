@@ -0,0 +1,125 @@
+package tiledb
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuerySubmitContext(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	defer array.Free()
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = array.OpenContext(cancelled, TILEDB_READ)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, ErrCancelled)
+	isOpen, err := array.IsOpen()
+	require.NoError(t, err)
+	assert.False(t, isOpen)
+
+	require.NoError(t, array.OpenContext(context.Background(), TILEDB_READ))
+	defer array.Close()
+
+	query, err := NewQuery(array.context, array)
+	require.NoError(t, err)
+	defer query.Free()
+	a1 := make([]int32, 3)
+	_, err = query.SetDataBuffer("a1", a1)
+	require.NoError(t, err)
+
+	err = query.SubmitContext(cancelled)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, ErrCancelled)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	require.NoError(t, query.SubmitContext(ctx))
+	assert.Equal(t, testAttributeValues.Attribute1, a1)
+}
+
+func TestNewCancellableQuery(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	defer array.Free()
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+
+	query, err := NewCancellableQuery(array)
+	require.NoError(t, err)
+	defer query.Free()
+	assert.NotSame(t, array.context, query.Context())
+	a1 := make([]int32, 3)
+	_, err = query.SetDataBuffer("a1", a1)
+	require.NoError(t, err)
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = query.SubmitContext(cancelled)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, ErrCancelled)
+
+	require.NoError(t, query.SubmitContext(context.Background()))
+	assert.Equal(t, testAttributeValues.Attribute1, a1)
+}
+
+func TestRunCancellable(t *testing.T) {
+	var mu sync.Mutex
+	release := make(chan struct{})
+	abandoned := make(chan error, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	mu.Lock()
+	err := runCancellable(ctx, &mu, func() error {
+		<-release
+		return nil
+	}, nil, func(err error) {
+		abandoned <- err
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	// The abandoned function still holds the lock.
+	assert.False(t, mu.TryLock())
+
+	close(release)
+	assert.NoError(t, <-abandoned)
+	mu.Lock()
+	mu.Unlock()
+
+	mu.Lock()
+	err = runCancellable(context.Background(), &mu, func() error { return errSubmitInFlight }, nil, nil)
+	assert.ErrorIs(t, err, errSubmitInFlight)
+	// The lock is released once the function returned.
+	mu.Lock()
+	mu.Unlock()
+
+	// A function stopped by cancel is waited for, and its error is kept.
+	stopped := errors.New("stopped")
+	release = make(chan struct{})
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	mu.Lock()
+	err = runCancellable(ctx, &mu, func() error {
+		<-release
+		return stopped
+	}, func() { close(release) }, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, err, ErrCancelled)
+	assert.ErrorIs(t, err, stopped)
+	mu.Lock()
+	mu.Unlock()
+
+	// A function that completes despite cancel succeeds.
+	mu.Lock()
+	err = runCancellable(ctx, &mu, func() error { return nil }, func() {}, nil)
+	assert.NoError(t, err)
+	mu.Lock()
+	mu.Unlock()
+}
@@ -0,0 +1,190 @@
+// tiledb-filter-eval compares filter pipelines on sample data of an attribute. It writes the
+// sample to a temporary array per pipeline, then reports the compression ratio and the write
+// and read throughputs of each pipeline, best first against the chosen objective:
+//
+//	go run ./cmd/tiledb-filter-eval -type FLOAT64 -sample values.txt \
+//		-pipeline none -pipeline zstd=7 -pipeline byteshuffle,zstd=7 -objective ratio
+//
+// Numeric samples hold whitespace-separated values; string samples hold one value per line.
+// Pipelines are filters separated by commas, each a name optionally followed by "=" and its
+// arguments separated by colons:
+//
+//	gzip[=level], zstd[=level], lz4[=level], bzip2[=level]
+//	delta[=reinterpret datatype], double-delta[=reinterpret datatype]
+//	bit-width-reduction[=window], positive-delta[=window]
+//	scale-float=factor:offset:byte width
+//	rle, dictionary, bitshuffle, byteshuffle, xor, checksum-md5, checksum-sha256
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	tiledb "github.com/TileDB-Inc/TileDB-Go"
+)
+
+// pipelinesFlag collects the pipelines given with repeated -pipeline flags.
+type pipelinesFlag []string
+
+func (p *pipelinesFlag) String() string { return strings.Join(*p, " ") }
+
+func (p *pipelinesFlag) Set(spec string) error {
+	*p = append(*p, spec)
+	return nil
+}
+
+var (
+	typeFlag       = flag.String("type", "FLOAT64", "The datatype of the attribute, e.g. INT32 or STRING_ASCII.")
+	sampleFlag     = flag.String("sample", "", "The file holding the sample values, or - for the standard input.")
+	objectiveFlag  = flag.String("objective", "ratio", "The objective the pipelines are ranked against: ratio, write or read.")
+	uriFlag        = flag.String("uri", "", "The location of the temporary arrays, e.g. mem:// or a local directory. Defaults to a temporary directory.")
+	tileExtentFlag = flag.Uint64("tile-extent", 0, "The number of cells per tile. Defaults to the smaller of 10000 and the number of values.")
+	pipelines      pipelinesFlag
+)
+
+func main() {
+	flag.Var(&pipelines, "pipeline", "A filter pipeline to evaluate; may be repeated.")
+	flag.Parse()
+	if *sampleFlag == "" || len(pipelines) == 0 {
+		fmt.Fprint(os.Stderr, "a sample and at least one pipeline must be specified.\n")
+		flag.Usage()
+		os.Exit(2)
+	}
+	objective, err := parseObjective(*objectiveFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if err := run(objective); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func parseObjective(s string) (tiledb.FilterObjective, error) {
+	switch s {
+	case "ratio":
+		return tiledb.FilterObjectiveCompressionRatio, nil
+	case "write":
+		return tiledb.FilterObjectiveWriteThroughput, nil
+	case "read":
+		return tiledb.FilterObjectiveReadThroughput, nil
+	}
+	return 0, fmt.Errorf("unknown objective %q: must be ratio, write or read", s)
+}
+
+func run(objective tiledb.FilterObjective) error {
+	datatype, err := tiledb.DatatypeFromString(strings.ToUpper(*typeFlag))
+	if err != nil {
+		return err
+	}
+	sample, err := readSample(*sampleFlag, datatype)
+	if err != nil {
+		return fmt.Errorf("could not read sample: %w", err)
+	}
+
+	tdbCtx, err := tiledb.NewContext(nil)
+	if err != nil {
+		return err
+	}
+	defer tdbCtx.Free()
+
+	candidates := make([]*tiledb.FilterList, len(pipelines))
+	for i, spec := range pipelines {
+		if candidates[i], err = parsePipeline(tdbCtx, spec); err != nil {
+			return err
+		}
+		defer candidates[i].Free()
+	}
+
+	opts := []tiledb.FilterEvaluationOption{tiledb.WithEvaluationTileExtent(*tileExtentFlag)}
+	if *uriFlag != "" {
+		opts = append(opts, tiledb.WithEvaluationURI(*uriFlag))
+	}
+	results, err := tiledb.EvaluateFilterPipelines(tdbCtx, sample, datatype, candidates, opts...)
+	if err != nil {
+		return err
+	}
+	tiledb.RankFilterPipelines(results, objective)
+
+	fmt.Printf("%d %v values, %d bytes, ranked by %v\n\n", reflect.ValueOf(sample).Len(), datatype, results[0].RawBytes, objective)
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "rank\tpipeline\tfragment bytes\tratio\twrite MB/s\tread MB/s")
+	for rank, r := range results {
+		if r.Err != nil {
+			fmt.Fprintf(w, "%d\t%s\terror\t-\t-\t-\n", rank+1, pipelines[r.Index])
+			continue
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%.2f\t%.1f\t%.1f\n", rank+1, pipelines[r.Index], r.FragmentBytes,
+			r.CompressionRatio, r.WriteThroughput/1e6, r.ReadThroughput/1e6)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Printf("\n%s: %v\n", pipelines[r.Index], r.Err)
+		}
+	}
+	return nil
+}
+
+// readSample reads the sample values of datatype from a file, or the standard input for "-".
+func readSample(path string, datatype tiledb.Datatype) (interface{}, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	if datatype == tiledb.TILEDB_STRING_ASCII || datatype == tiledb.TILEDB_STRING_UTF8 || datatype == tiledb.TILEDB_CHAR {
+		var values []string
+		for scanner.Scan() {
+			values = append(values, scanner.Text())
+		}
+		return values, scanner.Err()
+	}
+
+	t := datatype.ReflectType()
+	if t == nil {
+		return nil, fmt.Errorf("unsupported datatype %v", datatype)
+	}
+	bits := int(datatype.Size()) * 8
+	values := reflect.MakeSlice(reflect.SliceOf(t), 0, 0)
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		field := scanner.Text()
+		var v interface{}
+		var err error
+		switch t.Kind() {
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			v, err = strconv.ParseInt(field, 0, bits)
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			v, err = strconv.ParseUint(field, 0, bits)
+		case reflect.Float32, reflect.Float64:
+			v, err = strconv.ParseFloat(field, bits)
+		case reflect.Bool:
+			v, err = strconv.ParseBool(field)
+		default:
+			return nil, fmt.Errorf("unsupported datatype %v", datatype)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v value %q", datatype, field)
+		}
+		values = reflect.Append(values, reflect.ValueOf(v).Convert(t))
+	}
+	return values.Interface(), scanner.Err()
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tiledb "github.com/TileDB-Inc/TileDB-Go"
+)
+
+// parsePipeline builds the filter list described by spec: filters separated by commas, each a
+// name optionally followed by "=" and its arguments separated by colons, as in
+// "bitshuffle,zstd=7". The spec "none" is an empty pipeline.
+func parsePipeline(tdbCtx *tiledb.Context, spec string) (*tiledb.FilterList, error) {
+	list, err := tiledb.NewFilterList(tdbCtx)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(spec) == "none" {
+		return list, nil
+	}
+	for _, filterSpec := range strings.Split(spec, ",") {
+		filter, err := parseFilter(tdbCtx, strings.TrimSpace(filterSpec))
+		if err != nil {
+			list.Free()
+			return nil, fmt.Errorf("invalid pipeline %q: %w", spec, err)
+		}
+		err = list.AddFilter(filter)
+		filter.Free()
+		if err != nil {
+			list.Free()
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+// parseFilter builds the filter described by spec.
+func parseFilter(tdbCtx *tiledb.Context, spec string) (*tiledb.Filter, error) {
+	name, argList, _ := strings.Cut(spec, "=")
+	var args []string
+	if argList != "" {
+		args = strings.Split(argList, ":")
+	}
+	argc := func(min, max int) error {
+		if len(args) < min || len(args) > max {
+			return fmt.Errorf("filter %s takes %d to %d arguments, got %d", name, min, max, len(args))
+		}
+		return nil
+	}
+
+	switch name {
+	case "gzip", "zstd", "lz4", "bzip2":
+		if err := argc(0, 1); err != nil {
+			return nil, err
+		}
+		// -1 selects the default level of the compressor.
+		level := int32(-1)
+		if len(args) == 1 {
+			v, err := strconv.ParseInt(args[0], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s level %q", name, args[0])
+			}
+			level = int32(v)
+		}
+		switch name {
+		case "gzip":
+			return tiledb.NewGzipFilter(tdbCtx, level)
+		case "zstd":
+			return tiledb.NewZstdFilter(tdbCtx, level)
+		case "lz4":
+			return tiledb.NewLZ4Filter(tdbCtx, level)
+		}
+		return tiledb.NewBzip2Filter(tdbCtx, level)
+	case "double-delta", "delta":
+		if err := argc(0, 1); err != nil {
+			return nil, err
+		}
+		reinterpret := tiledb.TILEDB_ANY
+		if len(args) == 1 {
+			var err error
+			if reinterpret, err = tiledb.DatatypeFromString(strings.ToUpper(args[0])); err != nil {
+				return nil, err
+			}
+		}
+		if name == "delta" {
+			return tiledb.NewDeltaFilter(tdbCtx, reinterpret)
+		}
+		return tiledb.NewDoubleDeltaFilter(tdbCtx, reinterpret)
+	case "bit-width-reduction", "positive-delta":
+		if err := argc(0, 1); err != nil {
+			return nil, err
+		}
+		// The default maximum window of TileDB.
+		window := uint32(256)
+		if len(args) == 1 {
+			v, err := strconv.ParseUint(args[0], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s window %q", name, args[0])
+			}
+			window = uint32(v)
+		}
+		if name == "positive-delta" {
+			return tiledb.NewPositiveDeltaFilter(tdbCtx, window)
+		}
+		return tiledb.NewBitWidthReductionFilter(tdbCtx, window)
+	case "scale-float":
+		if err := argc(3, 3); err != nil {
+			return nil, err
+		}
+		factor, err := strconv.ParseFloat(args[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scale-float factor %q", args[0])
+		}
+		offset, err := strconv.ParseFloat(args[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scale-float offset %q", args[1])
+		}
+		byteWidth, err := strconv.ParseUint(args[2], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scale-float byte width %q", args[2])
+		}
+		return tiledb.NewScaleFloatFilter(tdbCtx, factor, offset, byteWidth)
+	}
+
+	if err := argc(0, 0); err != nil {
+		return nil, err
+	}
+	switch name {
+	case "rle":
+		return tiledb.NewRLEFilter(tdbCtx)
+	case "dictionary":
+		return tiledb.NewDictionaryFilter(tdbCtx)
+	case "bitshuffle":
+		return tiledb.NewBitShuffleFilter(tdbCtx)
+	case "byteshuffle":
+		return tiledb.NewByteShuffleFilter(tdbCtx)
+	case "xor":
+		return tiledb.NewXORFilter(tdbCtx)
+	case "checksum-md5":
+		return tiledb.NewChecksumMD5Filter(tdbCtx)
+	case "checksum-sha256":
+		return tiledb.NewChecksumSHA256Filter(tdbCtx)
+	}
+	return nil, fmt.Errorf("unknown filter %q", name)
+}
@@ -6,7 +6,6 @@ package tiledb
 */
 import "C"
 import (
-	"errors"
 	"unsafe"
 )
 
@@ -43,15 +42,13 @@ func cStringArray(stringList []string) ([]*C.char, func()) {
 // cError creates an error value from a TileDB error.
 func cError(err *C.tiledb_error_t) error {
 	var str *C.char
-	var msg string
 
 	switch C.tiledb_error_message(err, &str) {
 	case C.TILEDB_OK:
-		msg = C.GoString(str)
+		return &Error{Code: TILEDB_ERR, Message: C.GoString(str)}
 	case C.TILEDB_OOM:
-		msg = "out of memory error while retrieving TileDB error message"
+		return &Error{Code: TILEDB_OOM, Op: "tiledb_error_message", Message: "out of memory error while retrieving TileDB error message"}
 	default:
-		msg = "could not retrieve error"
+		return &Error{Code: TILEDB_ERR, Op: "tiledb_error_message", Message: "could not retrieve error"}
 	}
-	return errors.New(msg)
 }
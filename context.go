@@ -128,15 +128,15 @@ func (c *Context) Config() (*Config, error) {
 	return newConfigFromHandle(newConfigHandle(configPtr)), nil
 }
 
-// LastError returns the last error from this context.
+// LastError returns the last error from this context. It is a *Error, or nil if there was no error.
 func (c *Context) LastError() error {
 	var err *C.tiledb_error_t
 	ret := C.tiledb_ctx_get_last_error(c.tiledbContext.Get(), &err)
 	runtime.KeepAlive(c)
 	if ret == C.TILEDB_OOM {
-		return errors.New("out of Memory error in tiledb_ctx_get_last_error")
+		return &Error{Code: TILEDB_OOM, Op: "tiledb_ctx_get_last_error", Message: "out of Memory error in tiledb_ctx_get_last_error"}
 	} else if ret != C.TILEDB_OK {
-		return errors.New("unknown error in tiledb_ctx_get_last_error")
+		return &Error{Code: ErrorCode(ret), Op: "tiledb_ctx_get_last_error", Message: "unknown error in tiledb_ctx_get_last_error"}
 	}
 
 	if err != nil {
@@ -146,6 +146,20 @@ func (c *Context) LastError() error {
 	return nil
 }
 
+// lastError returns the last error from this context, reported by the C API function op
+// that failed with the return code ret.
+func (c *Context) lastError(op string, ret C.int32_t) error {
+	err := c.LastError()
+	if err == nil {
+		return &Error{Code: ErrorCode(ret), Op: op, Message: op + " failed without an error message"}
+	}
+	if tdbErr, ok := err.(*Error); ok && tdbErr.Op == "" {
+		tdbErr.Code = ErrorCode(ret)
+		tdbErr.Op = op
+	}
+	return err
+}
+
 // IsSupportedFS returns true if the given filesystem backend is supported.
 func (c *Context) IsSupportedFS(fs FS) (bool, error) {
 	var isSupported C.int32_t
@@ -170,7 +184,7 @@ func (c *Context) SetTag(key string, value string) error {
 	runtime.KeepAlive(c)
 
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error in setting tag: %w", c.LastError())
+		return fmt.Errorf("error in setting tag: %w", c.lastError("tiledb_ctx_set_tag", ret))
 	}
 
 	return nil
@@ -199,7 +213,7 @@ func (c *Context) setDefaultTags() error {
 func (c *Context) Stats() ([]byte, error) {
 	var stats *C.char
 	if ret := C.tiledb_ctx_get_stats(c.tiledbContext.Get(), &stats); ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting stats from context: %w", c.LastError())
+		return nil, fmt.Errorf("error getting stats from context: %w", c.lastError("tiledb_ctx_get_stats", ret))
 	}
 	runtime.KeepAlive(c)
 
@@ -223,7 +237,7 @@ func (c *Context) DataProtocol(uri string) (DataProtocol, error) {
 	ret := C.tiledb_ctx_get_data_protocol(c.tiledbContext.Get(), cURI, &protocol)
 	runtime.KeepAlive(c)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting data protocol for uri %s: %w", uri, c.LastError())
+		return 0, fmt.Errorf("error getting data protocol for uri %s: %w", uri, c.lastError("tiledb_ctx_get_data_protocol", ret))
 	}
 
 	return DataProtocol(protocol), nil
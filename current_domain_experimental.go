@@ -0,0 +1,122 @@
+package tiledb
+
+/*
+#include <tiledb/tiledb.h>
+#include <tiledb/tiledb_experimental.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+// CurrentDomainType is the kind of a current domain.
+type CurrentDomainType uint8
+
+const (
+	// TILEDB_NDRECTANGLE is a current domain defined by an NDRectangle.
+	TILEDB_NDRECTANGLE CurrentDomainType = C.TILEDB_NDRECTANGLE
+)
+
+type currentDomainHandle struct{ *capiHandle }
+
+func freeCapiCurrentDomain(c unsafe.Pointer) {
+	C.tiledb_current_domain_free((**C.tiledb_current_domain_t)(unsafe.Pointer(&c)))
+}
+
+func newCurrentDomainHandle(ptr *C.tiledb_current_domain_t) currentDomainHandle {
+	return currentDomainHandle{newCapiHandle(unsafe.Pointer(ptr), freeCapiCurrentDomain)}
+}
+
+func (x currentDomainHandle) Get() *C.tiledb_current_domain_t {
+	return (*C.tiledb_current_domain_t)(x.capiHandle.Get())
+}
+
+// CurrentDomain is the part of the domain of an array that is currently in use.
+// Cells can only be written within the current domain, and the current domain
+// can be expanded with ArraySchemaEvolution.ExpandCurrentDomain without recreating
+// the array.
+type CurrentDomain struct {
+	context       *Context
+	currentDomain currentDomainHandle
+}
+
+func newCurrentDomainFromHandle(context *Context, handle currentDomainHandle) *CurrentDomain {
+	return &CurrentDomain{context: context, currentDomain: handle}
+}
+
+// NewCurrentDomain allocates a new, empty current domain.
+func NewCurrentDomain(tdbCtx *Context) (*CurrentDomain, error) {
+	var cdPtr *C.tiledb_current_domain_t
+	ret := C.tiledb_current_domain_create(tdbCtx.tiledbContext.Get(), &cdPtr)
+	runtime.KeepAlive(tdbCtx)
+	if ret != C.TILEDB_OK {
+		return nil, fmt.Errorf("error creating tiledb current domain: %w", tdbCtx.lastError("tiledb_current_domain_create", ret))
+	}
+
+	return newCurrentDomainFromHandle(tdbCtx, newCurrentDomainHandle(cdPtr)), nil
+}
+
+// Free releases the internal TileDB core data that was allocated on the C heap.
+// It is automatically called when this object is garbage collected, but can be
+// called earlier to manually release memory if needed. Free is idempotent and
+// can safely be called many times on the same object; if it has already
+// been freed, it will not be freed again.
+func (cd *CurrentDomain) Free() {
+	cd.currentDomain.Free()
+}
+
+// Context exposes the internal TileDB context used to initialize the current domain.
+func (cd *CurrentDomain) Context() *Context {
+	return cd.context
+}
+
+// SetNDRectangle sets the NDRectangle of the current domain. The current domain
+// becomes of type TILEDB_NDRECTANGLE.
+func (cd *CurrentDomain) SetNDRectangle(ndr *NDRectangle) error {
+	ret := C.tiledb_current_domain_set_ndrectangle(cd.context.tiledbContext.Get(), cd.currentDomain.Get(), ndr.ndRectangle.Get())
+	runtime.KeepAlive(cd)
+	runtime.KeepAlive(ndr)
+	if ret != C.TILEDB_OK {
+		return fmt.Errorf("error setting ndrectangle of current domain: %w", cd.context.lastError("tiledb_current_domain_set_ndrectangle", ret))
+	}
+	return nil
+}
+
+// NDRectangle returns the NDRectangle of the current domain.
+func (cd *CurrentDomain) NDRectangle() (*NDRectangle, error) {
+	var ndrPtr *C.tiledb_ndrectangle_t
+	ret := C.tiledb_current_domain_get_ndrectangle(cd.context.tiledbContext.Get(), cd.currentDomain.Get(), &ndrPtr)
+	runtime.KeepAlive(cd)
+	if ret != C.TILEDB_OK {
+		return nil, fmt.Errorf("error getting ndrectangle of current domain: %w", cd.context.lastError("tiledb_current_domain_get_ndrectangle", ret))
+	}
+
+	return newNDRectangleFromHandle(cd.context, newNDRectangleHandle(ndrPtr)), nil
+}
+
+// IsEmpty returns whether the current domain is empty. An array whose schema has an
+// empty current domain can be written anywhere in its domain.
+func (cd *CurrentDomain) IsEmpty() (bool, error) {
+	var isEmpty C.uint32_t
+	ret := C.tiledb_current_domain_get_is_empty(cd.context.tiledbContext.Get(), cd.currentDomain.Get(), &isEmpty)
+	runtime.KeepAlive(cd)
+	if ret != C.TILEDB_OK {
+		return false, fmt.Errorf("error checking if current domain is empty: %w", cd.context.lastError("tiledb_current_domain_get_is_empty", ret))
+	}
+	return isEmpty == 1, nil
+}
+
+// Type returns the type of the current domain.
+func (cd *CurrentDomain) Type() (CurrentDomainType, error) {
+	var cdType C.tiledb_current_domain_type_t
+	ret := C.tiledb_current_domain_get_type(cd.context.tiledbContext.Get(), cd.currentDomain.Get(), &cdType)
+	runtime.KeepAlive(cd)
+	if ret != C.TILEDB_OK {
+		return 0, fmt.Errorf("error getting type of current domain: %w", cd.context.lastError("tiledb_current_domain_get_type", ret))
+	}
+	return CurrentDomainType(cdType), nil
+}
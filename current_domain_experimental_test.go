@@ -0,0 +1,142 @@
+package tiledb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNDRectangle(t *testing.T) {
+	context, err := NewContext(nil)
+	require.NoError(t, err)
+
+	d1, err := NewDimension(context, "d1", TILEDB_INT32, []int32{1, 100}, int32(10))
+	require.NoError(t, err)
+	d2, err := NewStringDimension(context, "d2")
+	require.NoError(t, err)
+	domain, err := NewDomain(context)
+	require.NoError(t, err)
+	require.NoError(t, domain.AddDimensions(d1, d2))
+
+	ndr, err := NewNDRectangle(context, domain)
+	require.NoError(t, err)
+	defer ndr.Free()
+
+	ndim, err := ndr.NDim()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), ndim)
+
+	require.NoError(t, ndr.SetRange(0, MakeRange[int32](1, 10)))
+	require.NoError(t, ndr.SetRangeForName("d2", MakeRange("a", "m")))
+
+	// Ranges must have the type of the dimension.
+	assert.Error(t, ndr.SetRange(0, MakeRange[int64](1, 10)))
+	assert.Error(t, ndr.SetRangeForName("d2", MakeRange[int32](1, 10)))
+
+	r, err := ndr.RangeFromName("d1")
+	require.NoError(t, err)
+	d1Range, err := ExtractRange[int32](r)
+	require.NoError(t, err)
+	assert.Equal(t, []int32{1, 10}, d1Range[:2])
+
+	r, err = ndr.Range(1)
+	require.NoError(t, err)
+	d2Range, err := ExtractRange[string](r)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "m"}, d2Range[:2])
+
+	datatype, err := ndr.DatatypeFromName("d2")
+	require.NoError(t, err)
+	assert.Equal(t, TILEDB_STRING_ASCII, datatype)
+}
+
+func TestCurrentDomain(t *testing.T) {
+	context, err := NewContext(nil)
+	require.NoError(t, err)
+
+	dimension, err := NewDimension(context, "d", TILEDB_INT32, []int32{1, 100}, int32(10))
+	require.NoError(t, err)
+	domain, err := NewDomain(context)
+	require.NoError(t, err)
+	require.NoError(t, domain.AddDimensions(dimension))
+	a, err := NewAttribute(context, "a", TILEDB_INT32)
+	require.NoError(t, err)
+	schema, err := NewArraySchema(context, TILEDB_SPARSE)
+	require.NoError(t, err)
+	require.NoError(t, schema.SetDomain(domain))
+	require.NoError(t, schema.AddAttributes(a))
+
+	empty, err := schema.CurrentDomain()
+	require.NoError(t, err)
+	isEmpty, err := empty.IsEmpty()
+	require.NoError(t, err)
+	assert.True(t, isEmpty)
+
+	newCurrentDomain := func(t *testing.T, upper int32) *CurrentDomain {
+		ndr, err := NewNDRectangle(context, domain)
+		require.NoError(t, err)
+		require.NoError(t, ndr.SetRange(0, MakeRange[int32](1, upper)))
+		cd, err := NewCurrentDomain(context)
+		require.NoError(t, err)
+		require.NoError(t, cd.SetNDRectangle(ndr))
+		return cd
+	}
+	require.NoError(t, schema.SetCurrentDomain(newCurrentDomain(t, 10)))
+
+	uri := t.TempDir()
+	require.NoError(t, CreateArray(context, uri, schema))
+
+	write := func(d int32) error {
+		array, err := NewArray(context, uri)
+		require.NoError(t, err)
+		defer array.Free()
+		require.NoError(t, array.Open(TILEDB_WRITE))
+		defer array.Close()
+
+		query, err := NewQuery(context, array)
+		require.NoError(t, err)
+		defer query.Free()
+		require.NoError(t, query.SetLayout(TILEDB_UNORDERED))
+		_, err = query.SetDataBuffer("d", []int32{d})
+		require.NoError(t, err)
+		_, err = query.SetDataBuffer("a", []int32{d})
+		require.NoError(t, err)
+		return query.Submit()
+	}
+
+	require.NoError(t, write(5))
+	assert.Error(t, write(50))
+
+	evolution, err := NewArraySchemaEvolution(context)
+	require.NoError(t, err)
+	require.NoError(t, evolution.ExpandCurrentDomain(newCurrentDomain(t, 60)))
+	require.NoError(t, evolution.Evolve(uri))
+
+	require.NoError(t, write(50))
+
+	array, err := NewArray(context, uri)
+	require.NoError(t, err)
+	defer array.Free()
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+	arraySchema, err := array.Schema()
+	require.NoError(t, err)
+	defer arraySchema.Free()
+
+	cd, err := arraySchema.CurrentDomain()
+	require.NoError(t, err)
+	defer cd.Free()
+	cdType, err := cd.Type()
+	require.NoError(t, err)
+	assert.Equal(t, TILEDB_NDRECTANGLE, cdType)
+
+	ndr, err := cd.NDRectangle()
+	require.NoError(t, err)
+	defer ndr.Free()
+	r, err := ndr.Range(0)
+	require.NoError(t, err)
+	bounds, err := ExtractRange[int32](r)
+	require.NoError(t, err)
+	assert.Equal(t, []int32{1, 60}, bounds[:2])
+}
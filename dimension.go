@@ -254,7 +254,7 @@ func NewDimension(context *Context, name string, datatype Datatype, domain inter
 	runtime.KeepAlive(context)
 
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error creating tiledb dimension: %w", context.LastError())
+		return nil, fmt.Errorf("error creating tiledb dimension: %w", context.lastError("tiledb_dimension_alloc", ret))
 	}
 
 	return newDimensionFromHandle(context, newDimensionHandle(dimensionPtr)), nil
@@ -274,7 +274,7 @@ func NewStringDimension(context *Context, name string) (*Dimension, error) {
 	runtime.KeepAlive(context)
 
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error creating tiledb dimension: %w", context.LastError())
+		return nil, fmt.Errorf("error creating tiledb dimension: %w", context.lastError("tiledb_dimension_alloc", ret))
 	}
 
 	return newDimensionFromHandle(context, newDimensionHandle(dimensionPtr)), nil
@@ -294,13 +294,22 @@ func (d *Dimension) Context() *Context {
 	return d.context
 }
 
-// SetFilterList sets the dimension filterList.
+// SetFilterList sets the dimension filterList. It returns an error if the filters
+// cannot be applied to the datatype of the dimension; see FilterList.Validate.
 func (d *Dimension) SetFilterList(filterlist *FilterList) error {
+	datatype, err := d.Type()
+	if err != nil {
+		return err
+	}
+	if err := filterlist.Validate(datatype); err != nil {
+		return fmt.Errorf("error setting tiledb dimension filter list: %w", err)
+	}
+
 	ret := C.tiledb_dimension_set_filter_list(d.context.tiledbContext.Get(), d.tiledbDimension.Get(), filterlist.tiledbFilterList.Get())
 	runtime.KeepAlive(d)
 	runtime.KeepAlive(filterlist)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting tiledb dimension filter list: %w", d.context.LastError())
+		return fmt.Errorf("error setting tiledb dimension filter list: %w", d.context.lastError("tiledb_dimension_set_filter_list", ret))
 	}
 	return nil
 }
@@ -311,7 +320,7 @@ func (d *Dimension) FilterList() (*FilterList, error) {
 	ret := C.tiledb_dimension_get_filter_list(d.context.tiledbContext.Get(), d.tiledbDimension.Get(), &filterListPtr)
 	runtime.KeepAlive(d)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting tiledb dimension filter list: %w", d.context.LastError())
+		return nil, fmt.Errorf("error getting tiledb dimension filter list: %w", d.context.lastError("tiledb_dimension_get_filter_list", ret))
 	}
 
 	return newFilterListFromHandle(d.context, newFilterListHandle(filterListPtr)), nil
@@ -326,7 +335,7 @@ func (d *Dimension) SetCellValNum(val uint32) error {
 		d.tiledbDimension.Get(), C.uint32_t(val))
 	runtime.KeepAlive(d)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting tiledb dimension cell val num: %w", d.context.LastError())
+		return fmt.Errorf("error setting tiledb dimension cell val num: %w", d.context.lastError("tiledb_dimension_set_cell_val_num", ret))
 	}
 	return nil
 }
@@ -338,7 +347,7 @@ func (d *Dimension) CellValNum() (uint32, error) {
 	ret := C.tiledb_dimension_get_cell_val_num(d.context.tiledbContext.Get(), d.tiledbDimension.Get(), &cellValNum)
 	runtime.KeepAlive(d)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting tiledb dimension cell val num: %w", d.context.LastError())
+		return 0, fmt.Errorf("error getting tiledb dimension cell val num: %w", d.context.lastError("tiledb_dimension_get_cell_val_num", ret))
 	}
 
 	return uint32(cellValNum), nil
@@ -349,7 +358,7 @@ func (d *Dimension) Name() (string, error) {
 	var cName *C.char // d must be kept alive while cName is being accessed.
 	ret := C.tiledb_dimension_get_name(d.context.tiledbContext.Get(), d.tiledbDimension.Get(), &cName)
 	if ret != C.TILEDB_OK {
-		return "", fmt.Errorf("error getting tiledb dimension name: %w", d.context.LastError())
+		return "", fmt.Errorf("error getting tiledb dimension name: %w", d.context.lastError("tiledb_dimension_get_name", ret))
 	}
 
 	name := C.GoString(cName)
@@ -363,13 +372,14 @@ func (d *Dimension) Type() (Datatype, error) {
 	ret := C.tiledb_dimension_get_type(d.context.tiledbContext.Get(), d.tiledbDimension.Get(), &cType)
 	runtime.KeepAlive(d)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting tiledb dimension type: %w", d.context.LastError())
+		return 0, fmt.Errorf("error getting tiledb dimension type: %w", d.context.lastError("tiledb_dimension_get_type", ret))
 	}
 
 	return Datatype(cType), nil
 }
 
-// Domain returns the dimension's domain.
+// Domain returns the dimension's domain. The domain of a datetime or time dimension is
+// returned as []int64 timestamps.
 func (d *Dimension) Domain() (interface{}, error) {
 	datatype, err := d.Type()
 	if err != nil {
@@ -408,6 +418,9 @@ func (d *Dimension) Domain() (interface{}, error) {
 	case TILEDB_STRING_ASCII:
 		return nil, nil
 	}
+	if isTimeDatatype(datatype) {
+		return domainInternal[int64](d)
+	}
 	return nil, fmt.Errorf("unrecognized domain type: %d", datatype)
 }
 
@@ -415,7 +428,7 @@ func domainInternal[T any](d *Dimension) ([]T, error) {
 	var cDomain unsafe.Pointer // d must be kept alive while cDomain is being accessed.
 	ret := C.tiledb_dimension_get_domain(d.context.tiledbContext.Get(), d.tiledbDimension.Get(), &cDomain)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting tiledb dimension's domain: %w", d.context.LastError())
+		return nil, fmt.Errorf("error getting tiledb dimension's domain: %w", d.context.lastError("tiledb_dimension_get_domain", ret))
 	}
 	asArray := (*[2]T)(cDomain)
 	result := []T{asArray[0], asArray[1]}
@@ -423,7 +436,8 @@ func domainInternal[T any](d *Dimension) ([]T, error) {
 	return result, nil
 }
 
-// Extent returns the dimension's extent.
+// Extent returns the dimension's extent. The extent of a datetime or time dimension is
+// returned as an int64.
 func (d *Dimension) Extent() (interface{}, error) {
 	datatype, err := d.Type()
 	if err != nil {
@@ -456,6 +470,9 @@ func (d *Dimension) Extent() (interface{}, error) {
 	case TILEDB_STRING_ASCII:
 		return nil, nil
 	}
+	if isTimeDatatype(datatype) {
+		return extentInternal[int64](d)
+	}
 	return nil, fmt.Errorf("unrecognized extent type: %d", datatype)
 }
 
@@ -464,7 +481,7 @@ func extentInternal[T any](d *Dimension) (T, error) {
 	var output T
 	cRet := C.tiledb_dimension_get_tile_extent(d.context.tiledbContext.Get(), d.tiledbDimension.Get(), &cExtent)
 	if cRet != C.TILEDB_OK {
-		return output, fmt.Errorf("could not get TileDB dimension's extent: %w", d.context.LastError())
+		return output, fmt.Errorf("could not get TileDB dimension's extent: %w", d.context.lastError("tiledb_dimension_get_tile_extent", cRet))
 	}
 	output = *(*T)(cExtent)
 	runtime.KeepAlive(d)
@@ -477,7 +494,7 @@ func (d *Dimension) DumpToString() (string, error) {
 	ret := C.tiledb_dimension_dump_str(d.context.tiledbContext.Get(), d.tiledbDimension.Get(), &cStr)
 	runtime.KeepAlive(d)
 	if ret != C.TILEDB_OK {
-		return "", fmt.Errorf("error dumping dimension to string: %w", d.context.LastError())
+		return "", fmt.Errorf("error dumping dimension to string: %w", d.context.lastError("tiledb_dimension_dump_str", ret))
 	}
 	defer C.tiledb_string_free(&cStr)
 
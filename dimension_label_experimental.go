@@ -51,7 +51,7 @@ func (d *DimensionLabel) DimensionIndex() (uint32, error) {
 	ret := C.tiledb_dimension_label_get_dimension_index(d.context.tiledbContext.Get(), d.tiledbDimensionLabel.Get(), &dimensionIndex)
 	runtime.KeepAlive(d)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error fetching dimension index for dimension label: %w", d.context.LastError())
+		return 0, fmt.Errorf("error fetching dimension index for dimension label: %w", d.context.lastError("tiledb_dimension_label_get_dimension_index", ret))
 	}
 
 	return uint32(dimensionIndex), nil
@@ -62,7 +62,7 @@ func (d *DimensionLabel) AttributeName() (string, error) {
 	var cLabelAttrName *C.char // d must be kept alive while cLabelAttrName is being accessed.
 	ret := C.tiledb_dimension_label_get_label_attr_name(d.context.tiledbContext.Get(), d.tiledbDimensionLabel.Get(), &cLabelAttrName)
 	if ret != C.TILEDB_OK {
-		return "", fmt.Errorf("error getting dimension label attribute name: %w", d.context.LastError())
+		return "", fmt.Errorf("error getting dimension label attribute name: %w", d.context.lastError("tiledb_dimension_label_get_label_attr_name", ret))
 	}
 
 	name := C.GoString(cLabelAttrName) // copies cLabelAttrName
@@ -77,7 +77,7 @@ func (d *DimensionLabel) CellValNum() (uint32, error) {
 	ret := C.tiledb_dimension_label_get_label_cell_val_num(d.context.tiledbContext.Get(), d.tiledbDimensionLabel.Get(), &labelCellValNum)
 	runtime.KeepAlive(d)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error fetching cell val num for dimension label: %w", d.context.LastError())
+		return 0, fmt.Errorf("error fetching cell val num for dimension label: %w", d.context.lastError("tiledb_dimension_label_get_label_cell_val_num", ret))
 	}
 
 	return uint32(labelCellValNum), nil
@@ -89,7 +89,7 @@ func (d *DimensionLabel) Order() (DataOrder, error) {
 	ret := C.tiledb_dimension_label_get_label_order(d.context.tiledbContext.Get(), d.tiledbDimensionLabel.Get(), &labelOrder)
 	runtime.KeepAlive(d)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error fetching label order for dimension label: %w", d.context.LastError())
+		return 0, fmt.Errorf("error fetching label order for dimension label: %w", d.context.lastError("tiledb_dimension_label_get_label_order", ret))
 	}
 
 	return DataOrder(labelOrder), nil
@@ -101,7 +101,7 @@ func (d *DimensionLabel) Type() (Datatype, error) {
 	ret := C.tiledb_dimension_label_get_label_type(d.context.tiledbContext.Get(), d.tiledbDimensionLabel.Get(), &dataType)
 	runtime.KeepAlive(d)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error fetching dimension label type: %w", d.context.LastError())
+		return 0, fmt.Errorf("error fetching dimension label type: %w", d.context.lastError("tiledb_dimension_label_get_label_type", ret))
 	}
 
 	return Datatype(dataType), nil
@@ -112,7 +112,7 @@ func (d *DimensionLabel) Name() (string, error) {
 	var cLabelName *C.char // d must be kept alive while cLabelName is being accessed.
 	ret := C.tiledb_dimension_label_get_name(d.context.tiledbContext.Get(), d.tiledbDimensionLabel.Get(), &cLabelName)
 	if ret != C.TILEDB_OK {
-		return "", fmt.Errorf("error getting dimension label name: %w", d.context.LastError())
+		return "", fmt.Errorf("error getting dimension label name: %w", d.context.lastError("tiledb_dimension_label_get_name", ret))
 	}
 
 	labelName := C.GoString(cLabelName) // copies cLabelName
@@ -125,7 +125,7 @@ func (d *DimensionLabel) URI() (string, error) {
 	var cLabelUri *C.char // d must be kept alive while cLabelUri is being accessed.
 	ret := C.tiledb_dimension_label_get_uri(d.context.tiledbContext.Get(), d.tiledbDimensionLabel.Get(), &cLabelUri)
 	if ret != C.TILEDB_OK {
-		return "", fmt.Errorf("error getting dimension label URI: %w", d.context.LastError())
+		return "", fmt.Errorf("error getting dimension label URI: %w", d.context.lastError("tiledb_dimension_label_get_uri", ret))
 	}
 
 	labelUri := C.GoString(cLabelUri) // copies cLabelUri
@@ -140,7 +140,7 @@ func (a *ArraySchema) AddDimensionLabel(dimIndex uint32, name string, order Data
 		C.uint32_t(dimIndex), cLabelName, C.tiledb_data_order_t(order), C.tiledb_datatype_t(labelType))
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error adding dimension label to ArraySchema: %w", a.context.LastError())
+		return fmt.Errorf("error adding dimension label to ArraySchema: %w", a.context.lastError("tiledb_array_schema_add_dimension_label", ret))
 	}
 
 	return nil
@@ -153,7 +153,7 @@ func (a *ArraySchema) DimensionLabelFromIndex(labelIdx uint64) (*DimensionLabel,
 		C.uint64_t(labelIdx), &dimLabelPtr)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting dimension label '%d' for ArraySchema: %w", labelIdx, a.context.LastError())
+		return nil, fmt.Errorf("error getting dimension label '%d' for ArraySchema: %w", labelIdx, a.context.lastError("tiledb_array_schema_get_dimension_label_from_index", ret))
 	}
 
 	return newDimensionLabelFromHandle(a.context, newDimensionLabelHandle(dimLabelPtr)), nil
@@ -168,7 +168,7 @@ func (a *ArraySchema) DimensionLabelFromName(name string) (*DimensionLabel, erro
 		cAttrName, &dimLabelPtr)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting dimension label '%s' for ArraySchema: %w", name, a.context.LastError())
+		return nil, fmt.Errorf("error getting dimension label '%s' for ArraySchema: %w", name, a.context.lastError("tiledb_array_schema_get_dimension_label_from_name", ret))
 	}
 
 	return newDimensionLabelFromHandle(a.context, newDimensionLabelHandle(dimLabelPtr)), nil
@@ -184,7 +184,7 @@ func (a *ArraySchema) HasDimensionLabel(name string) (bool, error) {
 		cLabelName, &hasLabel)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return false, fmt.Errorf("error checking ArraySchema for dimension label '%s': %w", name, a.context.LastError())
+		return false, fmt.Errorf("error checking ArraySchema for dimension label '%s': %w", name, a.context.lastError("tiledb_array_schema_has_dimension_label", ret))
 	}
 
 	return hasLabel != 0, nil
@@ -197,7 +197,7 @@ func (a *ArraySchema) DimensionLabelsNum() (uint64, error) {
 	ret := C.tiledb_array_schema_get_dimension_label_num(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), &labelNum)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error fetching dimension label number: %w", a.context.LastError())
+		return 0, fmt.Errorf("error fetching dimension label number: %w", a.context.lastError("tiledb_array_schema_get_dimension_label_num", ret))
 	}
 
 	return uint64(labelNum), nil
@@ -213,7 +213,7 @@ func (a *ArraySchema) SetDimensionLabelFilterList(name string, filterList Filter
 	runtime.KeepAlive(a)
 	runtime.KeepAlive(filterList)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting dimension label filter list on ArraySchema: %w", a.context.LastError())
+		return fmt.Errorf("error setting dimension label filter list on ArraySchema: %w", a.context.lastError("tiledb_array_schema_set_dimension_label_filter_list", ret))
 	}
 
 	return nil
@@ -265,7 +265,7 @@ func (a *ArraySchema) SetDimensionLabelTileExtent(labelName string, dimType Data
 	runtime.KeepAlive(a)
 	// cExtent is being kept alive by passing it to cgo call.
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting dimension label tile extent on ArraySchema: %w", a.context.LastError())
+		return fmt.Errorf("error setting dimension label tile extent on ArraySchema: %w", a.context.lastError("tiledb_array_schema_set_dimension_label_tile_extent", ret))
 	}
 
 	return nil
@@ -303,7 +303,7 @@ func (sa *Subarray) GetDimensionLabelRangeNum(labelName string) (uint64, error)
 	ret := C.tiledb_subarray_get_label_range_num(sa.context.tiledbContext.Get(), sa.subarray.Get(), cLabelName, &rangeNum)
 	runtime.KeepAlive(sa)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error retrieving subarray label range num: %w", sa.context.LastError())
+		return 0, fmt.Errorf("error retrieving subarray label range num: %w", sa.context.lastError("tiledb_subarray_get_label_range_num", ret))
 	}
 
 	return uint64(rangeNum), nil
@@ -316,6 +316,10 @@ func (sa *Subarray) AddDimensionLabelRange(labelName string, r Range) error {
 	if err != nil {
 		return err
 	}
+	r, err = r.withTimestamps(dt)
+	if err != nil {
+		return err
+	}
 	if err := r.assertCompatibility(dt, isVar); err != nil {
 		return err
 	}
@@ -338,7 +342,7 @@ func (sa *Subarray) AddDimensionLabelRange(labelName string, r Range) error {
 	runtime.KeepAlive(sa)
 	// The start and end values are being kept alive by passing them to cgo call.
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error adding subarray label range: %w", sa.context.LastError())
+		return fmt.Errorf("error adding subarray label range: %w", sa.context.lastError("tiledb_subarray_add_label_range", ret))
 	}
 
 	return nil
@@ -390,7 +394,7 @@ func (sa *Subarray) GetDimensionLabelRange(labelName string, rangeNum uint64) (R
 	}
 	runtime.KeepAlive(sa)
 	if ret != C.TILEDB_OK {
-		return Range{}, fmt.Errorf("error retrieving subarray range for label %s and range num %d: %w", labelName, rangeNum, sa.context.LastError())
+		return Range{}, fmt.Errorf("error retrieving subarray range for label %s and range num %d: %w", labelName, rangeNum, sa.context.lastError("tiledb_subarray_get_label_range", ret))
 	}
 
 	return r, err
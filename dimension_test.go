@@ -268,6 +268,18 @@ func TestDimensionDomainTypes(t *testing.T) {
 	assert.NotNil(t, extent)
 	assert.EqualValues(t, float64(5), extent)
 
+	dimension, err = NewDimension(context, "test", TILEDB_DATETIME_MS, []int64{1, 10}, int64(5))
+	require.NoError(t, err)
+	assert.NotNil(t, dimension)
+	// Test getting domain
+	domain, err = dimension.Domain()
+	require.NoError(t, err)
+	assert.EqualValues(t, []int64{1, 10}, domain)
+	// Test getting extent
+	extent, err = dimension.Extent()
+	require.NoError(t, err)
+	assert.EqualValues(t, int64(5), extent)
+
 	dimension, err = NewStringDimension(context, "test")
 	require.NoError(t, err)
 	assert.NotNil(t, dimension)
@@ -46,7 +46,7 @@ func NewDomain(tdbCtx *Context) (*Domain, error) {
 	ret := C.tiledb_domain_alloc(tdbCtx.tiledbContext.Get(), &domainPtr)
 	runtime.KeepAlive(tdbCtx)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error creating tiledb domain: %w", tdbCtx.LastError())
+		return nil, fmt.Errorf("error creating tiledb domain: %w", tdbCtx.lastError("tiledb_domain_alloc", ret))
 	}
 
 	return newDomainFromHandle(tdbCtx, newDomainHandle(domainPtr)), nil
@@ -72,7 +72,7 @@ func (d *Domain) Type() (Datatype, error) {
 	ret := C.tiledb_domain_get_type(d.context.tiledbContext.Get(), d.tiledbDomain.Get(), &datatype)
 	runtime.KeepAlive(d)
 	if ret != C.TILEDB_OK {
-		return -1, fmt.Errorf("error getting tiledb domain type: %w", d.context.LastError())
+		return -1, fmt.Errorf("error getting tiledb domain type: %w", d.context.lastError("tiledb_domain_get_type", ret))
 	}
 	return Datatype(datatype), nil
 }
@@ -83,7 +83,7 @@ func (d *Domain) NDim() (uint, error) {
 	ret := C.tiledb_domain_get_ndim(d.context.tiledbContext.Get(), d.tiledbDomain.Get(), &ndim)
 	runtime.KeepAlive(d)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting tiledb domain number of dimensions: %w", d.context.LastError())
+		return 0, fmt.Errorf("error getting tiledb domain number of dimensions: %w", d.context.lastError("tiledb_domain_get_ndim", ret))
 	}
 	return uint(ndim), nil
 }
@@ -95,7 +95,7 @@ func (d *Domain) DimensionFromIndex(index uint) (*Dimension, error) {
 		d.tiledbDomain.Get(), C.uint32_t(index), &dim)
 	runtime.KeepAlive(d)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting tiledb dimension by index for domain: %w", d.context.LastError())
+		return nil, fmt.Errorf("error getting tiledb dimension by index for domain: %w", d.context.lastError("tiledb_domain_get_dimension_from_index", ret))
 	}
 
 	return newDimensionFromHandle(d.context, newDimensionHandle(dim)), nil
@@ -109,7 +109,11 @@ func (d *Domain) DimensionFromName(name string) (*Dimension, error) {
 	ret := C.tiledb_domain_get_dimension_from_name(d.context.tiledbContext.Get(), d.tiledbDomain.Get(), cname, &dim)
 	runtime.KeepAlive(d)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting tiledb dimension by name for domain: %w", d.context.LastError())
+		err := d.context.lastError("tiledb_domain_get_dimension_from_name", ret)
+		if hasDim, hasErr := d.HasDimension(name); hasErr == nil && !hasDim {
+			return nil, errorf(ErrNotFound, "error getting tiledb dimension by name for domain: %w", err)
+		}
+		return nil, fmt.Errorf("error getting tiledb dimension by name for domain: %w", err)
 	}
 
 	return newDimensionFromHandle(d.context, newDimensionHandle(dim)), nil
@@ -121,7 +125,7 @@ func (d *Domain) AddDimensions(dimensions ...*Dimension) error {
 		ret := C.tiledb_domain_add_dimension(d.context.tiledbContext.Get(), d.tiledbDomain.Get(), dimension.tiledbDimension.Get())
 		runtime.KeepAlive(dimension)
 		if ret != C.TILEDB_OK {
-			return fmt.Errorf("error adding dimension to domain: %w", d.context.LastError())
+			return fmt.Errorf("error adding dimension to domain: %w", d.context.lastError("tiledb_domain_add_dimension", ret))
 		}
 	}
 	return nil
@@ -135,7 +139,7 @@ func (d *Domain) HasDimension(dimName string) (bool, error) {
 	ret := C.tiledb_domain_has_dimension(d.context.tiledbContext.Get(), d.tiledbDomain.Get(), cDimName, &hasDim)
 	runtime.KeepAlive(d)
 	if ret != C.TILEDB_OK {
-		return false, fmt.Errorf("error finding dimension %s in domain: %w", dimName, d.context.LastError())
+		return false, fmt.Errorf("error finding dimension %s in domain: %w", dimName, d.context.lastError("tiledb_domain_has_dimension", ret))
 	}
 
 	if hasDim == 0 {
@@ -151,7 +155,7 @@ func (d *Domain) DumpToString() (string, error) {
 	ret := C.tiledb_domain_dump_str(d.context.tiledbContext.Get(), d.tiledbDomain.Get(), &cStr)
 	runtime.KeepAlive(d)
 	if ret != C.TILEDB_OK {
-		return "", fmt.Errorf("error dumping domain to string: %w", d.context.LastError())
+		return "", fmt.Errorf("error dumping domain to string: %w", d.context.lastError("tiledb_domain_dump_str", ret))
 	}
 	defer C.tiledb_string_free(&cStr)
 
@@ -144,7 +144,7 @@ func newEnumeration[T EnumerationType](tdbCtx *Context, name string, ordered boo
 	// cData and cOffsets are kept alive by passing them to cgo call.
 	runtime.KeepAlive(tdbCtx)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error creating enumeration: %w", tdbCtx.LastError())
+		return nil, fmt.Errorf("error creating enumeration: %w", tdbCtx.lastError("tiledb_enumeration_alloc", ret))
 	}
 
 	return newEnumerationFromHandle(tdbCtx, newEnumerationHandle(tiledbEnum)), nil
@@ -166,7 +166,7 @@ func (e *Enumeration) Name() (string, error) {
 	ret := C.tiledb_enumeration_get_name(e.context.tiledbContext.Get(), e.tiledbEnum.Get(), &str)
 	runtime.KeepAlive(e)
 	if ret != C.TILEDB_OK {
-		return "", fmt.Errorf("error getting name: %w", e.context.LastError())
+		return "", fmt.Errorf("error getting name: %w", e.context.lastError("tiledb_enumeration_get_name", ret))
 	}
 	defer C.tiledb_string_free(&str)
 
@@ -180,7 +180,7 @@ func (e *Enumeration) Type() (Datatype, error) {
 	ret := C.tiledb_enumeration_get_type(e.context.tiledbContext.Get(), e.tiledbEnum.Get(), &attrType)
 	runtime.KeepAlive(e)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting tiledb enumeration type: %w", e.context.LastError())
+		return 0, fmt.Errorf("error getting tiledb enumeration type: %w", e.context.lastError("tiledb_enumeration_get_type", ret))
 	}
 
 	return Datatype(attrType), nil
@@ -193,7 +193,7 @@ func (e *Enumeration) CellValNum() (uint32, error) {
 	ret := C.tiledb_enumeration_get_cell_val_num(e.context.tiledbContext.Get(), e.tiledbEnum.Get(), &cellValNum)
 	runtime.KeepAlive(e)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting enumeration cell val num: %w", e.context.LastError())
+		return 0, fmt.Errorf("error getting enumeration cell val num: %w", e.context.lastError("tiledb_enumeration_get_cell_val_num", ret))
 	}
 
 	return uint32(cellValNum), nil
@@ -207,7 +207,7 @@ func (e *Enumeration) IsOrdered() (bool, error) {
 	ret := C.tiledb_enumeration_get_ordered(e.context.tiledbContext.Get(), e.tiledbEnum.Get(), &ordered)
 	runtime.KeepAlive(e)
 	if ret != C.TILEDB_OK {
-		return false, fmt.Errorf("error getting ordered: %w", e.context.LastError())
+		return false, fmt.Errorf("error getting ordered: %w", e.context.lastError("tiledb_enumeration_get_ordered", ret))
 	}
 
 	return ordered > 0, nil
@@ -219,7 +219,7 @@ func (e *Enumeration) DumpToString() (string, error) {
 	ret := C.tiledb_enumeration_dump_str(e.context.tiledbContext.Get(), e.tiledbEnum.Get(), &cStr)
 	runtime.KeepAlive(e)
 	if ret != C.TILEDB_OK {
-		return "", fmt.Errorf("error dumping enumeration to string: %w", e.context.LastError())
+		return "", fmt.Errorf("error dumping enumeration to string: %w", e.context.lastError("tiledb_enumeration_dump_str", ret))
 	}
 	defer C.tiledb_string_free(&cStr)
 
@@ -267,7 +267,7 @@ func (e *Enumeration) Values() (interface{}, error) {
 	var cDataSize C.uint64_t
 	ret := C.tiledb_enumeration_get_data(e.context.tiledbContext.Get(), e.tiledbEnum.Get(), &cData, &cDataSize)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting data: %w", e.context.LastError())
+		return nil, fmt.Errorf("error getting data: %w", e.context.lastError("tiledb_enumeration_get_data", ret))
 	}
 
 	if typ != TILEDB_STRING_ASCII {
@@ -303,7 +303,7 @@ func (e *Enumeration) Values() (interface{}, error) {
 	var cOffsetsSize C.uint64_t
 	ret = C.tiledb_enumeration_get_offsets(e.context.tiledbContext.Get(), e.tiledbEnum.Get(), &cOffsets, &cOffsetsSize)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting data offsets: %w", e.context.LastError())
+		return nil, fmt.Errorf("error getting data offsets: %w", e.context.lastError("tiledb_enumeration_get_offsets", ret))
 	}
 
 	if int(cOffsetsSize)%8 > 0 {
@@ -338,17 +338,17 @@ func ExtendEnumeration[T EnumerationType](tdbCtx *Context, e *Enumeration, value
 
 	eName, err := e.Name()
 	if err != nil {
-		return nil, fmt.Errorf("error extending enumeration: failed to get name of enumeration: %w", tdbCtx.LastError())
+		return nil, fmt.Errorf("error extending enumeration: failed to get name of enumeration: %w", err)
 	}
 
 	eType, err := e.Type()
 	if err != nil {
-		return nil, fmt.Errorf("error extending enumeration: failed to get type of enumeration %s: %w", eName, tdbCtx.LastError())
+		return nil, fmt.Errorf("error extending enumeration: failed to get type of enumeration %s: %w", eName, err)
 	}
 
 	tiledbType := enumerationTypeToTileDB[T]()
 	if eType != tiledbType {
-		return nil, fmt.Errorf("error extending enumeration: type mismatch: enumeration type %v, values type %v", eType, tiledbType)
+		return nil, errorf(ErrSchemaMismatch, "error extending enumeration: type mismatch: enumeration type %v, values type %v", eType, tiledbType)
 	}
 
 	var cData unsafe.Pointer
@@ -386,7 +386,7 @@ func ExtendEnumeration[T EnumerationType](tdbCtx *Context, e *Enumeration, value
 	runtime.KeepAlive(e)
 	// cData and cOffsets are being kept alive by passing them to cgo call.
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error extending enumeration: %w", tdbCtx.LastError())
+		return nil, fmt.Errorf("error extending enumeration: %w", tdbCtx.lastError("tiledb_enumeration_extend", ret))
 	}
 
 	return newEnumerationFromHandle(tdbCtx, newEnumerationHandle(extEnum)), nil
@@ -398,7 +398,7 @@ func (a *ArraySchema) AddEnumeration(e *Enumeration) error {
 	runtime.KeepAlive(a)
 	runtime.KeepAlive(e)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error adding enumeration: %w", a.context.LastError())
+		return fmt.Errorf("error adding enumeration: %w", a.context.lastError("tiledb_array_schema_add_enumeration", ret))
 	}
 
 	return nil
@@ -412,7 +412,7 @@ func (a *ArraySchema) EnumerationFromName(name string) (*Enumeration, error) {
 	ret := C.tiledb_array_schema_get_enumeration_from_name(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), cName, &enumPtr)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting enumeration from name: %w", a.context.LastError())
+		return nil, fmt.Errorf("error getting enumeration from name: %w", a.context.lastError("tiledb_array_schema_get_enumeration_from_name", ret))
 	}
 
 	return newEnumerationFromHandle(a.context, newEnumerationHandle(enumPtr)), nil
@@ -426,7 +426,7 @@ func (a *ArraySchema) EnumerationFromAttributeName(name string) (*Enumeration, e
 	ret := C.tiledb_array_schema_get_enumeration_from_attribute_name(a.context.tiledbContext.Get(), a.tiledbArraySchema.Get(), cName, &enumPtr)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting enumeration from attribute name: %w", a.context.LastError())
+		return nil, fmt.Errorf("error getting enumeration from attribute name: %w", a.context.lastError("tiledb_array_schema_get_enumeration_from_attribute_name", ret))
 	}
 
 	return newEnumerationFromHandle(a.context, newEnumerationHandle(enumPtr)), nil
@@ -438,7 +438,7 @@ func (a *Array) LoadAllEnumerations() error {
 	ret := C.tiledb_array_load_all_enumerations(a.context.tiledbContext.Get(), a.tiledbArray.Get())
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error loading all enumerations: %w", a.context.LastError())
+		return fmt.Errorf("error loading all enumerations: %w", a.context.lastError("tiledb_array_load_all_enumerations", ret))
 	}
 
 	return nil
@@ -449,7 +449,7 @@ func (a *Array) LoadEnumerationsAllSchemas() error {
 	ret := C.tiledb_array_load_enumerations_all_schemas(a.context.tiledbContext.Get(), a.tiledbArray.Get())
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error loading enumerations for all schemas: %w", a.context.LastError())
+		return fmt.Errorf("error loading enumerations for all schemas: %w", a.context.lastError("tiledb_array_load_enumerations_all_schemas", ret))
 	}
 
 	return nil
@@ -464,7 +464,7 @@ func (a *Array) GetEnumeration(name string) (*Enumeration, error) {
 	ret := C.tiledb_array_get_enumeration(a.context.tiledbContext.Get(), a.tiledbArray.Get(), cName, &tiledbEnum)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting enumeration %s: %w", name, a.context.LastError())
+		return nil, fmt.Errorf("error getting enumeration %s: %w", name, a.context.lastError("tiledb_array_get_enumeration", ret))
 	}
 
 	return newEnumerationFromHandle(a.context, newEnumerationHandle(tiledbEnum)), nil
@@ -479,20 +479,25 @@ func (a *Attribute) SetEnumerationName(name string) error {
 	ret := C.tiledb_attribute_set_enumeration_name(a.context.tiledbContext.Get(), a.tiledbAttribute.Get(), cName)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting enumeration name: %w", a.context.LastError())
+		return fmt.Errorf("error setting enumeration name: %w", a.context.lastError("tiledb_attribute_set_enumeration_name", ret))
 	}
 
 	return nil
 }
 
-// GetEnumerationName returns the enumeration name of the attribute.
+// GetEnumerationName returns the enumeration name of the attribute, or an empty string
+// if the attribute has no enumeration.
 func (a *Attribute) GetEnumerationName() (string, error) {
 	var str *C.tiledb_string_t
 
 	ret := C.tiledb_attribute_get_enumeration_name(a.context.tiledbContext.Get(), a.tiledbAttribute.Get(), &str)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return "", fmt.Errorf("error getting enumeration name: %w", a.context.LastError())
+		return "", fmt.Errorf("error getting enumeration name: %w", a.context.lastError("tiledb_attribute_get_enumeration_name", ret))
+	}
+	// TileDB returns no string for attributes without an enumeration.
+	if str == nil {
+		return "", nil
 	}
 	defer C.tiledb_string_free(&str)
 
@@ -500,7 +505,13 @@ func (a *Attribute) GetEnumerationName() (string, error) {
 }
 
 // UseEnumerations set true to allow query conditions with enumeration literals.
+// For conditions on times, the flag is applied when the condition is set on a query.
 func (qc *QueryCondition) UseEnumeration(useEnum bool) error {
+	if qc.unresolved() {
+		qc.useEnumeration = &useEnum
+		return nil
+	}
+
 	var cUseEnum C.int
 	if useEnum {
 		cUseEnum = 1
@@ -509,8 +520,9 @@ func (qc *QueryCondition) UseEnumeration(useEnum bool) error {
 	ret := C.tiledb_query_condition_set_use_enumeration(qc.context.tiledbContext.Get(), qc.cond.Get(), cUseEnum)
 	runtime.KeepAlive(qc)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error toggling enumerations use: %w", qc.context.LastError())
+		return fmt.Errorf("error toggling enumerations use: %w", qc.context.lastError("tiledb_query_condition_set_use_enumeration", ret))
 	}
+	qc.useEnumeration = &useEnum
 
 	return nil
 }
@@ -519,14 +531,14 @@ func (qc *QueryCondition) UseEnumeration(useEnum bool) error {
 func (ase *ArraySchemaEvolution) AddEnumeration(e *Enumeration) error {
 	name, err := e.Name()
 	if err != nil {
-		return fmt.Errorf("error getting enumeration name: %w", e.context.LastError())
+		return fmt.Errorf("error getting enumeration name: %w", err)
 	}
 
 	ret := C.tiledb_array_schema_evolution_add_enumeration(ase.context.tiledbContext.Get(), ase.tiledbArraySchemaEvolution.Get(), e.tiledbEnum.Get())
 	runtime.KeepAlive(ase)
 	runtime.KeepAlive(e)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error adding enumeration %s to tiledb arraySchemaEvolution: %w", name, ase.context.LastError())
+		return fmt.Errorf("error adding enumeration %s to tiledb arraySchemaEvolution: %w", name, ase.context.lastError("tiledb_array_schema_evolution_add_enumeration", ret))
 	}
 
 	return nil
@@ -540,7 +552,7 @@ func (ase *ArraySchemaEvolution) DropEnumeration(name string) error {
 	ret := C.tiledb_array_schema_evolution_drop_enumeration(ase.context.tiledbContext.Get(), ase.tiledbArraySchemaEvolution.Get(), cName)
 	runtime.KeepAlive(ase)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error dropping enumeration %s from tiledb arraySchemaEvolution: %w", name, ase.context.LastError())
+		return fmt.Errorf("error dropping enumeration %s from tiledb arraySchemaEvolution: %w", name, ase.context.lastError("tiledb_array_schema_evolution_drop_enumeration", ret))
 	}
 
 	return nil
@@ -552,7 +564,7 @@ func (ase *ArraySchemaEvolution) ApplyExtendedEnumeration(e *Enumeration) error
 	runtime.KeepAlive(ase)
 	runtime.KeepAlive(e)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error applying extended enumeration to arraySchemaEvolution: %w", ase.context.LastError())
+		return fmt.Errorf("error applying extended enumeration to arraySchemaEvolution: %w", ase.context.lastError("tiledb_array_schema_evolution_extend_enumeration", ret))
 	}
 
 	return nil
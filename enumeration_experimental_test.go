@@ -167,6 +167,15 @@ func TestEnumerationAndSchema(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, "romanNumerals", romanName)
 	})
+
+	t.Run("WithoutEnumeration", func(t *testing.T) {
+		attr, err := NewAttribute(tdbCtx, "plain", TILEDB_UINT8)
+		require.NoError(t, err)
+		defer attr.Free()
+		name, err := attr.GetEnumerationName()
+		require.NoError(t, err)
+		assert.Empty(t, name)
+	})
 }
 
 func TestEnumerationQueryCondition(t *testing.T) {
@@ -283,6 +292,34 @@ func TestEnumerationQueryCondition(t *testing.T) {
 		assert.Equal(t, []uint8{1, 5, 0}, romanBuffer[0:3])
 	})
 
+	t.Run("SetMembership", func(t *testing.T) {
+		array, err := NewArray(tdbCtx, arrayPath)
+		require.NoError(t, err)
+		require.NoError(t, array.Open(TILEDB_READ))
+		rQuery, err := NewQuery(tdbCtx, array)
+		require.NoError(t, err)
+		qc, err := NewQueryConditionSet(tdbCtx, "roman", TILEDB_QUERY_CONDITION_IN, []string{"ii", "vi"})
+		require.NoError(t, err)
+		require.NoError(t, rQuery.SetQueryCondition(qc))
+
+		rowsBuffer := make([]uint8, 16)
+		_, err = rQuery.SetDataBuffer("rows", rowsBuffer)
+		require.NoError(t, err)
+		colsBuffer := make([]uint8, 16)
+		_, err = rQuery.SetDataBuffer("cols", colsBuffer)
+		require.NoError(t, err)
+		romanBuffer := make([]uint8, 16)
+		_, err = rQuery.SetDataBuffer("roman", romanBuffer)
+		require.NoError(t, err)
+
+		require.NoError(t, rQuery.Submit())
+		require.NoError(t, array.Close())
+
+		assert.Equal(t, []uint8{1, 2, 0}, rowsBuffer[0:3])
+		assert.Equal(t, []uint8{2, 2, 0}, colsBuffer[0:3])
+		assert.Equal(t, []uint8{1, 5, 0}, romanBuffer[0:3])
+	})
+
 	t.Run("LabelNotExists", func(t *testing.T) {
 		array, err := NewArray(tdbCtx, arrayPath)
 		require.NoError(t, err)
@@ -262,6 +262,12 @@ func (d Datatype) Size() uint64 {
 	return uint64(C.tiledb_datatype_size(C.tiledb_datatype_t(d)))
 }
 
+// isStringDatatype returns whether the values of datatype are the bytes of a string,
+// which GetValue returns as a string.
+func isStringDatatype(d Datatype) bool {
+	return d == TILEDB_CHAR || d == TILEDB_STRING_ASCII || d == TILEDB_STRING_UTF8
+}
+
 // MakeSlice makes a slice of the correct type corresponding to the datatype, with a given number of elements.
 func (d Datatype) MakeSlice(numElements uint64) (interface{}, unsafe.Pointer, error) {
 	switch d {
@@ -332,12 +338,12 @@ func (d Datatype) GetValue(valueNum uint, cvalue unsafe.Pointer) (interface{}, e
 		TILEDB_DATETIME_SEC, TILEDB_DATETIME_MS, TILEDB_DATETIME_US,
 		TILEDB_DATETIME_NS, TILEDB_DATETIME_PS, TILEDB_DATETIME_FS,
 		TILEDB_DATETIME_AS, TILEDB_TIME_HR, TILEDB_TIME_MIN, TILEDB_TIME_SEC, TILEDB_TIME_MS, TILEDB_TIME_US, TILEDB_TIME_NS, TILEDB_TIME_PS, TILEDB_TIME_FS, TILEDB_TIME_AS:
-		if valueNum > 1 {
-			return nil, fmt.Errorf("only 1 timestamp may be returned, not %d", d)
-		}
 		if cvalue == nil {
 			return time.Time{}, nil
 		}
+		if valueNum > 1 {
+			return TimesFromTimestamps(d, unsafeSlice[int64](cvalue, valueNum)), nil
+		}
 		timestamp := *(*int64)(cvalue)
 		return GetTimeFromTimestamp(d, timestamp), nil
 	case TILEDB_BOOL:
@@ -437,8 +443,37 @@ const (
 	TILEDB_FILTER_SCALE_FLOAT FilterType = C.TILEDB_FILTER_SCALE_FLOAT
 	// TILEDB_FILTER_DELTA Delta encoding filter.
 	TILEDB_FILTER_DELTA FilterType = C.TILEDB_FILTER_DELTA
+	// TILEDB_FILTER_CHECKSUM_MD5 MD5 checksum filter.
+	TILEDB_FILTER_CHECKSUM_MD5 FilterType = C.TILEDB_FILTER_CHECKSUM_MD5
+	// TILEDB_FILTER_CHECKSUM_SHA256 SHA256 checksum filter.
+	TILEDB_FILTER_CHECKSUM_SHA256 FilterType = C.TILEDB_FILTER_CHECKSUM_SHA256
+	// TILEDB_FILTER_DICTIONARY Dictionary encoding filter for strings.
+	TILEDB_FILTER_DICTIONARY FilterType = C.TILEDB_FILTER_DICTIONARY
+	// TILEDB_FILTER_XOR XOR filter.
+	TILEDB_FILTER_XOR FilterType = C.TILEDB_FILTER_XOR
+	// TILEDB_FILTER_WEBP WebP image compressor.
+	TILEDB_FILTER_WEBP FilterType = C.TILEDB_FILTER_WEBP
 )
 
+// String returns a string representation.
+func (filterType FilterType) String() string {
+	var ctype *C.char
+	C.tiledb_filter_type_to_str(C.tiledb_filter_type_t(filterType), &ctype)
+	return C.GoString(ctype)
+}
+
+// FilterTypeFromString converts from a filter type string, such as "ZSTD", to enum.
+func FilterTypeFromString(s string) (FilterType, error) {
+	cname := C.CString(s)
+	defer C.free(unsafe.Pointer(cname))
+	var cFilterType C.tiledb_filter_type_t
+	ret := C.tiledb_filter_type_from_str(cname, &cFilterType)
+	if ret != C.TILEDB_OK {
+		return TILEDB_FILTER_NONE, fmt.Errorf("%s is not a recognized tiledb_filter_type_t", s)
+	}
+	return FilterType(cFilterType), nil
+}
+
 // FilterOption for a given filter
 type FilterOption uint8
 
@@ -449,6 +484,44 @@ const (
 	TILEDB_BIT_WIDTH_MAX_WINDOW FilterOption = C.TILEDB_BIT_WIDTH_MAX_WINDOW
 	// TILEDB_POSITIVE_DELTA_MAX_WINDOW Max window length for positive-delta encoding. Type: `uint32_t`.
 	TILEDB_POSITIVE_DELTA_MAX_WINDOW FilterOption = C.TILEDB_POSITIVE_DELTA_MAX_WINDOW
+	// TILEDB_SCALE_FLOAT_BYTEWIDTH Byte width of the integers stored by float scaling. Type: `uint64_t`.
+	TILEDB_SCALE_FLOAT_BYTEWIDTH FilterOption = C.TILEDB_SCALE_FLOAT_BYTEWIDTH
+	// TILEDB_SCALE_FLOAT_FACTOR Scale factor of float scaling. Type: `double`.
+	TILEDB_SCALE_FLOAT_FACTOR FilterOption = C.TILEDB_SCALE_FLOAT_FACTOR
+	// TILEDB_SCALE_FLOAT_OFFSET Offset of float scaling. Type: `double`.
+	TILEDB_SCALE_FLOAT_OFFSET FilterOption = C.TILEDB_SCALE_FLOAT_OFFSET
+	// TILEDB_WEBP_QUALITY Quality of WebP compression, from 0 to 100. Type: `float`.
+	TILEDB_WEBP_QUALITY FilterOption = C.TILEDB_WEBP_QUALITY
+	// TILEDB_WEBP_INPUT_FORMAT Pixel format of the WebP input. Type: `uint8_t`.
+	TILEDB_WEBP_INPUT_FORMAT FilterOption = C.TILEDB_WEBP_INPUT_FORMAT
+	// TILEDB_WEBP_LOSSLESS Whether WebP compression is lossless. Type: `uint8_t`.
+	TILEDB_WEBP_LOSSLESS FilterOption = C.TILEDB_WEBP_LOSSLESS
+	// TILEDB_COMPRESSION_REINTERPRET_DATATYPE Datatype the input of a compressor is reinterpreted
+	// as before compression. Type: `uint8_t`.
+	TILEDB_COMPRESSION_REINTERPRET_DATATYPE FilterOption = C.TILEDB_COMPRESSION_REINTERPRET_DATATYPE
+)
+
+// String returns a string representation.
+func (filterOption FilterOption) String() string {
+	var ctype *C.char
+	C.tiledb_filter_option_to_str(C.tiledb_filter_option_t(filterOption), &ctype)
+	return C.GoString(ctype)
+}
+
+// WebPFormat is the pixel format of the input of the WebP filter.
+type WebPFormat uint8
+
+const (
+	// TILEDB_WEBP_NONE No format set
+	TILEDB_WEBP_NONE WebPFormat = C.TILEDB_WEBP_NONE
+	// TILEDB_WEBP_RGB Red, green and blue pixels
+	TILEDB_WEBP_RGB WebPFormat = C.TILEDB_WEBP_RGB
+	// TILEDB_WEBP_BGR Blue, green and red pixels
+	TILEDB_WEBP_BGR WebPFormat = C.TILEDB_WEBP_BGR
+	// TILEDB_WEBP_RGBA Red, green, blue and alpha pixels
+	TILEDB_WEBP_RGBA WebPFormat = C.TILEDB_WEBP_RGBA
+	// TILEDB_WEBP_BGRA Blue, green, red and alpha pixels
+	TILEDB_WEBP_BGRA WebPFormat = C.TILEDB_WEBP_BGRA
 )
 
 // FS represents support fs types
@@ -573,6 +646,10 @@ const (
 	TILEDB_QUERY_CONDITION_EQ QueryConditionOp = C.TILEDB_EQ
 	// TILEDB_QUERY_CONDITION_NE defines the query condition for a not equal to comparison
 	TILEDB_QUERY_CONDITION_NE QueryConditionOp = C.TILEDB_NE
+	// TILEDB_QUERY_CONDITION_IN defines the query condition for set membership, see NewQueryConditionSet
+	TILEDB_QUERY_CONDITION_IN QueryConditionOp = C.TILEDB_IN
+	// TILEDB_QUERY_CONDITION_NOT_IN defines the query condition for set non-membership, see NewQueryConditionSet
+	TILEDB_QUERY_CONDITION_NOT_IN QueryConditionOp = C.TILEDB_NOT_IN
 )
 
 // QueryConditionCombinationOp operation type for a query condition combination
@@ -1,180 +1,150 @@
 package tiledb
 
-import "time"
+import (
+	"fmt"
+	"math"
+	"time"
+)
 
-const secondsInCommonYear = 31536000
-const secondsInLeapYear = 31622400
 const secondsInDay = 24 * 60 * 60
 const secondsInHour = 60 * 60
 const secondsInMin = 60
 const epochYear = 1970
 
-func isLeapYear(year int) bool {
-	leapFlag := false
-	if year%4 == 0 {
-		if year%100 == 0 {
-			if year%400 == 0 {
-				leapFlag = true
-			} else {
-				leapFlag = false
-			}
-		} else {
-			leapFlag = true
-		}
-	} else {
-		leapFlag = false
-	}
-	return leapFlag
-}
-
-func secondsFromEpochYears(numOfYears int64) int64 {
-	var numOfSeconds int64 = 0
-
-	if numOfYears > 0 {
-		for y := int64(epochYear); y < epochYear+numOfYears; y++ {
-			if isLeapYear(int(y)) {
-				numOfSeconds += secondsInLeapYear
-			} else {
-				numOfSeconds += secondsInCommonYear
-			}
-		}
-	} else {
-		for y := int64(epochYear) - 1; y >= epochYear+numOfYears; y-- {
-			if isLeapYear(int(y)) {
-				numOfSeconds -= secondsInLeapYear
-			} else {
-				numOfSeconds -= secondsInCommonYear
-			}
-		}
+// GetTimeFromTimestamp returns a time.Time object for a time related TileDB datatype
+// Datetimes in TileDB are deltas from unix epoch with a resolution of the specified time.
+// Units smaller than a nanosecond are rounded down to the previous nanosecond.
+func GetTimeFromTimestamp(datatype Datatype, timestamp int64) time.Time {
+	switch datatype {
+	case TILEDB_DATETIME_YEAR:
+		return time.Date(epochYear+int(timestamp), time.January, 1, 0, 0, 0, 0, time.UTC)
+	case TILEDB_DATETIME_MONTH:
+		// time.Date normalizes the months outside of [1, 12] into years.
+		return time.Date(epochYear, time.January+time.Month(timestamp), 1, 0, 0, 0, 0, time.UTC)
 	}
 
-	return numOfSeconds
-}
-
-// abs returns the absolute value of x
-func abs(x int64) int64 {
-	if x < 0 {
-		return -x
+	secondsPerUnit, unitsPerSecond, ok := timestampUnit(datatype)
+	switch {
+	case !ok:
+		return time.Time{}.UTC()
+	case secondsPerUnit > 0:
+		return time.Unix(timestamp*secondsPerUnit, 0).UTC()
 	}
-	return x
-}
 
-func secondsFromEpochMonths(numOfMonths int64) int64 {
-	numOfYears := numOfMonths / 12
-	numOfSeconds := secondsFromEpochYears(numOfYears)
-	numOfMonthsInLastYear := abs(numOfMonths) % 12
-
-	if numOfMonths > 0 {
-		for m := int64(1); m <= numOfMonthsInLastYear; m++ {
-			switch m {
-			case 1:
-				numOfSeconds += 31 * secondsInDay
-			case 2:
-				currentYear := epochYear + numOfYears + 1
-				if isLeapYear(int(currentYear)) {
-					numOfSeconds += 29 * secondsInDay
-				} else {
-					numOfSeconds += 28 * secondsInDay
-				}
-			case 3:
-				numOfSeconds += 31 * secondsInDay
-			case 4:
-				numOfSeconds += 30 * secondsInDay
-			case 5:
-				numOfSeconds += 31 * secondsInDay
-			case 6:
-				numOfSeconds += 30 * secondsInDay
-			case 7:
-				numOfSeconds += 31 * secondsInDay
-			case 8:
-				numOfSeconds += 31 * secondsInDay
-			case 9:
-				numOfSeconds += 30 * secondsInDay
-			case 10:
-				numOfSeconds += 31 * secondsInDay
-			case 11:
-				numOfSeconds += 30 * secondsInDay
-			case 12:
-				numOfSeconds += 31 * secondsInDay
-			}
-		}
-	} else {
-		for m := int64(12); m > 12-numOfMonthsInLastYear; m-- {
-			switch m {
-			case 1:
-				numOfSeconds -= 31 * secondsInDay
-			case 2:
-				currentYear := epochYear - numOfYears - 1
-				if isLeapYear(int(currentYear)) {
-					numOfSeconds -= 29 * secondsInDay
-				} else {
-					numOfSeconds -= 28 * secondsInDay
-				}
-			case 3:
-				numOfSeconds -= 31 * secondsInDay
-			case 4:
-				numOfSeconds -= 30 * secondsInDay
-			case 5:
-				numOfSeconds -= 31 * secondsInDay
-			case 6:
-				numOfSeconds -= 30 * secondsInDay
-			case 7:
-				numOfSeconds -= 31 * secondsInDay
-			case 8:
-				numOfSeconds -= 31 * secondsInDay
-			case 9:
-				numOfSeconds -= 30 * secondsInDay
-			case 10:
-				numOfSeconds -= 31 * secondsInDay
-			case 11:
-				numOfSeconds -= 30 * secondsInDay
-			case 12:
-				numOfSeconds -= 31 * secondsInDay
-			}
-		}
+	seconds := floorDiv(timestamp, unitsPerSecond)
+	fraction := timestamp - seconds*unitsPerSecond
+	if unitsPerSecond <= 1e9 {
+		return time.Unix(seconds, fraction*(1e9/unitsPerSecond)).UTC()
 	}
-
-	return numOfSeconds
+	return time.Unix(seconds, fraction/(unitsPerSecond/1e9)).UTC()
 }
 
-// GetTimeFromTimestamp returns a time.Time object for a time related TileDB datatype
-// Datetimes in TileDB are deltas from unix epoch with a resolution of the specified time.
-func GetTimeFromTimestamp(datatype Datatype, timestamp int64) time.Time {
-	var then time.Time
+// timestampUnit returns the number of seconds per unit of a datetime or time datatype for units
+// of a second or more, or the number of units per second for smaller units.
+func timestampUnit(datatype Datatype) (secondsPerUnit, unitsPerSecond int64, ok bool) {
 	switch datatype {
-	case TILEDB_DATETIME_YEAR:
-		numOfSeconds := secondsFromEpochYears(timestamp)
-		then = time.Unix(int64(numOfSeconds), 0)
-	case TILEDB_DATETIME_MONTH:
-		numOfSeconds := secondsFromEpochMonths(timestamp)
-		then = time.Unix(int64(numOfSeconds), 0)
 	case TILEDB_DATETIME_WEEK:
-		numOfSeconds := 7 * timestamp * secondsInDay
-		then = time.Unix(int64(numOfSeconds), 0)
+		return 7 * secondsInDay, 0, true
 	case TILEDB_DATETIME_DAY:
-		numOfSeconds := timestamp * secondsInDay
-		then = time.Unix(int64(numOfSeconds), 0)
+		return secondsInDay, 0, true
 	case TILEDB_DATETIME_HR, TILEDB_TIME_HR:
-		numOfSeconds := timestamp * secondsInHour
-		then = time.Unix(int64(numOfSeconds), 0)
+		return secondsInHour, 0, true
 	case TILEDB_DATETIME_MIN, TILEDB_TIME_MIN:
-		numOfSeconds := timestamp * secondsInMin
-		then = time.Unix(int64(numOfSeconds), 0)
+		return secondsInMin, 0, true
 	case TILEDB_DATETIME_SEC, TILEDB_TIME_SEC:
-		then = time.Unix(timestamp, 0)
+		return 1, 0, true
 	case TILEDB_DATETIME_MS, TILEDB_TIME_MS:
-		then = time.Unix(0, int64(timestamp*1000*1000))
+		return 0, 1e3, true
 	case TILEDB_DATETIME_US, TILEDB_TIME_US:
-		then = time.Unix(0, int64(timestamp*1000))
+		return 0, 1e6, true
 	case TILEDB_DATETIME_NS, TILEDB_TIME_NS:
-		then = time.Unix(0, timestamp)
+		return 0, 1e9, true
 	case TILEDB_DATETIME_PS, TILEDB_TIME_PS:
-		then = time.Unix(0, int64(timestamp/1000))
+		return 0, 1e12, true
 	case TILEDB_DATETIME_FS, TILEDB_TIME_FS:
-		then = time.Unix(0, int64(timestamp/(1000*1000)))
+		return 0, 1e15, true
 	case TILEDB_DATETIME_AS, TILEDB_TIME_AS:
-		then = time.Unix(0, int64(timestamp/(1000*1000)))
+		return 0, 1e18, true
+	}
+	return 0, 0, false
+}
+
+// isTimeDatatype returns whether datatype is a datetime or time datatype.
+func isTimeDatatype(datatype Datatype) bool {
+	if datatype == TILEDB_DATETIME_YEAR || datatype == TILEDB_DATETIME_MONTH {
+		return true
+	}
+	_, _, ok := timestampUnit(datatype)
+	return ok
+}
+
+// floorDiv returns x/y rounded towards negative infinity.
+func floorDiv(x, y int64) int64 {
+	q := x / y
+	if x%y != 0 && (x < 0) != (y < 0) {
+		q--
 	}
+	return q
+}
 
-	return then.UTC()
+// TimestampFromTime returns the timestamp of t for a datetime or time datatype, which is the
+// number of units of the datatype since the unix epoch. Times between two units are rounded
+// down to the previous unit. It returns an error if datatype is not a datetime or time
+// datatype, or if the timestamp overflows an int64.
+func TimestampFromTime(datatype Datatype, t time.Time) (int64, error) {
+	t = t.UTC()
+	switch datatype {
+	case TILEDB_DATETIME_YEAR:
+		return int64(t.Year() - epochYear), nil
+	case TILEDB_DATETIME_MONTH:
+		return int64(t.Year()-epochYear)*12 + int64(t.Month()-1), nil
+	}
+
+	secondsPerUnit, unitsPerSecond, ok := timestampUnit(datatype)
+	if !ok {
+		return 0, errorf(ErrSchemaMismatch, "cannot convert time to %v: not a datetime or time datatype", datatype)
+	}
+	seconds, nanoseconds := t.Unix(), int64(t.Nanosecond())
+	if secondsPerUnit > 0 {
+		return floorDiv(seconds, secondsPerUnit), nil
+	}
+
+	if seconds > math.MaxInt64/unitsPerSecond || seconds < math.MinInt64/unitsPerSecond {
+		return 0, fmt.Errorf("cannot convert %v to %v: timestamp overflows int64", t, datatype)
+	}
+	var fraction int64
+	if unitsPerSecond <= 1e9 {
+		fraction = nanoseconds / (1e9 / unitsPerSecond)
+	} else {
+		fraction = nanoseconds * (unitsPerSecond / 1e9)
+	}
+	timestamp := seconds * unitsPerSecond
+	if timestamp > math.MaxInt64-fraction {
+		return 0, fmt.Errorf("cannot convert %v to %v: timestamp overflows int64", t, datatype)
+	}
+	return timestamp + fraction, nil
+}
+
+// TimestampsFromTimes returns the timestamps of times for a datetime or time datatype.
+// See TimestampFromTime.
+func TimestampsFromTimes(datatype Datatype, times []time.Time) ([]int64, error) {
+	timestamps := make([]int64, len(times))
+	for i, t := range times {
+		timestamp, err := TimestampFromTime(datatype, t)
+		if err != nil {
+			return nil, err
+		}
+		timestamps[i] = timestamp
+	}
+	return timestamps, nil
+}
+
+// TimesFromTimestamps returns the times of timestamps of a datetime or time datatype.
+// See GetTimeFromTimestamp.
+func TimesFromTimestamps(datatype Datatype, timestamps []int64) []time.Time {
+	times := make([]time.Time, len(timestamps))
+	for i, timestamp := range timestamps {
+		times[i] = GetTimeFromTimestamp(datatype, timestamp)
+	}
+	return times
 }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestEpoch(t *testing.T) {
@@ -17,7 +18,11 @@ func TestEpoch(t *testing.T) {
 	assert.Equal(t, then, timeObject)
 
 	timeObject = GetTimeFromTimestamp(TILEDB_DATETIME_MONTH, 83)
-	then = time.Date(1976, 11, 30, 0, 0, 0, 0, time.UTC)
+	then = time.Date(1976, 12, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, then, timeObject)
+
+	timeObject = GetTimeFromTimestamp(TILEDB_DATETIME_MONTH, 26)
+	then = time.Date(1972, 3, 1, 0, 0, 0, 0, time.UTC)
 	assert.Equal(t, then, timeObject)
 
 	timeObject = GetTimeFromTimestamp(TILEDB_DATETIME_MONTH, -83)
@@ -40,3 +45,103 @@ func TestEpoch(t *testing.T) {
 	then = time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC)
 	assert.Equal(t, then, timeObject)
 }
+
+func TestTimestampFromTime(t *testing.T) {
+	then := time.Date(2024, 3, 15, 12, 30, 45, 123456789, time.UTC)
+	nearEpoch := time.Date(1970, 1, 2, 3, 4, 5, 6, time.UTC)
+	beforeEpoch := time.Date(1969, 12, 31, 23, 59, 59, 500000000, time.UTC)
+
+	tests := []struct {
+		datatype Datatype
+		time     time.Time
+		want     int64
+	}{
+		{TILEDB_DATETIME_YEAR, then, 54},
+		{TILEDB_DATETIME_MONTH, then, 650},
+		{TILEDB_DATETIME_WEEK, then, 2828},
+		{TILEDB_DATETIME_DAY, then, 19797},
+		{TILEDB_DATETIME_HR, then, 475140},
+		{TILEDB_DATETIME_MIN, then, 28508430},
+		{TILEDB_DATETIME_SEC, then, 1710505845},
+		{TILEDB_DATETIME_MS, then, 1710505845123},
+		{TILEDB_DATETIME_US, then, 1710505845123456},
+		{TILEDB_DATETIME_NS, then, 1710505845123456789},
+		{TILEDB_DATETIME_PS, nearEpoch, 97445000000006000},
+		{TILEDB_DATETIME_FS, time.Unix(1, 500), 1000000000500000},
+		{TILEDB_DATETIME_AS, time.Unix(1, 500), 1000000500000000000},
+		{TILEDB_TIME_SEC, then, 1710505845},
+		{TILEDB_TIME_NS, then, 1710505845123456789},
+		{TILEDB_DATETIME_YEAR, beforeEpoch, -1},
+		{TILEDB_DATETIME_MONTH, beforeEpoch, -1},
+		{TILEDB_DATETIME_DAY, beforeEpoch, -1},
+		{TILEDB_DATETIME_SEC, beforeEpoch, -1},
+		{TILEDB_DATETIME_MS, beforeEpoch, -500},
+		{TILEDB_DATETIME_NS, beforeEpoch, -500000000},
+	}
+	for _, tc := range tests {
+		got, err := TimestampFromTime(tc.datatype, tc.time)
+		require.NoError(t, err, "%v %v", tc.datatype, tc.time)
+		assert.Equal(t, tc.want, got, "%v %v", tc.datatype, tc.time)
+	}
+
+	timestamps, err := TimestampsFromTimes(TILEDB_DATETIME_MS, []time.Time{then, beforeEpoch})
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{then.Truncate(time.Millisecond), beforeEpoch}, TimesFromTimestamps(TILEDB_DATETIME_MS, timestamps))
+
+	_, err = TimestampFromTime(TILEDB_DATETIME_NS, time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Error(t, err)
+	_, err = TimestampFromTime(TILEDB_DATETIME_NS, time.Date(1600, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Error(t, err)
+	_, err = TimestampFromTime(TILEDB_DATETIME_PS, then)
+	assert.Error(t, err)
+	_, err = TimestampFromTime(TILEDB_INT64, then)
+	assert.ErrorIs(t, err, ErrSchemaMismatch)
+}
+
+func TestTimestampRoundTrip(t *testing.T) {
+	then := time.Date(2024, 3, 15, 12, 30, 45, 123456789, time.UTC)
+	nearEpoch := time.Date(1970, 1, 2, 3, 4, 5, 6, time.UTC)
+	beforeEpoch := time.Date(1969, 12, 31, 23, 59, 59, 500000000, time.UTC)
+
+	tests := []struct {
+		datatype Datatype
+		time     time.Time
+		want     time.Time
+	}{
+		{TILEDB_DATETIME_YEAR, then, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{TILEDB_DATETIME_YEAR, beforeEpoch, time.Date(1969, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{TILEDB_DATETIME_MONTH, then, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{TILEDB_DATETIME_MONTH, time.Date(1972, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(1972, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{TILEDB_DATETIME_MONTH, beforeEpoch, time.Date(1969, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{TILEDB_DATETIME_WEEK, then, time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)},
+		{TILEDB_DATETIME_DAY, then, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{TILEDB_DATETIME_DAY, beforeEpoch, time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{TILEDB_DATETIME_HR, then, then.Truncate(time.Hour)},
+		{TILEDB_DATETIME_MIN, then, then.Truncate(time.Minute)},
+		{TILEDB_DATETIME_SEC, then, then.Truncate(time.Second)},
+		{TILEDB_DATETIME_SEC, beforeEpoch, beforeEpoch.Truncate(time.Second)},
+		{TILEDB_DATETIME_MS, then, then.Truncate(time.Millisecond)},
+		{TILEDB_DATETIME_MS, beforeEpoch, beforeEpoch},
+		{TILEDB_DATETIME_US, then, then.Truncate(time.Microsecond)},
+		{TILEDB_DATETIME_NS, then, then},
+		{TILEDB_DATETIME_NS, beforeEpoch, beforeEpoch},
+		{TILEDB_DATETIME_PS, nearEpoch, nearEpoch},
+		{TILEDB_DATETIME_FS, time.Unix(1, 500).UTC(), time.Unix(1, 500).UTC()},
+		{TILEDB_DATETIME_AS, time.Unix(1, 500).UTC(), time.Unix(1, 500).UTC()},
+		{TILEDB_TIME_HR, then, then.Truncate(time.Hour)},
+		{TILEDB_TIME_MIN, then, then.Truncate(time.Minute)},
+		{TILEDB_TIME_SEC, then, then.Truncate(time.Second)},
+		{TILEDB_TIME_MS, then, then.Truncate(time.Millisecond)},
+		{TILEDB_TIME_US, then, then.Truncate(time.Microsecond)},
+		{TILEDB_TIME_NS, then, then},
+		{TILEDB_TIME_PS, nearEpoch, nearEpoch},
+		{TILEDB_TIME_FS, time.Unix(1, 500).UTC(), time.Unix(1, 500).UTC()},
+		{TILEDB_TIME_AS, time.Unix(1, 500).UTC(), time.Unix(1, 500).UTC()},
+	}
+	for _, tc := range tests {
+		timestamp, err := TimestampFromTime(tc.datatype, tc.time)
+		require.NoError(t, err, "%v %v", tc.datatype, tc.time)
+		assert.Equal(t, tc.want, GetTimeFromTimestamp(tc.datatype, timestamp), "%v %v", tc.datatype, tc.time)
+		assert.Equal(t, []time.Time{tc.want}, TimesFromTimestamps(tc.datatype, []int64{timestamp}), "%v %v", tc.datatype, tc.time)
+	}
+}
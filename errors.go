@@ -0,0 +1,102 @@
+package tiledb
+
+/*
+#include <tiledb/tiledb.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors that errors returned by this package can be matched against with errors.Is.
+// They are attached to errors where this package detects their condition, including after a
+// TileDB call failed.
+var (
+	// ErrNotFound matches errors about arrays, groups, files, fields or metadata that do not exist.
+	ErrNotFound = errors.New("tiledb: not found")
+	// ErrOOM matches errors caused by running out of memory or exceeding a memory budget.
+	ErrOOM = errors.New("tiledb: out of memory")
+	// ErrCancelled matches errors of operations stopped because their context.Context was done.
+	ErrCancelled = errors.New("tiledb: cancelled")
+	// ErrSchemaMismatch matches errors caused by values or buffers whose type does not match the array schema.
+	ErrSchemaMismatch = errors.New("tiledb: schema mismatch")
+	// ErrBufferTooSmall matches errors caused by buffers too small to hold a result.
+	ErrBufferTooSmall = errors.New("tiledb: buffer too small")
+)
+
+// ErrorCode is a return code of the TileDB C API.
+type ErrorCode int32
+
+const (
+	// TILEDB_ERR is the return code of failed calls.
+	TILEDB_ERR ErrorCode = C.TILEDB_ERR
+	// TILEDB_OOM is the return code of calls that ran out of memory.
+	TILEDB_OOM ErrorCode = C.TILEDB_OOM
+	// TILEDB_INVALID_CONTEXT is the return code of calls made with an invalid context.
+	TILEDB_INVALID_CONTEXT ErrorCode = C.TILEDB_INVALID_CONTEXT
+	// TILEDB_INVALID_ERROR is the return code of calls made with an invalid error.
+	TILEDB_INVALID_ERROR ErrorCode = C.TILEDB_INVALID_ERROR
+	// TILEDB_BUDGET_UNAVAILABLE is the return code of calls whose memory budget is unavailable.
+	TILEDB_BUDGET_UNAVAILABLE ErrorCode = C.TILEDB_BUDGET_UNAVAILABLE
+)
+
+// Error is an error reported by TileDB. The errors returned by this package wrap it
+// when a TileDB call fails, so it can be retrieved with errors.As:
+//
+//	var tdbErr *tiledb.Error
+//	if errors.As(err, &tdbErr) {
+//		log.Printf("%s failed with code %d", tdbErr.Op, tdbErr.Code)
+//	}
+//
+// It matches ErrOOM with errors.Is when its code reports a lack of memory. The errors
+// returned by this package match the other sentinel errors where the bindings detect
+// their condition, for example when an array that does not exist is opened.
+type Error struct {
+	// Code is the return code of the failed call.
+	Code ErrorCode
+	// Op is the C API function that failed, such as "tiledb_array_open". It is empty for
+	// errors retrieved with Context.LastError.
+	Op string
+	// Message is the message reported by TileDB.
+	Message string
+}
+
+// Error returns the message reported by TileDB.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether the error matches ErrOOM, based on its return code.
+func (e *Error) Is(target error) bool {
+	return target == ErrOOM && (e.Code == TILEDB_OOM || e.Code == TILEDB_BUDGET_UNAVAILABLE)
+}
+
+// notFoundError returns err, which also matches ErrNotFound if there is no object of
+// objectType at uri.
+func notFoundError(tdbCtx *Context, uri string, objectType ObjectTypeEnum, err error) error {
+	if actual, typeErr := ObjectType(tdbCtx, uri); typeErr == nil && actual != objectType {
+		return errorf(ErrNotFound, "%w", err)
+	}
+	return err
+}
+
+// sentinelError is an error detected by this package that matches a sentinel error.
+type sentinelError struct {
+	sentinel error
+	err      error
+}
+
+func (e *sentinelError) Error() string {
+	return e.err.Error()
+}
+
+func (e *sentinelError) Unwrap() []error {
+	return []error{e.sentinel, e.err}
+}
+
+// errorf formats an error like fmt.Errorf. The error also matches sentinel with errors.Is.
+func errorf(sentinel error, format string, args ...any) error {
+	return &sentinelError{sentinel: sentinel, err: fmt.Errorf(format, args...)}
+}
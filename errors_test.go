@@ -0,0 +1,91 @@
+package tiledb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestError(t *testing.T) {
+	err := &Error{Code: TILEDB_ERR, Op: "tiledb_array_open", Message: "[TileDB::Array] Error: Cannot open array; Array does not exist."}
+	assert.Equal(t, "[TileDB::Array] Error: Cannot open array; Array does not exist.", err.Error())
+	// Only the return code is matched, not the message.
+	assert.NotErrorIs(t, err, ErrNotFound)
+	assert.NotErrorIs(t, err, ErrOOM)
+	assert.ErrorIs(t, &Error{Code: TILEDB_OOM, Message: "allocation failed"}, ErrOOM)
+	assert.ErrorIs(t, &Error{Code: TILEDB_BUDGET_UNAVAILABLE, Message: "budget unavailable"}, ErrOOM)
+
+	// Errors detected by this package keep their message and match their sentinel.
+	wrapped := errorf(ErrSchemaMismatch, "cannot use %s: %w", "a1", err)
+	assert.Equal(t, "cannot use a1: [TileDB::Array] Error: Cannot open array; Array does not exist.", wrapped.Error())
+	assert.ErrorIs(t, wrapped, ErrSchemaMismatch)
+	var tdbErr *Error
+	assert.ErrorAs(t, wrapped, &tdbErr)
+}
+
+func TestErrorFromTileDB(t *testing.T) {
+	context, err := NewContext(nil)
+	require.NoError(t, err)
+	missing := t.TempDir() + "/missing"
+
+	array, err := NewArray(context, missing)
+	require.NoError(t, err)
+	defer array.Free()
+	err = array.Open(TILEDB_READ)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrNotFound)
+	var tdbErr *Error
+	require.True(t, errors.As(err, &tdbErr))
+	assert.Equal(t, TILEDB_ERR, tdbErr.Code)
+	assert.Equal(t, "tiledb_array_open", tdbErr.Op)
+
+	_, err = LoadArraySchema(context, missing)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	group, err := NewGroup(context, missing)
+	require.NoError(t, err)
+	defer group.Free()
+	assert.ErrorIs(t, group.Open(TILEDB_READ), ErrNotFound)
+
+	vfs, err := NewVFS(context, nil)
+	require.NoError(t, err)
+	defer vfs.Free()
+	_, err = vfs.Open(missing, TILEDB_VFS_READ)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// An existing array is found, even if it cannot be opened.
+	existing, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	defer existing.Free()
+	require.NoError(t, existing.Open(TILEDB_READ))
+	defer existing.Close()
+	schema, err := existing.Schema()
+	require.NoError(t, err)
+	defer schema.Free()
+	_, err = schema.AttributeFromName("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+	err = existing.Open(TILEDB_READ)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNotFound)
+}
+
+func TestErrorFromBindings(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+
+	query, err := NewQuery(array.context, array)
+	require.NoError(t, err)
+	defer query.Free()
+
+	_, err = query.SetDataBuffer("a1", make([]float64, 1))
+	assert.ErrorIs(t, err, ErrSchemaMismatch)
+	_, err = query.SetDataBuffer("missing", make([]int32, 1))
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, _, _, err = array.GetMetadata("missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -23,7 +23,7 @@ func FileSize(tdbCtx *Context, arrayURI string) (int64, error) {
 	ret := C.tiledb_filestore_size(tdbCtx.tiledbContext.Get(), cArrayURI, &size)
 	runtime.KeepAlive(tdbCtx)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting file size: %w", tdbCtx.LastError())
+		return 0, fmt.Errorf("error getting file size: %w", tdbCtx.lastError("tiledb_filestore_size", ret))
 	}
 
 	return int64(size), nil
@@ -40,7 +40,7 @@ func ExportFile(tdbCtx *Context, filePath, arrayURI string) error {
 	ret := C.tiledb_filestore_uri_export(tdbCtx.tiledbContext.Get(), cFileURI, cArrayURI)
 	runtime.KeepAlive(tdbCtx)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error exporting file: %w", tdbCtx.LastError())
+		return fmt.Errorf("error exporting file: %w", tdbCtx.lastError("tiledb_filestore_uri_export", ret))
 	}
 
 	return nil
@@ -56,7 +56,7 @@ func ImportFile(tdbCtx *Context, arrayURI, filePath string, mimeType FileStoreMi
 	ret := C.tiledb_filestore_uri_import(tdbCtx.tiledbContext.Get(), cArrayURI, cFileURI, C.tiledb_mime_type_t(mimeType))
 	runtime.KeepAlive(tdbCtx)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error importing file: %w", tdbCtx.LastError())
+		return fmt.Errorf("error importing file: %w", tdbCtx.lastError("tiledb_filestore_uri_import", ret))
 	}
 
 	return nil
@@ -158,7 +158,7 @@ func NewArraySchemaForFile(tdbCtx *Context, filePath string) (*ArraySchema, erro
 	ret := C.tiledb_filestore_schema_create(tdbCtx.tiledbContext.Get(), fileURI, &arraySchemaPtr)
 	runtime.KeepAlive(tdbCtx)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error creating schema: %w", tdbCtx.LastError())
+		return nil, fmt.Errorf("error creating schema: %w", tdbCtx.lastError("tiledb_filestore_schema_create", ret))
 	}
 
 	return newArraySchemaFromHandle(tdbCtx, newArraySchemaHandle(arraySchemaPtr)), nil
@@ -175,7 +175,7 @@ func bufferExport(tdbCtx *Context, uri *C.char, off int64, p []byte) error {
 	ret := C.tiledb_filestore_buffer_export(tdbCtx.tiledbContext.Get(), uri, C.size_t(off), slicePtr(p), C.size_t(len(p)))
 	runtime.KeepAlive(tdbCtx)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error exporting buffer data: %w", tdbCtx.LastError())
+		return fmt.Errorf("error exporting buffer data: %w", tdbCtx.lastError("tiledb_filestore_buffer_export", ret))
 	}
 
 	return nil
@@ -191,7 +191,7 @@ func bufferImport(tdbCtx *Context, uri *C.char, data []byte, mimeType FileStoreM
 	ret := C.tiledb_filestore_buffer_import(tdbCtx.tiledbContext.Get(), uri, slicePtr(data), C.size_t(len(data)), C.tiledb_mime_type_t(mimeType))
 	runtime.KeepAlive(tdbCtx)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error importing buffer data: %w", tdbCtx.LastError())
+		return fmt.Errorf("error importing buffer data: %w", tdbCtx.lastError("tiledb_filestore_buffer_import", ret))
 	}
 
 	return nil
@@ -9,6 +9,8 @@ import "C"
 import (
 	"errors"
 	"fmt"
+	"math"
+	"reflect"
 	"runtime"
 	"unsafe"
 )
@@ -43,7 +45,7 @@ func NewFilter(context *Context, filterType FilterType) (*Filter, error) {
 	ret := C.tiledb_filter_alloc(context.tiledbContext.Get(), C.tiledb_filter_type_t(filterType), &filterPtr)
 	runtime.KeepAlive(context)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error creating tiledb filter: %w", context.LastError())
+		return nil, fmt.Errorf("error creating tiledb filter: %w", context.lastError("tiledb_filter_alloc", ret))
 	}
 
 	return newFilterFromHandle(context, newFilterHandle(filterPtr)), nil
@@ -70,90 +72,189 @@ func (f *Filter) Type() (FilterType, error) {
 	runtime.KeepAlive(f)
 
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting tiledb filter type: %w", f.context.LastError())
+		return 0, fmt.Errorf("error getting tiledb filter type: %w", f.context.lastError("tiledb_filter_get_type", ret))
 	}
 
 	return FilterType(filterType), nil
 }
 
-// SetOption sets an option on a filter. Options are filter dependent;
-// this function returns an error if the given option is not valid for the
-// given filter.
-func (f *Filter) SetOption(filterOption FilterOption, valueInterface interface{}) error {
-	var cvalue unsafe.Pointer
-
+// filterOptionType returns the Go type of the values of a filter option, or nil if the
+// option is unknown.
+func filterOptionType(filterOption FilterOption) reflect.Type {
 	switch filterOption {
 	case TILEDB_COMPRESSION_LEVEL:
-		value, ok := valueInterface.(int32)
-		if !ok {
-			return errors.New("error setting tiledb filter option TILEDB_COMPRESSION_LEVEL, passed data is not int32")
+		return reflect.TypeFor[int32]()
+	case TILEDB_BIT_WIDTH_MAX_WINDOW, TILEDB_POSITIVE_DELTA_MAX_WINDOW:
+		return reflect.TypeFor[uint32]()
+	case TILEDB_SCALE_FLOAT_BYTEWIDTH:
+		return reflect.TypeFor[uint64]()
+	case TILEDB_SCALE_FLOAT_FACTOR, TILEDB_SCALE_FLOAT_OFFSET:
+		return reflect.TypeFor[float64]()
+	case TILEDB_WEBP_QUALITY:
+		return reflect.TypeFor[float32]()
+	case TILEDB_WEBP_INPUT_FORMAT:
+		return reflect.TypeFor[WebPFormat]()
+	case TILEDB_WEBP_LOSSLESS:
+		return reflect.TypeFor[bool]()
+	case TILEDB_COMPRESSION_REINTERPRET_DATATYPE:
+		return reflect.TypeFor[Datatype]()
+	}
+	return nil
+}
+
+// isCompressionFilter returns whether the filter type is a compressor, which accepts the
+// compression level and reinterpret datatype options.
+func isCompressionFilter(filterType FilterType) bool {
+	switch filterType {
+	case TILEDB_FILTER_GZIP, TILEDB_FILTER_ZSTD, TILEDB_FILTER_LZ4, TILEDB_FILTER_RLE, TILEDB_FILTER_BZIP2,
+		TILEDB_FILTER_DOUBLE_DELTA, TILEDB_FILTER_DELTA, TILEDB_FILTER_DICTIONARY:
+		return true
+	}
+	return false
+}
+
+// filterAcceptsOption returns whether filters of the filter type have the option.
+func filterAcceptsOption(filterType FilterType, filterOption FilterOption) bool {
+	switch filterOption {
+	case TILEDB_COMPRESSION_LEVEL, TILEDB_COMPRESSION_REINTERPRET_DATATYPE:
+		return isCompressionFilter(filterType)
+	case TILEDB_BIT_WIDTH_MAX_WINDOW:
+		return filterType == TILEDB_FILTER_BIT_WIDTH_REDUCTION
+	case TILEDB_POSITIVE_DELTA_MAX_WINDOW:
+		return filterType == TILEDB_FILTER_POSITIVE_DELTA
+	case TILEDB_SCALE_FLOAT_BYTEWIDTH, TILEDB_SCALE_FLOAT_FACTOR, TILEDB_SCALE_FLOAT_OFFSET:
+		return filterType == TILEDB_FILTER_SCALE_FLOAT
+	case TILEDB_WEBP_QUALITY, TILEDB_WEBP_INPUT_FORMAT, TILEDB_WEBP_LOSSLESS:
+		return filterType == TILEDB_FILTER_WEBP
+	}
+	return false
+}
+
+// validateFilterOption checks that value is a valid value of the option for filters of the
+// filter type.
+func validateFilterOption(filterType FilterType, filterOption FilterOption, value interface{}) error {
+	optionType := filterOptionType(filterOption)
+	if optionType == nil {
+		return fmt.Errorf("unknown filter option %d", filterOption)
+	}
+	if reflect.TypeOf(value) != optionType {
+		return fmt.Errorf("passed data is %T, not %v", value, optionType)
+	}
+	if !filterAcceptsOption(filterType, filterOption) {
+		return fmt.Errorf("option is not valid for %v filters", filterType)
+	}
+
+	switch value := value.(type) {
+	case uint64: // TILEDB_SCALE_FLOAT_BYTEWIDTH
+		if value != 1 && value != 2 && value != 4 && value != 8 {
+			return fmt.Errorf("byte width must be 1, 2, 4 or 8, got %d", value)
 		}
-		cvalue = unsafe.Pointer(&value)
-		ret := C.tiledb_filter_set_option(f.context.tiledbContext.Get(), f.tiledbFilter.Get(), C.tiledb_filter_option_t(filterOption), cvalue)
-		if ret != C.TILEDB_OK {
-			return fmt.Errorf("error setting tiledb filter option: %w", f.context.LastError())
+	case float64: // TILEDB_SCALE_FLOAT_FACTOR and TILEDB_SCALE_FLOAT_OFFSET
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return fmt.Errorf("value must be finite, got %v", value)
 		}
-	case TILEDB_BIT_WIDTH_MAX_WINDOW:
-		value, ok := valueInterface.(uint32)
-		if !ok {
-			return errors.New("error setting tiledb filter option TILEDB_BIT_WIDTH_MAX_WINDOW, passed data is not uint32")
+		if filterOption == TILEDB_SCALE_FLOAT_FACTOR && value == 0 {
+			return errors.New("scale factor must not be zero")
 		}
-		cvalue = unsafe.Pointer(&value)
-		ret := C.tiledb_filter_set_option(f.context.tiledbContext.Get(), f.tiledbFilter.Get(), C.tiledb_filter_option_t(filterOption), cvalue)
-		if ret != C.TILEDB_OK {
-			return fmt.Errorf("error setting tiledb filter option: %w", f.context.LastError())
+	case float32: // TILEDB_WEBP_QUALITY
+		if !(value >= 0 && value <= 100) {
+			return fmt.Errorf("quality must be between 0 and 100, got %v", value)
 		}
-	case TILEDB_POSITIVE_DELTA_MAX_WINDOW:
-		value, ok := valueInterface.(uint32)
-		if !ok {
-			return errors.New("error setting tiledb filter option TILEDB_POSITIVE_DELTA_MAX_WINDOW, passed data is not uint32")
+	case WebPFormat:
+		switch value {
+		case TILEDB_WEBP_NONE, TILEDB_WEBP_RGB, TILEDB_WEBP_BGR, TILEDB_WEBP_RGBA, TILEDB_WEBP_BGRA:
+		default:
+			return fmt.Errorf("unknown WebP format %d", value)
+		}
+	case Datatype:
+		if value != TILEDB_ANY && value.ReflectType() == nil {
+			return fmt.Errorf("cannot reinterpret input as %v", value)
 		}
+	}
+	return nil
+}
+
+// SetOption sets an option on a filter. Options are filter dependent;
+// this function returns an error if the given option is not valid for the
+// given filter, or if the value is not valid for the option. The Go type of
+// the value depends on the option:
+//
+//   - TILEDB_COMPRESSION_LEVEL: int32
+//   - TILEDB_BIT_WIDTH_MAX_WINDOW, TILEDB_POSITIVE_DELTA_MAX_WINDOW: uint32
+//   - TILEDB_SCALE_FLOAT_BYTEWIDTH: uint64, one of 1, 2, 4 or 8
+//   - TILEDB_SCALE_FLOAT_FACTOR, TILEDB_SCALE_FLOAT_OFFSET: float64
+//   - TILEDB_WEBP_QUALITY: float32, between 0 and 100
+//   - TILEDB_WEBP_INPUT_FORMAT: WebPFormat
+//   - TILEDB_WEBP_LOSSLESS: bool
+//   - TILEDB_COMPRESSION_REINTERPRET_DATATYPE: Datatype
+func (f *Filter) SetOption(filterOption FilterOption, valueInterface interface{}) error {
+	filterType, err := f.Type()
+	if err != nil {
+		return err
+	}
+	if err := validateFilterOption(filterType, filterOption, valueInterface); err != nil {
+		return fmt.Errorf("error setting tiledb filter option %v: %w", filterOption, err)
+	}
+
+	var cvalue unsafe.Pointer
+	switch value := valueInterface.(type) {
+	case int32:
 		cvalue = unsafe.Pointer(&value)
-		ret := C.tiledb_filter_set_option(f.context.tiledbContext.Get(), f.tiledbFilter.Get(), C.tiledb_filter_option_t(filterOption), cvalue)
-		if ret != C.TILEDB_OK {
-			return fmt.Errorf("error setting tiledb filter option: %w", f.context.LastError())
+	case uint32:
+		cvalue = unsafe.Pointer(&value)
+	case uint64:
+		cvalue = unsafe.Pointer(&value)
+	case float32:
+		cvalue = unsafe.Pointer(&value)
+	case float64:
+		cvalue = unsafe.Pointer(&value)
+	case WebPFormat:
+		cvalue = unsafe.Pointer(&value)
+	case Datatype:
+		v := uint8(value)
+		cvalue = unsafe.Pointer(&v)
+	case bool:
+		var v uint8
+		if value {
+			v = 1
 		}
+		cvalue = unsafe.Pointer(&v)
 	}
+
+	ret := C.tiledb_filter_set_option(f.context.tiledbContext.Get(), f.tiledbFilter.Get(), C.tiledb_filter_option_t(filterOption), cvalue)
 	runtime.KeepAlive(f)
+	if ret != C.TILEDB_OK {
+		return fmt.Errorf("error setting tiledb filter option: %w", f.context.lastError("tiledb_filter_set_option", ret))
+	}
 
 	return nil
 }
 
 // Option fetches the specified option set on a filter. Returns an interface{}
-// dependent on the option being fetched
+// dependent on the option being fetched, of the Go type documented by SetOption.
 // var optionValue int32
-// optionValueInterface, err := filter.Option(TILEDB_FILTER_GZIP)
+// optionValueInterface, err := filter.Option(TILEDB_COMPRESSION_LEVEL)
 // optionValue = optionValueInterface.(int32)
 func (f *Filter) Option(filterOption FilterOption) (interface{}, error) {
+	optionType := filterOptionType(filterOption)
+	if optionType == nil {
+		return nil, fmt.Errorf("error getting tiledb filter option: unknown filter option %d", filterOption)
+	}
 
-	var cvalue unsafe.Pointer
-
-	switch filterOption {
-	case TILEDB_COMPRESSION_LEVEL:
-		var val int32
-		cvalue = unsafe.Pointer(&val)
-		ret := C.tiledb_filter_get_option(f.context.tiledbContext.Get(), f.tiledbFilter.Get(), C.tiledb_filter_option_t(filterOption), cvalue)
-		if ret != C.TILEDB_OK {
-			return nil, fmt.Errorf("error getting tiledb filter option: %w", f.context.LastError())
-		}
-		return val, nil
-	case TILEDB_BIT_WIDTH_MAX_WINDOW:
-		var val uint32
-		cvalue = unsafe.Pointer(&val)
-		ret := C.tiledb_filter_get_option(f.context.tiledbContext.Get(), f.tiledbFilter.Get(), C.tiledb_filter_option_t(filterOption), cvalue)
-		if ret != C.TILEDB_OK {
-			return nil, fmt.Errorf("error getting tiledb filter option: %w", f.context.LastError())
-		}
-		return val, nil
-	case TILEDB_POSITIVE_DELTA_MAX_WINDOW:
-		var val uint32
-		cvalue = unsafe.Pointer(&val)
-		ret := C.tiledb_filter_get_option(f.context.tiledbContext.Get(), f.tiledbFilter.Get(), C.tiledb_filter_option_t(filterOption), cvalue)
-		if ret != C.TILEDB_OK {
-			return nil, fmt.Errorf("error getting tiledb filter option: %w", f.context.LastError())
-		}
-		return val, nil
+	// Datatype and bool options are stored as uint8_t.
+	storageType := optionType
+	if optionType.Kind() == reflect.Bool || optionType == reflect.TypeFor[Datatype]() {
+		storageType = reflect.TypeFor[uint8]()
 	}
+	value := reflect.New(storageType)
+	ret := C.tiledb_filter_get_option(f.context.tiledbContext.Get(), f.tiledbFilter.Get(), C.tiledb_filter_option_t(filterOption), value.UnsafePointer())
 	runtime.KeepAlive(f)
-	return nil, nil
+	if ret != C.TILEDB_OK {
+		return nil, fmt.Errorf("error getting tiledb filter option: %w", f.context.lastError("tiledb_filter_get_option", ret))
+	}
+
+	if optionType.Kind() == reflect.Bool {
+		return value.Elem().Uint() != 0, nil
+	}
+	return value.Elem().Convert(optionType).Interface(), nil
 }
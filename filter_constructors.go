@@ -0,0 +1,129 @@
+package tiledb
+
+import "fmt"
+
+// filterOptionValue is an option set by a typed filter constructor.
+type filterOptionValue struct {
+	option FilterOption
+	value  interface{}
+}
+
+// newFilterWithOptions allocates a filter and sets its options in order. The filter is freed
+// if an option cannot be set.
+func newFilterWithOptions(context *Context, filterType FilterType, options ...filterOptionValue) (*Filter, error) {
+	filter, err := NewFilter(context, filterType)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range options {
+		if err := filter.SetOption(o.option, o.value); err != nil {
+			filter.Free()
+			return nil, fmt.Errorf("error creating %v filter: %w", filterType, err)
+		}
+	}
+	return filter, nil
+}
+
+// NewGzipFilter allocates a gzip compression filter with the given compression level.
+func NewGzipFilter(context *Context, level int32) (*Filter, error) {
+	return newFilterWithOptions(context, TILEDB_FILTER_GZIP, filterOptionValue{TILEDB_COMPRESSION_LEVEL, level})
+}
+
+// NewZstdFilter allocates a Zstandard compression filter with the given compression level.
+func NewZstdFilter(context *Context, level int32) (*Filter, error) {
+	return newFilterWithOptions(context, TILEDB_FILTER_ZSTD, filterOptionValue{TILEDB_COMPRESSION_LEVEL, level})
+}
+
+// NewLZ4Filter allocates an LZ4 compression filter with the given compression level.
+func NewLZ4Filter(context *Context, level int32) (*Filter, error) {
+	return newFilterWithOptions(context, TILEDB_FILTER_LZ4, filterOptionValue{TILEDB_COMPRESSION_LEVEL, level})
+}
+
+// NewBzip2Filter allocates a bzip2 compression filter with the given compression level.
+func NewBzip2Filter(context *Context, level int32) (*Filter, error) {
+	return newFilterWithOptions(context, TILEDB_FILTER_BZIP2, filterOptionValue{TILEDB_COMPRESSION_LEVEL, level})
+}
+
+// NewRLEFilter allocates a run-length encoding filter.
+func NewRLEFilter(context *Context) (*Filter, error) {
+	return NewFilter(context, TILEDB_FILTER_RLE)
+}
+
+// NewDictionaryFilter allocates a dictionary encoding filter, which applies to string values.
+func NewDictionaryFilter(context *Context) (*Filter, error) {
+	return NewFilter(context, TILEDB_FILTER_DICTIONARY)
+}
+
+// NewDoubleDeltaFilter allocates a double-delta encoding filter. The input is reinterpreted
+// as the reinterpret datatype before encoding, which lets float values be encoded as integers
+// of the same size; TILEDB_ANY encodes the input as is.
+func NewDoubleDeltaFilter(context *Context, reinterpret Datatype) (*Filter, error) {
+	return newFilterWithOptions(context, TILEDB_FILTER_DOUBLE_DELTA, filterOptionValue{TILEDB_COMPRESSION_REINTERPRET_DATATYPE, reinterpret})
+}
+
+// NewDeltaFilter allocates a delta encoding filter. The input is reinterpreted as the
+// reinterpret datatype before encoding; TILEDB_ANY encodes the input as is.
+func NewDeltaFilter(context *Context, reinterpret Datatype) (*Filter, error) {
+	return newFilterWithOptions(context, TILEDB_FILTER_DELTA, filterOptionValue{TILEDB_COMPRESSION_REINTERPRET_DATATYPE, reinterpret})
+}
+
+// NewBitWidthReductionFilter allocates a bit width reduction filter with the given maximum
+// window length.
+func NewBitWidthReductionFilter(context *Context, maxWindow uint32) (*Filter, error) {
+	return newFilterWithOptions(context, TILEDB_FILTER_BIT_WIDTH_REDUCTION, filterOptionValue{TILEDB_BIT_WIDTH_MAX_WINDOW, maxWindow})
+}
+
+// NewPositiveDeltaFilter allocates a positive-delta encoding filter with the given maximum
+// window length.
+func NewPositiveDeltaFilter(context *Context, maxWindow uint32) (*Filter, error) {
+	return newFilterWithOptions(context, TILEDB_FILTER_POSITIVE_DELTA, filterOptionValue{TILEDB_POSITIVE_DELTA_MAX_WINDOW, maxWindow})
+}
+
+// NewBitShuffleFilter allocates a bitshuffle filter.
+func NewBitShuffleFilter(context *Context) (*Filter, error) {
+	return NewFilter(context, TILEDB_FILTER_BITSHUFFLE)
+}
+
+// NewByteShuffleFilter allocates a byteshuffle filter.
+func NewByteShuffleFilter(context *Context) (*Filter, error) {
+	return NewFilter(context, TILEDB_FILTER_BYTESHUFFLE)
+}
+
+// NewXORFilter allocates an XOR filter.
+func NewXORFilter(context *Context) (*Filter, error) {
+	return NewFilter(context, TILEDB_FILTER_XOR)
+}
+
+// NewChecksumMD5Filter allocates a filter storing MD5 checksums of the data.
+func NewChecksumMD5Filter(context *Context) (*Filter, error) {
+	return NewFilter(context, TILEDB_FILTER_CHECKSUM_MD5)
+}
+
+// NewChecksumSHA256Filter allocates a filter storing SHA256 checksums of the data.
+func NewChecksumSHA256Filter(context *Context) (*Filter, error) {
+	return NewFilter(context, TILEDB_FILTER_CHECKSUM_SHA256)
+}
+
+// NewScaleFloatFilter allocates a float scaling filter, which stores each float value v as the
+// integer round((v - offset) / factor) of byteWidth bytes. The factor must be non-zero and the
+// byte width one of 1, 2, 4 or 8.
+func NewScaleFloatFilter(context *Context, factor, offset float64, byteWidth uint64) (*Filter, error) {
+	return newFilterWithOptions(context, TILEDB_FILTER_SCALE_FLOAT,
+		filterOptionValue{TILEDB_SCALE_FLOAT_FACTOR, factor},
+		filterOptionValue{TILEDB_SCALE_FLOAT_OFFSET, offset},
+		filterOptionValue{TILEDB_SCALE_FLOAT_BYTEWIDTH, byteWidth},
+	)
+}
+
+// NewWebPFilter allocates a WebP image compression filter for uint8 pixels of the given
+// format. The quality, from 0 to 100, applies to lossy compression.
+func NewWebPFilter(context *Context, quality float32, format WebPFormat, lossless bool) (*Filter, error) {
+	if format == TILEDB_WEBP_NONE {
+		return nil, fmt.Errorf("error creating %v filter: an input format is required", TILEDB_FILTER_WEBP)
+	}
+	return newFilterWithOptions(context, TILEDB_FILTER_WEBP,
+		filterOptionValue{TILEDB_WEBP_QUALITY, quality},
+		filterOptionValue{TILEDB_WEBP_INPUT_FORMAT, format},
+		filterOptionValue{TILEDB_WEBP_LOSSLESS, lossless},
+	)
+}
@@ -0,0 +1,409 @@
+package tiledb
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FilterObjective is the measure by which RankFilterPipelines orders filter pipelines.
+type FilterObjective uint8
+
+const (
+	// FilterObjectiveCompressionRatio favors the pipelines writing the smallest fragments.
+	FilterObjectiveCompressionRatio FilterObjective = iota
+	// FilterObjectiveWriteThroughput favors the pipelines writing the sample the fastest.
+	FilterObjectiveWriteThroughput
+	// FilterObjectiveReadThroughput favors the pipelines reading the sample the fastest.
+	FilterObjectiveReadThroughput
+)
+
+// String returns the name of the objective.
+func (o FilterObjective) String() string {
+	switch o {
+	case FilterObjectiveCompressionRatio:
+		return "compression ratio"
+	case FilterObjectiveWriteThroughput:
+		return "write throughput"
+	case FilterObjectiveReadThroughput:
+		return "read throughput"
+	}
+	return fmt.Sprintf("FilterObjective(%d)", uint8(o))
+}
+
+// FilterEvaluationOption configures EvaluateFilterPipelines.
+type FilterEvaluationOption func(e *filterEvaluation)
+
+// WithEvaluationURI sets the location under which the temporary arrays are created, such as
+// a local directory or "mem://". It defaults to a new directory in os.TempDir.
+func WithEvaluationURI(uri string) FilterEvaluationOption {
+	return func(e *filterEvaluation) {
+		e.uri = uri
+	}
+}
+
+// WithEvaluationTileExtent sets the number of cells per tile of the temporary arrays, which
+// is the unit filters are applied to. It defaults to the smaller of 10000 and the number of
+// sample values.
+func WithEvaluationTileExtent(extent uint64) FilterEvaluationOption {
+	return func(e *filterEvaluation) {
+		e.tileExtent = extent
+	}
+}
+
+// filterEvaluation holds the sample and the settings of EvaluateFilterPipelines.
+type filterEvaluation struct {
+	context    *Context
+	datatype   Datatype
+	uri        string
+	tileExtent uint64
+
+	// The sample, as the buffers of a fixed-sized or var-sized attribute.
+	data     interface{}
+	offsets  []uint64
+	n        uint64
+	rawBytes uint64
+}
+
+// FilterPipelineResult holds the measures of a filter pipeline evaluated by
+// EvaluateFilterPipelines.
+type FilterPipelineResult struct {
+	// Index is the index of the pipeline in the candidates.
+	Index int
+	// FilterList is the evaluated pipeline.
+	FilterList *FilterList
+	// RawBytes is the size of the sample data, including the offsets of var-sized values.
+	RawBytes uint64
+	// FragmentBytes is the size of the fragment written with the pipeline, including its
+	// metadata.
+	FragmentBytes uint64
+	// CompressionRatio is RawBytes divided by FragmentBytes.
+	CompressionRatio float64
+	// WriteDuration is the time taken to write the sample.
+	WriteDuration time.Duration
+	// ReadDuration is the time taken to read the sample back.
+	ReadDuration time.Duration
+	// WriteThroughput is the number of raw bytes written per second.
+	WriteThroughput float64
+	// ReadThroughput is the number of raw bytes read per second.
+	ReadThroughput float64
+	// Err is the error that occurred while evaluating the pipeline, if any, for instance
+	// because it does not apply to the datatype. The measures are not meaningful
+	// if it is set.
+	Err error
+}
+
+/*
+EvaluateFilterPipelines measures how candidate filter pipelines perform on sample data of an
+attribute of the given datatype. The sample is a slice of values of the Go type of the datatype,
+or a []string written as var-sized values for TILEDB_STRING_ASCII, TILEDB_STRING_UTF8 and
+TILEDB_CHAR.
+
+For each candidate, the sample is written to a temporary one-dimensional dense array whose
+attribute uses the pipeline, and read back. The results hold the size of the fragment reported
+by FragmentInfo.GetFragmentSize and the timings of the write and read queries, in the order of
+the candidates; RankFilterPipelines orders them against an objective. A nil candidate evaluates
+an empty pipeline. Errors specific to a candidate are reported in its result, and the temporary
+arrays are removed once evaluated.
+
+	results, err := tiledb.EvaluateFilterPipelines(ctx, sample, tiledb.TILEDB_FLOAT64, candidates,
+		tiledb.WithEvaluationURI("mem://"))
+	if err != nil {
+		return err
+	}
+	tiledb.RankFilterPipelines(results, tiledb.FilterObjectiveCompressionRatio)
+*/
+func EvaluateFilterPipelines(tdbCtx *Context, sampleData interface{}, datatype Datatype, candidates []*FilterList, opts ...FilterEvaluationOption) ([]FilterPipelineResult, error) {
+	if tdbCtx == nil {
+		return nil, errors.New("error evaluating filter pipelines: context is nil")
+	}
+	e := &filterEvaluation{context: tdbCtx, datatype: datatype}
+	for _, opt := range opts {
+		opt(e)
+	}
+	if err := e.setSample(sampleData); err != nil {
+		return nil, fmt.Errorf("error evaluating filter pipelines: %w", err)
+	}
+	if e.tileExtent == 0 {
+		e.tileExtent = min(e.n, 10000)
+	}
+
+	if e.uri == "" {
+		dir, err := os.MkdirTemp("", "tiledb-filter-evaluation-")
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating filter pipelines: %w", err)
+		}
+		defer os.RemoveAll(dir)
+		e.uri = dir
+	}
+
+	results := make([]FilterPipelineResult, len(candidates))
+	for i, candidate := range candidates {
+		results[i] = e.evaluate(i, candidate)
+	}
+	return results, nil
+}
+
+// setSample sets the buffers written for the sample data.
+func (e *filterEvaluation) setSample(sampleData interface{}) error {
+	if strs, ok := sampleData.([]string); ok {
+		if !isStringDatatype(e.datatype) {
+			return errorf(ErrSchemaMismatch, "string samples require a string datatype, got %v", e.datatype)
+		}
+		data, offsets := PackStrings(strs)
+		if len(data) == 0 {
+			// Var-sized buffers cannot be empty.
+			return errors.New("sample strings are empty")
+		}
+		e.data, e.offsets = data, offsets
+		e.n = uint64(len(strs))
+		e.rawBytes = uint64(len(data)) + e.n*8
+		return nil
+	}
+
+	v := reflect.ValueOf(sampleData)
+	t := e.datatype.ReflectType()
+	if v.Kind() != reflect.Slice || t == nil || v.Type().Elem() != t {
+		return errorf(ErrSchemaMismatch, "sample data of type %T does not match datatype %v", sampleData, e.datatype)
+	}
+	if v.Len() == 0 {
+		return errors.New("sample data is empty")
+	}
+	e.data = sampleData
+	e.n = uint64(v.Len())
+	e.rawBytes = e.n * e.datatype.Size()
+	return nil
+}
+
+// evaluate writes and reads the sample with a candidate pipeline.
+func (e *filterEvaluation) evaluate(index int, filterList *FilterList) FilterPipelineResult {
+	result := FilterPipelineResult{Index: index, FilterList: filterList, RawBytes: e.rawBytes}
+	uri := fmt.Sprintf("%s/pipeline-%d", strings.TrimSuffix(e.uri, "/"), index)
+	if err := e.createArray(uri, filterList); err != nil {
+		result.Err = fmt.Errorf("error creating array for filter pipeline %d: %w", index, err)
+		return result
+	}
+	defer ObjectRemove(e.context, uri)
+
+	var err error
+	if result.WriteDuration, err = e.write(uri); err != nil {
+		result.Err = fmt.Errorf("error writing with filter pipeline %d: %w", index, err)
+		return result
+	}
+	if result.FragmentBytes, err = e.fragmentSize(uri); err != nil {
+		result.Err = fmt.Errorf("error getting fragment size of filter pipeline %d: %w", index, err)
+		return result
+	}
+	if result.ReadDuration, err = e.read(uri); err != nil {
+		result.Err = fmt.Errorf("error reading with filter pipeline %d: %w", index, err)
+		return result
+	}
+
+	result.CompressionRatio = float64(result.RawBytes) / float64(result.FragmentBytes)
+	result.WriteThroughput = throughput(result.RawBytes, result.WriteDuration)
+	result.ReadThroughput = throughput(result.RawBytes, result.ReadDuration)
+	return result
+}
+
+// throughput returns the number of bytes processed per second.
+func throughput(bytes uint64, d time.Duration) float64 {
+	if d <= 0 {
+		return math.Inf(1)
+	}
+	return float64(bytes) / d.Seconds()
+}
+
+// createArray creates the dense array evaluating a pipeline, with dimension "d" covering the
+// sample and attribute "a" filtered by the pipeline.
+func (e *filterEvaluation) createArray(uri string, filterList *FilterList) error {
+	schema, err := NewArraySchema(e.context, TILEDB_DENSE)
+	if err != nil {
+		return err
+	}
+	defer schema.Free()
+
+	domain, err := NewDomain(e.context)
+	if err != nil {
+		return err
+	}
+	defer domain.Free()
+	dimension, err := NewDimension(e.context, "d", TILEDB_UINT64, []uint64{0, e.n - 1}, e.tileExtent)
+	if err != nil {
+		return err
+	}
+	defer dimension.Free()
+	if err := domain.AddDimensions(dimension); err != nil {
+		return err
+	}
+	if err := schema.SetDomain(domain); err != nil {
+		return err
+	}
+
+	attribute, err := NewAttribute(e.context, "a", e.datatype)
+	if err != nil {
+		return err
+	}
+	defer attribute.Free()
+	if e.offsets != nil {
+		if err := attribute.SetCellValNum(TILEDB_VAR_NUM); err != nil {
+			return err
+		}
+	}
+	if filterList != nil {
+		if err := attribute.SetFilterList(filterList); err != nil {
+			return err
+		}
+	}
+	if err := schema.AddAttributes(attribute); err != nil {
+		return err
+	}
+	return CreateArray(e.context, uri, schema)
+}
+
+// query opens the array evaluating a pipeline and returns a query over the whole sample.
+// The returned function frees the query and closes the array.
+func (e *filterEvaluation) query(uri string, queryType QueryType) (*Query, func(), error) {
+	array, err := NewArray(e.context, uri)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := array.Open(queryType); err != nil {
+		array.Free()
+		return nil, nil, err
+	}
+	closeArray := func() {
+		array.Close()
+		array.Free()
+	}
+
+	query, err := NewQuery(e.context, array)
+	if err != nil {
+		closeArray()
+		return nil, nil, err
+	}
+	release := func() {
+		query.Free()
+		closeArray()
+	}
+	err = func() error {
+		if err := query.SetLayout(TILEDB_ROW_MAJOR); err != nil {
+			return err
+		}
+		subarray, err := array.NewSubarray()
+		if err != nil {
+			return err
+		}
+		defer subarray.Free()
+		if err := subarray.AddRange(0, MakeRange(uint64(0), e.n-1)); err != nil {
+			return err
+		}
+		return query.SetSubarray(subarray)
+	}()
+	if err != nil {
+		release()
+		return nil, nil, err
+	}
+	return query, release, nil
+}
+
+// write writes the sample and returns the time taken by the query.
+func (e *filterEvaluation) write(uri string) (time.Duration, error) {
+	query, release, err := e.query(uri, TILEDB_WRITE)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	if _, err := query.SetDataBuffer("a", e.data); err != nil {
+		return 0, err
+	}
+	if e.offsets != nil {
+		if _, err := query.SetOffsetsBuffer("a", e.offsets); err != nil {
+			return 0, err
+		}
+	}
+	start := time.Now()
+	if err := query.Submit(); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+// read reads the sample back and returns the time taken by the query.
+func (e *filterEvaluation) read(uri string) (time.Duration, error) {
+	query, release, err := e.query(uri, TILEDB_READ)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	data := reflect.ValueOf(e.data)
+	if _, err := query.SetDataBuffer("a", reflect.MakeSlice(data.Type(), data.Len(), data.Len()).Interface()); err != nil {
+		return 0, err
+	}
+	if e.offsets != nil {
+		if _, err := query.SetOffsetsBuffer("a", make([]uint64, len(e.offsets))); err != nil {
+			return 0, err
+		}
+	}
+	start := time.Now()
+	if err := query.Submit(); err != nil {
+		return 0, err
+	}
+	elapsed := time.Since(start)
+
+	status, err := query.Status()
+	if err != nil {
+		return 0, err
+	}
+	if status != TILEDB_COMPLETED {
+		return 0, fmt.Errorf("query status is %v", status)
+	}
+	return elapsed, nil
+}
+
+// fragmentSize returns the size of the single fragment of the array evaluating a pipeline.
+func (e *filterEvaluation) fragmentSize(uri string) (uint64, error) {
+	fragmentInfo, err := NewFragmentInfo(e.context, uri)
+	if err != nil {
+		return 0, err
+	}
+	defer fragmentInfo.Free()
+	if err := fragmentInfo.Load(); err != nil {
+		return 0, err
+	}
+	num, err := fragmentInfo.GetFragmentNum()
+	if err != nil {
+		return 0, err
+	}
+	if num != 1 {
+		return 0, fmt.Errorf("expected a single fragment, got %d", num)
+	}
+	return fragmentInfo.GetFragmentSize(0)
+}
+
+// RankFilterPipelines sorts results from the best to the worst pipeline against objective:
+// the highest compression ratio, write throughput or read throughput. Ties keep their relative
+// order, and results with an error come last.
+func RankFilterPipelines(results []FilterPipelineResult, objective FilterObjective) {
+	score := func(r FilterPipelineResult) float64 {
+		switch objective {
+		case FilterObjectiveWriteThroughput:
+			return r.WriteThroughput
+		case FilterObjectiveReadThroughput:
+			return r.ReadThroughput
+		}
+		return r.CompressionRatio
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if (results[i].Err == nil) != (results[j].Err == nil) {
+			return results[i].Err == nil
+		}
+		return score(results[i]) > score(results[j])
+	})
+}
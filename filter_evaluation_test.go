@@ -0,0 +1,91 @@
+package tiledb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateFilterPipelines(t *testing.T) {
+	tdbCtx, err := NewContext(nil)
+	require.NoError(t, err)
+
+	newList := func(filters ...*Filter) *FilterList {
+		list, err := NewFilterList(tdbCtx)
+		require.NoError(t, err)
+		t.Cleanup(list.Free)
+		for _, f := range filters {
+			require.NoError(t, list.AddFilter(f))
+		}
+		return list
+	}
+	zstd, err := NewZstdFilter(tdbCtx, 5)
+	require.NoError(t, err)
+	t.Cleanup(zstd.Free)
+	dictionary, err := NewDictionaryFilter(tdbCtx)
+	require.NoError(t, err)
+	t.Cleanup(dictionary.Free)
+
+	sample := make([]int64, 10000)
+	for i := range sample {
+		sample[i] = int64(i % 16)
+	}
+	candidates := []*FilterList{nil, newList(zstd), newList(dictionary)}
+
+	for _, uri := range []string{"mem://", t.TempDir(), ""} {
+		results, err := EvaluateFilterPipelines(tdbCtx, sample, TILEDB_INT64, candidates, WithEvaluationURI(uri))
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		for i, r := range results[:2] {
+			require.NoError(t, r.Err)
+			assert.Equal(t, i, r.Index)
+			assert.Equal(t, uint64(80000), r.RawBytes)
+			assert.NotZero(t, r.FragmentBytes)
+			assert.Positive(t, r.WriteThroughput)
+			assert.Positive(t, r.ReadThroughput)
+		}
+		assert.Greater(t, results[1].CompressionRatio, 10*results[0].CompressionRatio)
+		assert.ErrorIs(t, results[2].Err, ErrSchemaMismatch)
+
+		RankFilterPipelines(results, FilterObjectiveCompressionRatio)
+		assert.Equal(t, []int{1, 0, 2}, []int{results[0].Index, results[1].Index, results[2].Index})
+	}
+
+	_, err = EvaluateFilterPipelines(tdbCtx, []int32{1, 2}, TILEDB_INT64, candidates)
+	assert.ErrorIs(t, err, ErrSchemaMismatch)
+	_, err = EvaluateFilterPipelines(tdbCtx, []int64{}, TILEDB_INT64, candidates)
+	assert.Error(t, err)
+
+	results, err := EvaluateFilterPipelines(tdbCtx, []string{"a", "bb", "a", "bb"}, TILEDB_STRING_ASCII,
+		[]*FilterList{newList(dictionary)}, WithEvaluationURI("mem://"), WithEvaluationTileExtent(2))
+	require.NoError(t, err)
+	require.NoError(t, results[0].Err)
+	assert.Equal(t, uint64(6+4*8), results[0].RawBytes)
+}
+
+func TestRankFilterPipelines(t *testing.T) {
+	results := []FilterPipelineResult{
+		{Index: 0, CompressionRatio: 2, WriteThroughput: 300, ReadThroughput: 100},
+		{Index: 1, Err: ErrSchemaMismatch},
+		{Index: 2, CompressionRatio: 4, WriteThroughput: 100, ReadThroughput: 200},
+		{Index: 3, CompressionRatio: 3, WriteThroughput: 200, ReadThroughput: 300},
+	}
+	indexes := func() []int {
+		var indexes []int
+		for _, r := range results {
+			indexes = append(indexes, r.Index)
+		}
+		return indexes
+	}
+
+	RankFilterPipelines(results, FilterObjectiveCompressionRatio)
+	assert.Equal(t, []int{2, 3, 0, 1}, indexes())
+	RankFilterPipelines(results, FilterObjectiveWriteThroughput)
+	assert.Equal(t, []int{0, 3, 2, 1}, indexes())
+	RankFilterPipelines(results, FilterObjectiveReadThroughput)
+	assert.Equal(t, []int{3, 2, 0, 1}, indexes())
+
+	assert.Equal(t, float64(2000), throughput(1000, 500*time.Millisecond))
+}
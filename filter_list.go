@@ -7,6 +7,7 @@ package tiledb
 import "C"
 
 import (
+	"errors"
 	"fmt"
 	"runtime"
 	"unsafe"
@@ -43,7 +44,7 @@ func NewFilterList(context *Context) (*FilterList, error) {
 	ret := C.tiledb_filter_list_alloc(context.tiledbContext.Get(), &filterListPtr)
 	runtime.KeepAlive(context)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error creating tiledb FilterList: %w", context.LastError())
+		return nil, fmt.Errorf("error creating tiledb FilterList: %w", context.lastError("tiledb_filter_list_alloc", ret))
 	}
 
 	return newFilterListFromHandle(context, newFilterListHandle(filterListPtr)), nil
@@ -70,7 +71,7 @@ func (f *FilterList) AddFilter(filter *Filter) error {
 	runtime.KeepAlive(f)
 	runtime.KeepAlive(filter)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error adding filter to tiledb FilterList: %w", f.context.LastError())
+		return fmt.Errorf("error adding filter to tiledb FilterList: %w", f.context.lastError("tiledb_filter_list_add_filter", ret))
 	}
 	return nil
 }
@@ -80,7 +81,7 @@ func (f *FilterList) SetMaxChunkSize(maxChunkSize uint32) error {
 	ret := C.tiledb_filter_list_set_max_chunk_size(f.context.tiledbContext.Get(), f.tiledbFilterList.Get(), C.uint32_t(maxChunkSize))
 	runtime.KeepAlive(f)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting max chunk size on tiledb FilterList: %w", f.context.LastError())
+		return fmt.Errorf("error setting max chunk size on tiledb FilterList: %w", f.context.lastError("tiledb_filter_list_set_max_chunk_size", ret))
 	}
 	return nil
 }
@@ -91,7 +92,7 @@ func (f *FilterList) MaxChunkSize() (uint32, error) {
 	ret := C.tiledb_filter_list_get_max_chunk_size(f.context.tiledbContext.Get(), f.tiledbFilterList.Get(), &cMaxChunkSize)
 	runtime.KeepAlive(f)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error fetching max chunk size from tiledb FilterList: %w", f.context.LastError())
+		return 0, fmt.Errorf("error fetching max chunk size from tiledb FilterList: %w", f.context.lastError("tiledb_filter_list_get_max_chunk_size", ret))
 	}
 	return uint32(cMaxChunkSize), nil
 }
@@ -102,7 +103,7 @@ func (f *FilterList) NFilters() (uint32, error) {
 	ret := C.tiledb_filter_list_get_nfilters(f.context.tiledbContext.Get(), f.tiledbFilterList.Get(), &cNFilters)
 	runtime.KeepAlive(f)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting number of filter for tiledb FilterList: %w", f.context.LastError())
+		return 0, fmt.Errorf("error getting number of filter for tiledb FilterList: %w", f.context.lastError("tiledb_filter_list_get_nfilters", ret))
 	}
 	return uint32(cNFilters), nil
 }
@@ -113,7 +114,7 @@ func (f *FilterList) FilterFromIndex(index uint32) (*Filter, error) {
 	ret := C.tiledb_filter_list_get_filter_from_index(f.context.tiledbContext.Get(), f.tiledbFilterList.Get(), C.uint32_t(index), &filterPtr)
 	runtime.KeepAlive(f)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error fetching filter for index %d from tiledb FilterList: %w", index, f.context.LastError())
+		return nil, fmt.Errorf("error fetching filter for index %d from tiledb FilterList: %w", index, f.context.lastError("tiledb_filter_list_get_filter_from_index", ret))
 	}
 
 	return newFilterFromHandle(f.context, newFilterHandle(filterPtr)), nil
@@ -136,3 +137,93 @@ func (f *FilterList) Filters() ([]*Filter, error) {
 	}
 	return filters, err
 }
+
+// Validate checks that the filters of the list can be applied to values of datatype, so that
+// invalid combinations are reported before the array is created. Attribute.SetFilterList and
+// Dimension.SetFilterList validate their filter lists.
+func (f *FilterList) Validate(datatype Datatype) error {
+	filters, err := f.Filters()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, filter := range filters {
+			filter.Free()
+		}
+	}()
+
+	input := datatype
+	for i, filter := range filters {
+		filterType, err := filter.Type()
+		if err != nil {
+			return err
+		}
+		if err := filter.validateInput(filterType, input); err != nil {
+			return fmt.Errorf("invalid filter %d (%v) for %v values: %w", i, filterType, datatype, err)
+		}
+		if filterType == TILEDB_FILTER_SCALE_FLOAT {
+			// Float scaling outputs signed integers of its byte width.
+			if input, err = filter.scaleFloatOutput(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateInput checks that the filter accepts values of datatype as input.
+func (f *Filter) validateInput(filterType FilterType, input Datatype) error {
+	switch filterType {
+	case TILEDB_FILTER_SCALE_FLOAT:
+		if input != TILEDB_FLOAT32 && input != TILEDB_FLOAT64 {
+			return errorf(ErrSchemaMismatch, "float scaling requires float values")
+		}
+	case TILEDB_FILTER_WEBP:
+		if input != TILEDB_UINT8 {
+			return errorf(ErrSchemaMismatch, "WebP requires uint8 values")
+		}
+		format, err := f.Option(TILEDB_WEBP_INPUT_FORMAT)
+		if err != nil {
+			return err
+		}
+		if format == TILEDB_WEBP_NONE {
+			return errors.New("WebP requires an input format")
+		}
+	case TILEDB_FILTER_DICTIONARY:
+		switch input {
+		case TILEDB_STRING_ASCII, TILEDB_STRING_UTF8, TILEDB_STRING_UTF16, TILEDB_STRING_UTF32,
+			TILEDB_STRING_UCS2, TILEDB_STRING_UCS4, TILEDB_CHAR:
+		default:
+			return errorf(ErrSchemaMismatch, "dictionary encoding requires string values")
+		}
+	case TILEDB_FILTER_DOUBLE_DELTA, TILEDB_FILTER_DELTA:
+		reinterpret, err := f.Option(TILEDB_COMPRESSION_REINTERPRET_DATATYPE)
+		if err != nil {
+			return err
+		}
+		if dt := reinterpret.(Datatype); dt != TILEDB_ANY {
+			input = dt
+		}
+		if input == TILEDB_FLOAT32 || input == TILEDB_FLOAT64 {
+			return errorf(ErrSchemaMismatch, "delta encoding of float values requires an integer reinterpret datatype")
+		}
+	}
+	return nil
+}
+
+// scaleFloatOutput returns the datatype of the integers output by a float scaling filter.
+func (f *Filter) scaleFloatOutput() (Datatype, error) {
+	byteWidth, err := f.Option(TILEDB_SCALE_FLOAT_BYTEWIDTH)
+	if err != nil {
+		return 0, err
+	}
+	switch byteWidth.(uint64) {
+	case 1:
+		return TILEDB_INT8, nil
+	case 2:
+		return TILEDB_INT16, nil
+	case 4:
+		return TILEDB_INT32, nil
+	}
+	return TILEDB_INT64, nil
+}
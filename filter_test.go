@@ -0,0 +1,116 @@
+package tiledb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterConstructors(t *testing.T) {
+	tdbCtx, err := NewContext(nil)
+	require.NoError(t, err)
+
+	zstd, err := NewZstdFilter(tdbCtx, 7)
+	require.NoError(t, err)
+	level, err := zstd.Option(TILEDB_COMPRESSION_LEVEL)
+	require.NoError(t, err)
+	assert.Equal(t, int32(7), level)
+
+	delta, err := NewDoubleDeltaFilter(tdbCtx, TILEDB_INT64)
+	require.NoError(t, err)
+	reinterpret, err := delta.Option(TILEDB_COMPRESSION_REINTERPRET_DATATYPE)
+	require.NoError(t, err)
+	assert.Equal(t, TILEDB_INT64, reinterpret)
+
+	scale, err := NewScaleFloatFilter(tdbCtx, 0.5, 10, 2)
+	require.NoError(t, err)
+	factor, err := scale.Option(TILEDB_SCALE_FLOAT_FACTOR)
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, factor)
+	offset, err := scale.Option(TILEDB_SCALE_FLOAT_OFFSET)
+	require.NoError(t, err)
+	assert.Equal(t, float64(10), offset)
+	byteWidth, err := scale.Option(TILEDB_SCALE_FLOAT_BYTEWIDTH)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), byteWidth)
+
+	for _, newFilter := range []func(*Context) (*Filter, error){
+		NewRLEFilter, NewDictionaryFilter, NewBitShuffleFilter, NewByteShuffleFilter,
+		NewXORFilter, NewChecksumMD5Filter, NewChecksumSHA256Filter,
+	} {
+		filter, err := newFilter(tdbCtx)
+		require.NoError(t, err)
+		filter.Free()
+	}
+
+	_, err = NewScaleFloatFilter(tdbCtx, 0, 0, 4)
+	assert.Error(t, err)
+	_, err = NewScaleFloatFilter(tdbCtx, 1, 0, 3)
+	assert.Error(t, err)
+	_, err = NewWebPFilter(tdbCtx, 80, TILEDB_WEBP_NONE, false)
+	assert.Error(t, err)
+}
+
+func TestFilterSetOption(t *testing.T) {
+	tdbCtx, err := NewContext(nil)
+	require.NoError(t, err)
+	gzip, err := NewFilter(tdbCtx, TILEDB_FILTER_GZIP)
+	require.NoError(t, err)
+
+	require.NoError(t, gzip.SetOption(TILEDB_COMPRESSION_LEVEL, int32(5)))
+	require.NoError(t, gzip.SetOption(TILEDB_COMPRESSION_REINTERPRET_DATATYPE, TILEDB_UINT32))
+	assert.Error(t, gzip.SetOption(TILEDB_COMPRESSION_LEVEL, 5))
+	assert.Error(t, gzip.SetOption(TILEDB_BIT_WIDTH_MAX_WINDOW, uint32(8)))
+	assert.Error(t, gzip.SetOption(FilterOption(200), int32(5)))
+
+	bitWidth, err := NewBitWidthReductionFilter(tdbCtx, 256)
+	require.NoError(t, err)
+	window, err := bitWidth.Option(TILEDB_BIT_WIDTH_MAX_WINDOW)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(256), window)
+	assert.Error(t, bitWidth.SetOption(TILEDB_COMPRESSION_LEVEL, int32(5)))
+}
+
+func TestFilterListValidate(t *testing.T) {
+	tdbCtx, err := NewContext(nil)
+	require.NoError(t, err)
+
+	newList := func(filters ...*Filter) *FilterList {
+		list, err := NewFilterList(tdbCtx)
+		require.NoError(t, err)
+		for _, filter := range filters {
+			require.NoError(t, list.AddFilter(filter))
+		}
+		return list
+	}
+	scale, err := NewScaleFloatFilter(tdbCtx, 0.01, 0, 2)
+	require.NoError(t, err)
+	xor, err := NewXORFilter(tdbCtx)
+	require.NoError(t, err)
+	dictionary, err := NewDictionaryFilter(tdbCtx)
+	require.NoError(t, err)
+	doubleDelta, err := NewDoubleDeltaFilter(tdbCtx, TILEDB_ANY)
+	require.NoError(t, err)
+	reinterpreted, err := NewDoubleDeltaFilter(tdbCtx, TILEDB_INT64)
+	require.NoError(t, err)
+	zstd, err := NewZstdFilter(tdbCtx, 3)
+	require.NoError(t, err)
+
+	// Float scaling outputs int16 values, which XOR and double delta accept.
+	assert.NoError(t, newList(scale, xor, doubleDelta, zstd).Validate(TILEDB_FLOAT64))
+	assert.ErrorIs(t, newList(scale).Validate(TILEDB_INT32), ErrSchemaMismatch)
+	assert.ErrorIs(t, newList(dictionary).Validate(TILEDB_INT32), ErrSchemaMismatch)
+	assert.NoError(t, newList(dictionary).Validate(TILEDB_STRING_UTF8))
+	assert.ErrorIs(t, newList(doubleDelta).Validate(TILEDB_FLOAT64), ErrSchemaMismatch)
+	assert.NoError(t, newList(reinterpreted).Validate(TILEDB_FLOAT64))
+
+	attribute, err := NewAttribute(tdbCtx, "a", TILEDB_INT32)
+	require.NoError(t, err)
+	assert.ErrorIs(t, attribute.SetFilterList(newList(scale)), ErrSchemaMismatch)
+	require.NoError(t, attribute.SetFilterList(newList(xor, zstd)))
+
+	dimension, err := NewDimension(tdbCtx, "d", TILEDB_FLOAT32, []float32{0, 10}, float32(5))
+	require.NoError(t, err)
+	assert.ErrorIs(t, dimension.SetFilterList(newList(dictionary)), ErrSchemaMismatch)
+}
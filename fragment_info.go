@@ -53,7 +53,7 @@ func NewFragmentInfo(tdbCtx *Context, uri string) (*FragmentInfo, error) {
 		curi, &fragmentInfoPtr)
 	runtime.KeepAlive(tdbCtx)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error creating tiledb fragment info: %w", tdbCtx.LastError())
+		return nil, fmt.Errorf("error creating tiledb fragment info: %w", tdbCtx.lastError("tiledb_fragment_info_alloc", ret))
 	}
 
 	return newFragmentInfoFromHandle(tdbCtx, uri, newfragmentInfoHandle(fragmentInfoPtr)), nil
@@ -78,7 +78,7 @@ func (fI *FragmentInfo) Load() error {
 	ret := C.tiledb_fragment_info_load(fI.context.tiledbContext.Get(), fI.tiledbFragmentInfo.Get())
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error loading tiledb fragment info: %w", fI.context.LastError())
+		return fmt.Errorf("error loading tiledb fragment info: %w", fI.context.lastError("tiledb_fragment_info_load", ret))
 	}
 	return nil
 }
@@ -90,7 +90,7 @@ func (fI *FragmentInfo) GetFragmentNum() (uint32, error) {
 	ret := C.tiledb_fragment_info_get_fragment_num(fI.context.tiledbContext.Get(), fI.tiledbFragmentInfo.Get(), &cNum)
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting number of fragments from fragment info: %w", fI.context.LastError())
+		return 0, fmt.Errorf("error getting number of fragments from fragment info: %w", fI.context.lastError("tiledb_fragment_info_get_fragment_num", ret))
 	}
 
 	return uint32(cNum), nil
@@ -118,7 +118,7 @@ func (fI *FragmentInfo) GetFragmentSize(fid uint32) (uint64, error) {
 		fI.tiledbFragmentInfo.Get(), C.uint32_t(fid), &cSize)
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting fragment size for fragment %d: %w", fid, fI.context.LastError())
+		return 0, fmt.Errorf("error getting fragment size for fragment %d: %w", fid, fI.context.lastError("tiledb_fragment_info_get_fragment_size", ret))
 	}
 
 	return uint64(cSize), nil
@@ -132,7 +132,7 @@ func (fI *FragmentInfo) GetDense(fid uint32) (bool, error) {
 		fI.tiledbFragmentInfo.Get(), C.uint32_t(fid), &cDense)
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return false, fmt.Errorf("error finding if fragment %d is dense: %w", fid, fI.context.LastError())
+		return false, fmt.Errorf("error finding if fragment %d is dense: %w", fid, fI.context.lastError("tiledb_fragment_info_get_dense", ret))
 	}
 
 	return cDense == 1, nil
@@ -146,7 +146,7 @@ func (fI *FragmentInfo) GetSparse(fid uint32) (bool, error) {
 		fI.tiledbFragmentInfo.Get(), C.uint32_t(fid), &cSparse)
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return false, fmt.Errorf("error finding if fragment %d is dense: %w", fid, fI.context.LastError())
+		return false, fmt.Errorf("error finding if fragment %d is dense: %w", fid, fI.context.lastError("tiledb_fragment_info_get_sparse", ret))
 	}
 
 	return cSparse == 1, nil
@@ -161,7 +161,7 @@ func (fI *FragmentInfo) GetTimestampRange(fid uint32) (uint64, uint64, error) {
 		fI.tiledbFragmentInfo.Get(), C.uint32_t(fid), &cStart, &cEnd)
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return 0, 0, fmt.Errorf("error getting the timestamp range for fragment %d: %w", fid, fI.context.LastError())
+		return 0, 0, fmt.Errorf("error getting the timestamp range for fragment %d: %w", fid, fI.context.lastError("tiledb_fragment_info_get_timestamp_range", ret))
 	}
 
 	return uint64(cStart), uint64(cEnd), nil
@@ -251,7 +251,7 @@ func (fI *FragmentInfo) GetNonEmptyDomainFromIndex(fid uint32, did uint32) (*Non
 		tmpDimensionPtr)
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error in getting non empty domain from fragment %d for a given dimension index %d: %w", fid, did, fI.context.LastError())
+		return nil, fmt.Errorf("error in getting non empty domain from fragment %d for a given dimension index %d: %w", fid, did, fI.context.lastError("tiledb_fragment_info_get_non_empty_domain_from_index", ret))
 	}
 
 	if isEmpty == 1 {
@@ -286,7 +286,7 @@ func (fI *FragmentInfo) GetNonEmptyDomainFromName(fid uint32, did string) (*NonE
 		tmpDimensionPtr)
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error in getting non empty domain from fragment %d for a given dimension name %s: %w", fid, did, fI.context.LastError())
+		return nil, fmt.Errorf("error in getting non empty domain from fragment %d for a given dimension name %s: %w", fid, did, fI.context.lastError("tiledb_fragment_info_get_non_empty_domain_from_name", ret))
 	}
 
 	// If at least one domain for a dimension is empty the union of domains is non-empty
@@ -310,7 +310,7 @@ func (fI *FragmentInfo) GetNonEmptyDomainVarSizeFromIndex(fid uint32, did uint32
 		fI.tiledbFragmentInfo.Get(), C.uint32_t(fid), C.uint32_t(did), &cStart, &cEnd)
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return 0, 0, fmt.Errorf("error retrieving the non-empty domain range sizes from fragment %d for a given dimension index %d: %w", fid, did, fI.context.LastError())
+		return 0, 0, fmt.Errorf("error retrieving the non-empty domain range sizes from fragment %d for a given dimension index %d: %w", fid, did, fI.context.lastError("tiledb_fragment_info_get_non_empty_domain_var_size_from_index", ret))
 	}
 
 	return uint64(cStart), uint64(cEnd), nil
@@ -329,7 +329,7 @@ func (fI *FragmentInfo) GetNonEmptyDomainVarSizeFromName(fid uint32, did string)
 		fI.tiledbFragmentInfo.Get(), C.uint32_t(fid), cDid, &cStart, &cEnd)
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return 0, 0, fmt.Errorf("error retrieving the non-empty domain range sizes from fragment %d for a given dimension name %s: %w", fid, did, fI.context.LastError())
+		return 0, 0, fmt.Errorf("error retrieving the non-empty domain range sizes from fragment %d for a given dimension name %s: %w", fid, did, fI.context.lastError("tiledb_fragment_info_get_non_empty_domain_var_size_from_name", ret))
 	}
 
 	return uint64(cStart), uint64(cEnd), nil
@@ -344,7 +344,7 @@ func (fI *FragmentInfo) GetNonEmptyDomainVarFromIndex(fid uint32, did uint32) (*
 	ret := C.tiledb_fragment_info_get_non_empty_domain_var_size_from_index(fI.context.tiledbContext.Get(),
 		fI.tiledbFragmentInfo.Get(), C.uint32_t(fid), C.uint32_t(did), &cStartSize, &cEndSize)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error retrieving the non-empty domain range sizes from fragment %d for a given dimension index %d: %w", fid, did, fI.context.LastError())
+		return nil, fmt.Errorf("error retrieving the non-empty domain range sizes from fragment %d for a given dimension index %d: %w", fid, did, fI.context.lastError("tiledb_fragment_info_get_non_empty_domain_var_size_from_index", ret))
 	}
 
 	err := fI.useArrayFromCache()
@@ -401,7 +401,7 @@ func (fI *FragmentInfo) GetNonEmptyDomainVarFromIndex(fid uint32, did uint32) (*
 
 	if ret != C.TILEDB_OK {
 		return nil, fmt.Errorf("error in getting non empty domain for dimension index %d for fragment info %d: %w",
-			did, fid, fI.context.LastError())
+			did, fid, fI.context.lastError("tiledb_fragment_info_get_non_empty_domain_var_from_index", ret))
 	}
 
 	nonEmptyDomain, err := getNonEmptyDomainForDim(dimension, bounds)
@@ -430,7 +430,7 @@ func (fI *FragmentInfo) GetNonEmptyDomainVarFromName(fid uint32, did string) (*N
 	ret := C.tiledb_fragment_info_get_non_empty_domain_var_size_from_name(fI.context.tiledbContext.Get(),
 		fI.tiledbFragmentInfo.Get(), C.uint32_t(fid), cDid, &cStartSize, &cEndSize)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error retrieving the non-empty domain range sizes from fragment %d for a given dimension name %s: %w", fid, did, fI.context.LastError())
+		return nil, fmt.Errorf("error retrieving the non-empty domain range sizes from fragment %d for a given dimension name %s: %w", fid, did, fI.context.lastError("tiledb_fragment_info_get_non_empty_domain_var_size_from_name", ret))
 	}
 
 	err := fI.useArrayFromCache()
@@ -487,7 +487,7 @@ func (fI *FragmentInfo) GetNonEmptyDomainVarFromName(fid uint32, did string) (*N
 
 	if ret != C.TILEDB_OK {
 		return nil, fmt.Errorf("error in getting non empty domain for dimension name %s for fragment info %d: %w",
-			did, fid, fI.context.LastError())
+			did, fid, fI.context.lastError("tiledb_fragment_info_get_non_empty_domain_var_from_name", ret))
 	}
 
 	nonEmptyDomain, err := getNonEmptyDomainForDim(dimension, bounds)
@@ -519,7 +519,7 @@ func (fI *FragmentInfo) GetCellNum(fid uint32) (uint64, error) {
 		fI.tiledbFragmentInfo.Get(), C.uint32_t(fid), &cCellNum)
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error retrieving number of cells written to the fragment %d by the user: %w", fid, fI.context.LastError())
+		return 0, fmt.Errorf("error retrieving number of cells written to the fragment %d by the user: %w", fid, fI.context.lastError("tiledb_fragment_info_get_cell_num", ret))
 	}
 
 	return uint64(cCellNum), nil
@@ -533,7 +533,7 @@ func (fI *FragmentInfo) GetVersion(fid uint32) (uint32, error) {
 		fI.tiledbFragmentInfo.Get(), C.uint32_t(fid), &cVersion)
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error finding version of fragment %d: %w", fid, fI.context.LastError())
+		return 0, fmt.Errorf("error finding version of fragment %d: %w", fid, fI.context.lastError("tiledb_fragment_info_get_version", ret))
 	}
 
 	return uint32(cVersion), nil
@@ -547,7 +547,7 @@ func (fI *FragmentInfo) HasConsolidatedMetadata(fid uint32) (bool, error) {
 		fI.tiledbFragmentInfo.Get(), C.uint32_t(fid), &cHas)
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return false, fmt.Errorf("error finding if fragment %d has consolidated metadata: %w", fid, fI.context.LastError())
+		return false, fmt.Errorf("error finding if fragment %d has consolidated metadata: %w", fid, fI.context.lastError("tiledb_fragment_info_has_consolidated_metadata", ret))
 	}
 
 	return cHas == 1, nil
@@ -561,7 +561,7 @@ func (fI *FragmentInfo) GetUnconsolidatedMetadataNum() (uint32, error) {
 	ret := C.tiledb_fragment_info_get_unconsolidated_metadata_num(fI.context.tiledbContext.Get(), fI.tiledbFragmentInfo.Get(), &cNum)
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting number of fragments with unconsolidated metadata: %w", fI.context.LastError())
+		return 0, fmt.Errorf("error getting number of fragments with unconsolidated metadata: %w", fI.context.lastError("tiledb_fragment_info_get_unconsolidated_metadata_num", ret))
 	}
 
 	return uint32(cNum), nil
@@ -574,7 +574,7 @@ func (fI *FragmentInfo) GetToVacuumNum() (uint32, error) {
 	ret := C.tiledb_fragment_info_get_to_vacuum_num(fI.context.tiledbContext.Get(), fI.tiledbFragmentInfo.Get(), &cNum)
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting number of fragments to vacuum: %w", fI.context.LastError())
+		return 0, fmt.Errorf("error getting number of fragments to vacuum: %w", fI.context.lastError("tiledb_fragment_info_get_to_vacuum_num", ret))
 	}
 
 	return uint32(cNum), nil
@@ -587,7 +587,7 @@ func (fI *FragmentInfo) GetToVacuumURI(fid uint32) (string, error) {
 	ret := C.tiledb_fragment_info_get_to_vacuum_uri(fI.context.tiledbContext.Get(), fI.tiledbFragmentInfo.Get(), C.uint32_t(fid), &curi)
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return "", fmt.Errorf("error getting URI uri for fragment to vacuum: %w", fI.context.LastError())
+		return "", fmt.Errorf("error getting URI uri for fragment to vacuum: %w", fI.context.lastError("tiledb_fragment_info_get_to_vacuum_uri", ret))
 	}
 	uri := C.GoString(curi)
 	if uri == "" {
@@ -601,7 +601,7 @@ func (fI *FragmentInfo) DumpSTDOUT() error {
 	ret := C.tiledb_fragment_info_dump(fI.context.tiledbContext.Get(), fI.tiledbFragmentInfo.Get(), C.stdout)
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error dumping fragment info to stdout: %w", fI.context.LastError())
+		return fmt.Errorf("error dumping fragment info to stdout: %w", fI.context.lastError("tiledb_fragment_info_dump", ret))
 	}
 	return nil
 }
@@ -613,13 +613,13 @@ func (fI *FragmentInfo) String() (string, error) {
 	ret := C.tiledb_fragment_info_dump_str(fI.context.tiledbContext.Get(), fI.tiledbFragmentInfo.Get(), &tdbString)
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return "", fmt.Errorf("error dumping fragment info to string: %w", fI.context.LastError())
+		return "", fmt.Errorf("error dumping fragment info to string: %w", fI.context.lastError("tiledb_fragment_info_dump_str", ret))
 	}
 	defer C.tiledb_string_free(&tdbString)
 
 	dumpStr, err := stringHandleToString(tdbString)
 	if err != nil {
-		return "", fmt.Errorf("error getting fragment info string: %w", fI.context.LastError())
+		return "", fmt.Errorf("error getting fragment info string: %w", err)
 	}
 	return dumpStr, nil
 }
@@ -629,7 +629,7 @@ func (fI *FragmentInfo) SetConfig(config *Config) error {
 	ret := C.tiledb_fragment_info_set_config(fI.context.tiledbContext.Get(), fI.tiledbFragmentInfo.Get(), config.tiledbConfig.Get())
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting config on group: %w", fI.context.LastError())
+		return fmt.Errorf("error setting config on group: %w", fI.context.lastError("tiledb_fragment_info_set_config", ret))
 	}
 	return nil
 }
@@ -640,7 +640,7 @@ func (fI *FragmentInfo) Config() (*Config, error) {
 	ret := C.tiledb_fragment_info_get_config(fI.context.tiledbContext.Get(), fI.tiledbFragmentInfo.Get(), &configPtr)
 	runtime.KeepAlive(fI)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting config from fragment info: %w", fI.context.LastError())
+		return nil, fmt.Errorf("error getting config from fragment info: %w", fI.context.lastError("tiledb_fragment_info_get_config", ret))
 	}
 
 	return newConfigFromHandle(newConfigHandle(configPtr)), nil
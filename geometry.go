@@ -0,0 +1,352 @@
+package tiledb
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+/*
+Geometry is a two-dimensional geometry stored in a TILEDB_GEOM_WKB or TILEDB_GEOM_WKT
+attribute. It is one of Point, LineString, Polygon, MultiPoint, MultiLineString or
+MultiPolygon.
+
+Geometries are encoded with MarshalWKB and MarshalWKT and decoded with UnmarshalWKB and
+UnmarshalWKT. Query.SetGeometryBuffer and Query.GetGeometryResults write and read them
+directly, in the encoding of the attribute's Datatype.
+*/
+type Geometry interface {
+	// Envelope returns the bounding box of the geometry.
+	Envelope() Envelope
+	// String returns the WKT representation of the geometry.
+	String() string
+
+	appendWKB(b []byte) []byte
+	appendWKT(b []byte) []byte
+}
+
+// Point is a position in the plane.
+type Point struct {
+	X, Y float64
+}
+
+// LineString is a sequence of points joined by straight lines.
+type LineString []Point
+
+// Polygon is a planar surface bounded by closed rings. The first ring is the exterior
+// boundary and any other rings are holes.
+type Polygon []LineString
+
+// MultiPoint is a collection of points.
+type MultiPoint []Point
+
+// MultiLineString is a collection of line strings.
+type MultiLineString []LineString
+
+// MultiPolygon is a collection of polygons.
+type MultiPolygon []Polygon
+
+// Envelope is an axis-aligned bounding box. An envelope whose minimum exceeds its maximum
+// along either axis is empty; EmptyEnvelope returns one that can be extended.
+type Envelope struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// EmptyEnvelope returns an envelope containing nothing.
+func EmptyEnvelope() Envelope {
+	return Envelope{MinX: math.Inf(1), MinY: math.Inf(1), MaxX: math.Inf(-1), MaxY: math.Inf(-1)}
+}
+
+// IsEmpty returns whether the envelope contains nothing.
+func (e Envelope) IsEmpty() bool {
+	return !(e.MinX <= e.MaxX && e.MinY <= e.MaxY)
+}
+
+// ExtendPoint returns the smallest envelope containing e and p.
+func (e Envelope) ExtendPoint(p Point) Envelope {
+	return Envelope{
+		MinX: math.Min(e.MinX, p.X),
+		MinY: math.Min(e.MinY, p.Y),
+		MaxX: math.Max(e.MaxX, p.X),
+		MaxY: math.Max(e.MaxY, p.Y),
+	}
+}
+
+// Union returns the smallest envelope containing e and other.
+func (e Envelope) Union(other Envelope) Envelope {
+	if other.IsEmpty() {
+		return e
+	}
+	if e.IsEmpty() {
+		return other
+	}
+	return Envelope{
+		MinX: math.Min(e.MinX, other.MinX),
+		MinY: math.Min(e.MinY, other.MinY),
+		MaxX: math.Max(e.MaxX, other.MaxX),
+		MaxY: math.Max(e.MaxY, other.MaxY),
+	}
+}
+
+// Contains returns whether p lies within the envelope, including its boundary.
+func (e Envelope) Contains(p Point) bool {
+	return e.MinX <= p.X && p.X <= e.MaxX && e.MinY <= p.Y && p.Y <= e.MaxY
+}
+
+// Intersects returns whether the envelopes share at least one point.
+func (e Envelope) Intersects(other Envelope) bool {
+	if e.IsEmpty() || other.IsEmpty() {
+		return false
+	}
+	return e.MinX <= other.MaxX && other.MinX <= e.MaxX && e.MinY <= other.MaxY && other.MinY <= e.MaxY
+}
+
+// Envelope returns the envelope of the point.
+func (p Point) Envelope() Envelope {
+	return Envelope{MinX: p.X, MinY: p.Y, MaxX: p.X, MaxY: p.Y}
+}
+
+// Envelope returns the envelope of the points of the line string.
+func (l LineString) Envelope() Envelope {
+	return pointsEnvelope(l)
+}
+
+// Envelope returns the envelope of the exterior ring of the polygon.
+func (p Polygon) Envelope() Envelope {
+	if len(p) == 0 {
+		return EmptyEnvelope()
+	}
+	return p[0].Envelope()
+}
+
+// Envelope returns the envelope of the points.
+func (m MultiPoint) Envelope() Envelope {
+	return pointsEnvelope(m)
+}
+
+// Envelope returns the envelope of the line strings.
+func (m MultiLineString) Envelope() Envelope {
+	e := EmptyEnvelope()
+	for _, l := range m {
+		e = e.Union(l.Envelope())
+	}
+	return e
+}
+
+// Envelope returns the envelope of the polygons.
+func (m MultiPolygon) Envelope() Envelope {
+	e := EmptyEnvelope()
+	for _, p := range m {
+		e = e.Union(p.Envelope())
+	}
+	return e
+}
+
+func pointsEnvelope(points []Point) Envelope {
+	e := EmptyEnvelope()
+	for _, p := range points {
+		e = e.ExtendPoint(p)
+	}
+	return e
+}
+
+// isGeometryDatatype returns whether datatype stores geometries.
+func isGeometryDatatype(datatype Datatype) bool {
+	return datatype == TILEDB_GEOM_WKB || datatype == TILEDB_GEOM_WKT
+}
+
+// appendGeometry appends the encoding of g for a geometry datatype.
+func appendGeometry(b []byte, datatype Datatype, g Geometry) []byte {
+	if datatype == TILEDB_GEOM_WKT {
+		return g.appendWKT(b)
+	}
+	return g.appendWKB(b)
+}
+
+// decodeGeometry decodes a geometry encoded for a geometry datatype.
+func decodeGeometry(datatype Datatype, data []byte) (Geometry, error) {
+	if datatype == TILEDB_GEOM_WKT {
+		return UnmarshalWKT(string(data))
+	}
+	return UnmarshalWKB(data)
+}
+
+// geometryField returns the schema field of a geometry attribute of the array of the query.
+func geometryField(q *Query, attribute string) (fieldInfo, error) {
+	schema, err := q.array.Schema()
+	if err != nil {
+		return fieldInfo{}, fmt.Errorf("could not get array schema for %s: %w", attribute, err)
+	}
+	defer schema.Free()
+
+	info, err := schemaFieldInfo(schema, attribute)
+	if err != nil {
+		return fieldInfo{}, err
+	}
+	if !isGeometryDatatype(info.datatype) {
+		return fieldInfo{}, errorf(ErrSchemaMismatch, "%s is not a geometry attribute: datatype is %v", attribute, info.datatype)
+	}
+	if !info.isVar() {
+		return fieldInfo{}, errorf(ErrSchemaMismatch, "geometry attribute %s must be var-sized", attribute)
+	}
+	return info, nil
+}
+
+// SetGeometryBuffer encodes values into the data and offsets buffers of a TILEDB_GEOM_WKB or
+// TILEDB_GEOM_WKT attribute and sets them on the query. Nil values are written as null and
+// require a nullable attribute.
+func (q *Query) SetGeometryBuffer(attribute string, values []Geometry) error {
+	info, err := geometryField(q, attribute)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	offsets := make([]uint64, len(values))
+	var validity []uint8
+	if info.nullable {
+		validity = make([]uint8, len(values))
+	}
+	for i, g := range values {
+		offsets[i] = uint64(len(data))
+		if g == nil {
+			if !info.nullable {
+				return fmt.Errorf("cannot write null geometry %d to %s: attribute is not nullable", i, attribute)
+			}
+			continue
+		}
+		data = appendGeometry(data, info.datatype, g)
+		if validity != nil {
+			validity[i] = 1
+		}
+	}
+
+	return columnBuffers{data: reflect.ValueOf(data), offsets: offsets, validity: validity}.set(q, attribute)
+}
+
+// GetGeometryResults decodes the geometries read into the buffers of a TILEDB_GEOM_WKB or
+// TILEDB_GEOM_WKT attribute by the last submission of the query, with nil for null cells.
+func (q *Query) GetGeometryResults(attribute string) ([]Geometry, error) {
+	info, err := geometryField(q, attribute)
+	if err != nil {
+		return nil, err
+	}
+	data, offsets, err := varResults[uint8](q, attribute)
+	if err != nil {
+		return nil, err
+	}
+	var validity []uint8
+	if info.nullable {
+		if validity, err = q.GetValidityBuffer(attribute); err != nil {
+			return nil, err
+		}
+	}
+
+	values := make([]Geometry, len(offsets))
+	for i := range offsets {
+		if validity != nil && validity[i] == 0 {
+			continue
+		}
+		start, end := varCell(offsets, i, uint64(len(data)))
+		if values[i], err = decodeGeometry(info.datatype, data[start:end]); err != nil {
+			return nil, fmt.Errorf("error decoding geometry %d of %s: %w", i, attribute, err)
+		}
+	}
+	return values, nil
+}
+
+// AddEnvelopeRanges adds ranges selecting the cells within env to the x and y dimensions of
+// the subarray, typically the spatial dimensions of a sparse array. The envelope is clipped
+// to the domains of the dimensions, and for integer dimensions shrunk to the integer
+// coordinates it contains. It returns an error if no coordinates of the domain lie within
+// the envelope.
+func (sa *Subarray) AddEnvelopeRanges(xDim, yDim string, env Envelope) error {
+	if env.IsEmpty() {
+		return errors.New("error adding envelope ranges: envelope is empty")
+	}
+	xRange, err := sa.envelopeRange(xDim, env.MinX, env.MaxX)
+	if err != nil {
+		return err
+	}
+	yRange, err := sa.envelopeRange(yDim, env.MinY, env.MaxY)
+	if err != nil {
+		return err
+	}
+	if err := sa.AddRangeByName(xDim, xRange); err != nil {
+		return err
+	}
+	return sa.AddRangeByName(yDim, yRange)
+}
+
+// envelopeRange returns the range of dimension dimName covering [lo, hi].
+func (sa *Subarray) envelopeRange(dimName string, lo, hi float64) (Range, error) {
+	schema, err := sa.array.Schema()
+	if err != nil {
+		return Range{}, fmt.Errorf("could not get array schema for envelope ranges: %w", err)
+	}
+	defer schema.Free()
+
+	domain, err := schema.Domain()
+	if err != nil {
+		return Range{}, fmt.Errorf("could not get domain for envelope ranges: %w", err)
+	}
+	defer domain.Free()
+
+	dimension, err := domain.DimensionFromName(dimName)
+	if err != nil {
+		return Range{}, fmt.Errorf("could not get dimension %s for envelope ranges: %w", dimName, err)
+	}
+	defer dimension.Free()
+
+	datatype, err := dimension.Type()
+	if err != nil {
+		return Range{}, err
+	}
+	bounds, err := dimension.Domain()
+	if err != nil {
+		return Range{}, err
+	}
+
+	t := datatype.ReflectType()
+	boundsValue := reflect.ValueOf(bounds)
+	if t == nil || boundsValue.Kind() != reflect.Slice || boundsValue.Len() != 2 {
+		return Range{}, errorf(ErrSchemaMismatch, "cannot add envelope range to %v dimension %s", datatype, dimName)
+	}
+	domainLo, okLo := numberAsFloat64(boundsValue.Index(0))
+	domainHi, okHi := numberAsFloat64(boundsValue.Index(1))
+	if !okLo || !okHi {
+		return Range{}, errorf(ErrSchemaMismatch, "cannot add envelope range to %v dimension %s", datatype, dimName)
+	}
+
+	if t.Kind() != reflect.Float32 && t.Kind() != reflect.Float64 {
+		lo, hi = math.Ceil(lo), math.Floor(hi)
+	}
+	if lo > hi || lo > domainHi || hi < domainLo {
+		return Range{}, fmt.Errorf("error adding envelope range to %s: envelope does not intersect the domain", dimName)
+	}
+
+	// Endpoints outside the domain are replaced by its bounds, which also avoids converting
+	// floats beyond the range of the dimension type.
+	start, end := boundsValue.Index(0), boundsValue.Index(1)
+	if lo > domainLo {
+		start = reflect.ValueOf(lo).Convert(t)
+	}
+	if hi < domainHi {
+		end = reflect.ValueOf(hi).Convert(t)
+	}
+	return Range{start: start.Interface(), end: end.Interface()}, nil
+}
+
+// numberAsFloat64 returns the value of an integer or floating point number as a float64.
+func numberAsFloat64(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
@@ -0,0 +1,175 @@
+package tiledb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testGeometries = []Geometry{
+	Point{X: 1, Y: 2},
+	LineString{{X: 0, Y: 0}, {X: 1.5, Y: -2}},
+	Polygon{
+		{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 0}},
+		{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 1, Y: 2}, {X: 1, Y: 1}},
+	},
+	MultiPoint{{X: 1, Y: 2}, {X: 3, Y: 4}},
+	MultiLineString{{{X: 0, Y: 0}, {X: 1, Y: 1}}, {{X: 2, Y: 2}, {X: 3, Y: 3}}},
+	MultiPolygon{
+		{{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 0}}},
+		{{{X: 5, Y: 5}, {X: 6, Y: 5}, {X: 5, Y: 6}, {X: 5, Y: 5}}},
+	},
+	LineString{},
+	MultiPolygon{},
+}
+
+func TestGeometryEncoding(t *testing.T) {
+	wkt := []string{
+		"POINT (1 2)",
+		"LINESTRING (0 0, 1.5 -2)",
+		"POLYGON ((0 0, 4 0, 4 4, 0 0), (1 1, 2 1, 1 2, 1 1))",
+		"MULTIPOINT ((1 2), (3 4))",
+		"MULTILINESTRING ((0 0, 1 1), (2 2, 3 3))",
+		"MULTIPOLYGON (((0 0, 1 0, 0 1, 0 0)), ((5 5, 6 5, 5 6, 5 5)))",
+		"LINESTRING EMPTY",
+		"MULTIPOLYGON EMPTY",
+	}
+	for i, g := range testGeometries {
+		assert.Equal(t, wkt[i], MarshalWKT(g))
+		assert.Equal(t, wkt[i], g.String())
+
+		fromWKT, err := UnmarshalWKT(wkt[i])
+		require.NoError(t, err, wkt[i])
+		assert.Equal(t, g, fromWKT)
+
+		fromWKB, err := UnmarshalWKB(MarshalWKB(g))
+		require.NoError(t, err, wkt[i])
+		assert.Equal(t, g, fromWKB)
+	}
+
+	g, err := UnmarshalWKT("multipoint (1 2, 3 4)")
+	require.NoError(t, err)
+	assert.Equal(t, MultiPoint{{X: 1, Y: 2}, {X: 3, Y: 4}}, g)
+
+	bigEndian := []byte{0, 0, 0, 0, 1, 0x3f, 0xf0, 0, 0, 0, 0, 0, 0, 0x40, 0, 0, 0, 0, 0, 0, 0}
+	g, err = UnmarshalWKB(bigEndian)
+	require.NoError(t, err)
+	assert.Equal(t, Point{X: 1, Y: 2}, g)
+
+	for _, invalid := range []string{"", "POINT EMPTY", "POINT (1 2 3)", "POINT Z (1 2 3)", "LINESTRING (1 2, 3)", "POINT (1 2) x", "GEOMETRYCOLLECTION EMPTY"} {
+		_, err := UnmarshalWKT(invalid)
+		assert.Error(t, err, invalid)
+	}
+
+	wkb := MarshalWKB(Point{X: 1, Y: 2})
+	_, err = UnmarshalWKB(wkb[:10])
+	assert.Error(t, err)
+	_, err = UnmarshalWKB(append(wkb, 0))
+	assert.Error(t, err)
+	_, err = UnmarshalWKB([]byte{1, 2, 0, 0, 0, 0xff, 0xff, 0xff, 0xff})
+	assert.Error(t, err)
+}
+
+func TestEnvelope(t *testing.T) {
+	assert.Equal(t, Envelope{MinX: 0, MinY: -2, MaxX: 1.5, MaxY: 0}, testGeometries[1].Envelope())
+	assert.Equal(t, Envelope{MinX: 0, MinY: 0, MaxX: 6, MaxY: 6}, testGeometries[5].Envelope())
+	assert.True(t, LineString{}.Envelope().IsEmpty())
+
+	env := Envelope{MinX: 0, MinY: 0, MaxX: 2, MaxY: 2}
+	assert.True(t, env.Contains(Point{X: 2, Y: 1}))
+	assert.False(t, env.Contains(Point{X: 3, Y: 1}))
+	assert.True(t, env.Intersects(Envelope{MinX: 2, MinY: 2, MaxX: 3, MaxY: 3}))
+	assert.False(t, env.Intersects(Envelope{MinX: 2.5, MinY: 0, MaxX: 3, MaxY: 3}))
+	assert.False(t, env.Intersects(EmptyEnvelope()))
+	assert.Equal(t, Envelope{MinX: -1, MinY: 0, MaxX: 2, MaxY: 5}, env.Union(Point{X: -1, Y: 5}.Envelope()))
+	assert.Equal(t, env, EmptyEnvelope().Union(env))
+}
+
+func TestGeometryQuery(t *testing.T) {
+	arrPath := t.TempDir()
+	cfg, err := NewConfig()
+	require.NoError(t, err)
+	tdbCtx, err := NewContext(cfg)
+	require.NoError(t, err)
+	schema, err := NewArraySchema(tdbCtx, TILEDB_SPARSE)
+	require.NoError(t, err)
+	domain, err := NewDomain(tdbCtx)
+	require.NoError(t, err)
+	xDim, err := NewDimension(tdbCtx, "x", TILEDB_FLOAT64, []float64{0, 100}, float64(10))
+	require.NoError(t, err)
+	yDim, err := NewDimension(tdbCtx, "y", TILEDB_INT32, []int32{0, 100}, int32(10))
+	require.NoError(t, err)
+	require.NoError(t, domain.AddDimensions(xDim, yDim))
+	require.NoError(t, schema.SetDomain(domain))
+	wkbAttr, err := NewAttribute(tdbCtx, "wkb", TILEDB_GEOM_WKB)
+	require.NoError(t, err)
+	require.NoError(t, wkbAttr.SetCellValNum(TILEDB_VAR_NUM))
+	wktAttr, err := NewAttribute(tdbCtx, "wkt", TILEDB_GEOM_WKT)
+	require.NoError(t, err)
+	require.NoError(t, wktAttr.SetCellValNum(TILEDB_VAR_NUM))
+	require.NoError(t, wktAttr.SetNullable(true))
+	require.NoError(t, schema.AddAttributes(wkbAttr, wktAttr))
+	require.NoError(t, CreateArray(tdbCtx, arrPath, schema))
+
+	geometries := []Geometry{
+		Point{X: 1, Y: 1},
+		LineString{{X: 20, Y: 20}, {X: 30, Y: 25}},
+		Polygon{{{X: 50, Y: 50}, {X: 60, Y: 50}, {X: 60, Y: 60}, {X: 50, Y: 50}}},
+	}
+	nullable := []Geometry{geometries[0], nil, geometries[2]}
+
+	wArr := openArray(t, arrPath, TILEDB_WRITE)
+	wq, err := NewQuery(tdbCtx, wArr)
+	require.NoError(t, err)
+	require.NoError(t, wq.SetLayout(TILEDB_UNORDERED))
+	_, err = wq.SetDataBuffer("x", []float64{1, 20, 50})
+	require.NoError(t, err)
+	_, err = wq.SetDataBuffer("y", []int32{1, 20, 50})
+	require.NoError(t, err)
+	require.NoError(t, wq.SetGeometryBuffer("wkb", geometries))
+	require.NoError(t, wq.SetGeometryBuffer("wkt", nullable))
+	assert.Error(t, wq.SetGeometryBuffer("wkb", nullable))
+	assert.ErrorIs(t, wq.SetGeometryBuffer("x", geometries), ErrSchemaMismatch)
+	require.NoError(t, wq.Submit())
+
+	rArr := openArray(t, arrPath, TILEDB_READ)
+	rq, err := NewQuery(tdbCtx, rArr)
+	require.NoError(t, err)
+	require.NoError(t, rq.SetLayout(TILEDB_ROW_MAJOR))
+	sa, err := rArr.NewSubarray()
+	require.NoError(t, err)
+	// Covers the first two cells; the y range is shrunk to [0, 25] and clipped to the domain.
+	require.NoError(t, sa.AddEnvelopeRanges("x", "y", Envelope{MinX: -5, MinY: -5, MaxX: 35, MaxY: 25.5}))
+	xRange, err := sa.GetRange(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, MakeRange[float64](0, 35), xRange)
+	yRange, err := sa.GetRange(1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, MakeRange[int32](0, 25), yRange)
+	require.NoError(t, rq.SetSubarray(sa))
+	_, err = rq.SetDataBuffer("wkb", make([]byte, 1024))
+	require.NoError(t, err)
+	_, err = rq.SetOffsetsBuffer("wkb", make([]uint64, 3))
+	require.NoError(t, err)
+	_, err = rq.SetDataBuffer("wkt", make([]byte, 1024))
+	require.NoError(t, err)
+	_, err = rq.SetOffsetsBuffer("wkt", make([]uint64, 3))
+	require.NoError(t, err)
+	_, err = rq.SetValidityBuffer("wkt", make([]uint8, 3))
+	require.NoError(t, err)
+	require.NoError(t, rq.Submit())
+
+	got, err := rq.GetGeometryResults("wkb")
+	require.NoError(t, err)
+	assert.Equal(t, geometries[:2], got)
+	got, err = rq.GetGeometryResults("wkt")
+	require.NoError(t, err)
+	assert.Equal(t, nullable[:2], got)
+
+	sa, err = rArr.NewSubarray()
+	require.NoError(t, err)
+	assert.Error(t, sa.AddEnvelopeRanges("x", "y", Envelope{MinX: 200, MinY: 0, MaxX: 300, MaxY: 10}))
+	assert.Error(t, sa.AddEnvelopeRanges("x", "y", Envelope{MinX: 0, MinY: 1.2, MaxX: 10, MaxY: 1.8}))
+	assert.Error(t, sa.AddEnvelopeRanges("x", "y", EmptyEnvelope()))
+}
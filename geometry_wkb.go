@@ -0,0 +1,268 @@
+package tiledb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// WKB geometry type codes of the two-dimensional geometries.
+const (
+	wkbPoint           uint32 = 1
+	wkbLineString      uint32 = 2
+	wkbPolygon         uint32 = 3
+	wkbMultiPoint      uint32 = 4
+	wkbMultiLineString uint32 = 5
+	wkbMultiPolygon    uint32 = 6
+)
+
+// wkbNDR marks little-endian WKB, which is the byte order of encoded geometries.
+const wkbNDR = 1
+
+// MarshalWKB returns the well-known binary representation of g, in little-endian byte order.
+func MarshalWKB(g Geometry) []byte {
+	return g.appendWKB(nil)
+}
+
+// UnmarshalWKB decodes a geometry from its well-known binary representation, in either byte
+// order. Geometries with Z or M coordinates, geometry collections and empty points are not
+// supported.
+func UnmarshalWKB(data []byte) (Geometry, error) {
+	r := &wkbReader{data: data}
+	g, err := r.geometry(0)
+	if err != nil {
+		return nil, err
+	}
+	if r.pos != len(data) {
+		return nil, fmt.Errorf("invalid WKB: %d trailing bytes", len(data)-r.pos)
+	}
+	return g, nil
+}
+
+func appendWKBHeader(b []byte, geometryType uint32) []byte {
+	b = append(b, wkbNDR)
+	return binary.LittleEndian.AppendUint32(b, geometryType)
+}
+
+func appendWKBPoints(b []byte, points []Point) []byte {
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(points)))
+	for _, p := range points {
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(p.X))
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(p.Y))
+	}
+	return b
+}
+
+func appendWKBRings(b []byte, rings []LineString) []byte {
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(rings)))
+	for _, ring := range rings {
+		b = appendWKBPoints(b, ring)
+	}
+	return b
+}
+
+func (p Point) appendWKB(b []byte) []byte {
+	b = appendWKBHeader(b, wkbPoint)
+	b = binary.LittleEndian.AppendUint64(b, math.Float64bits(p.X))
+	return binary.LittleEndian.AppendUint64(b, math.Float64bits(p.Y))
+}
+
+func (l LineString) appendWKB(b []byte) []byte {
+	return appendWKBPoints(appendWKBHeader(b, wkbLineString), l)
+}
+
+func (p Polygon) appendWKB(b []byte) []byte {
+	return appendWKBRings(appendWKBHeader(b, wkbPolygon), p)
+}
+
+func (m MultiPoint) appendWKB(b []byte) []byte {
+	b = appendWKBHeader(b, wkbMultiPoint)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(m)))
+	for _, p := range m {
+		b = p.appendWKB(b)
+	}
+	return b
+}
+
+func (m MultiLineString) appendWKB(b []byte) []byte {
+	b = appendWKBHeader(b, wkbMultiLineString)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(m)))
+	for _, l := range m {
+		b = l.appendWKB(b)
+	}
+	return b
+}
+
+func (m MultiPolygon) appendWKB(b []byte) []byte {
+	b = appendWKBHeader(b, wkbMultiPolygon)
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(m)))
+	for _, p := range m {
+		b = p.appendWKB(b)
+	}
+	return b
+}
+
+// wkbReader decodes WKB geometries. Every geometry, including the members of collections,
+// carries its own byte order.
+type wkbReader struct {
+	data  []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+var errWKBTruncated = errors.New("invalid WKB: unexpected end of data")
+
+func (r *wkbReader) uint32() (uint32, error) {
+	if len(r.data)-r.pos < 4 {
+		return 0, errWKBTruncated
+	}
+	v := r.order.Uint32(r.data[r.pos:])
+	r.pos += 4
+	return v, nil
+}
+
+func (r *wkbReader) float64() (float64, error) {
+	if len(r.data)-r.pos < 8 {
+		return 0, errWKBTruncated
+	}
+	v := math.Float64frombits(r.order.Uint64(r.data[r.pos:]))
+	r.pos += 8
+	return v, nil
+}
+
+// count reads the number of elements of at least minSize bytes each that follow.
+func (r *wkbReader) count(minSize int) (int, error) {
+	n, err := r.uint32()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(n)*uint64(minSize) > uint64(len(r.data)-r.pos) {
+		return 0, errWKBTruncated
+	}
+	return int(n), nil
+}
+
+func (r *wkbReader) point() (Point, error) {
+	x, err := r.float64()
+	if err != nil {
+		return Point{}, err
+	}
+	y, err := r.float64()
+	if err != nil {
+		return Point{}, err
+	}
+	if math.IsNaN(x) && math.IsNaN(y) {
+		return Point{}, errors.New("invalid WKB: empty points are not supported")
+	}
+	return Point{X: x, Y: y}, nil
+}
+
+func (r *wkbReader) points() ([]Point, error) {
+	n, err := r.count(16)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]Point, n)
+	for i := range points {
+		if points[i], err = r.point(); err != nil {
+			return nil, err
+		}
+	}
+	return points, nil
+}
+
+func (r *wkbReader) rings() ([]LineString, error) {
+	n, err := r.count(4)
+	if err != nil {
+		return nil, err
+	}
+	rings := make([]LineString, n)
+	for i := range rings {
+		if rings[i], err = r.points(); err != nil {
+			return nil, err
+		}
+	}
+	return rings, nil
+}
+
+// geometry decodes a geometry. If want is non-zero, the geometry must be of that type.
+func (r *wkbReader) geometry(want uint32) (Geometry, error) {
+	if r.pos >= len(r.data) {
+		return nil, errWKBTruncated
+	}
+	switch r.data[r.pos] {
+	case 0:
+		r.order = binary.BigEndian
+	case 1:
+		r.order = binary.LittleEndian
+	default:
+		return nil, fmt.Errorf("invalid WKB: unknown byte order %d", r.data[r.pos])
+	}
+	r.pos++
+
+	geometryType, err := r.uint32()
+	if err != nil {
+		return nil, err
+	}
+	if want != 0 && geometryType != want {
+		return nil, fmt.Errorf("invalid WKB: expected geometry type %d, got %d", want, geometryType)
+	}
+
+	switch geometryType {
+	case wkbPoint:
+		return r.point()
+	case wkbLineString:
+		points, err := r.points()
+		return LineString(points), err
+	case wkbPolygon:
+		rings, err := r.rings()
+		return Polygon(rings), err
+	case wkbMultiPoint, wkbMultiLineString, wkbMultiPolygon:
+		return r.collection(geometryType)
+	}
+	return nil, fmt.Errorf("unsupported WKB geometry type %d", geometryType)
+}
+
+// collection decodes the members of a multi geometry.
+func (r *wkbReader) collection(geometryType uint32) (Geometry, error) {
+	// Every member has at least a byte order, a type and a count or coordinates.
+	n, err := r.count(9)
+	if err != nil {
+		return nil, err
+	}
+	var multi Geometry
+	switch geometryType {
+	case wkbMultiPoint:
+		points := make(MultiPoint, n)
+		for i := range points {
+			g, err := r.geometry(wkbPoint)
+			if err != nil {
+				return nil, err
+			}
+			points[i] = g.(Point)
+		}
+		multi = points
+	case wkbMultiLineString:
+		lines := make(MultiLineString, n)
+		for i := range lines {
+			g, err := r.geometry(wkbLineString)
+			if err != nil {
+				return nil, err
+			}
+			lines[i] = g.(LineString)
+		}
+		multi = lines
+	case wkbMultiPolygon:
+		polygons := make(MultiPolygon, n)
+		for i := range polygons {
+			g, err := r.geometry(wkbPolygon)
+			if err != nil {
+				return nil, err
+			}
+			polygons[i] = g.(Polygon)
+		}
+		multi = polygons
+	}
+	return multi, nil
+}
@@ -0,0 +1,336 @@
+package tiledb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MarshalWKT returns the well-known text representation of g.
+func MarshalWKT(g Geometry) string {
+	return string(g.appendWKT(nil))
+}
+
+// UnmarshalWKT decodes a geometry from its well-known text representation. Keywords are case
+// insensitive. Geometries with Z or M coordinates, geometry collections and empty points are
+// not supported.
+func UnmarshalWKT(text string) (Geometry, error) {
+	p := &wktParser{text: text}
+	g, err := p.geometry()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.next(); tok != "" {
+		return nil, p.errorf("unexpected %q after geometry", tok)
+	}
+	return g, nil
+}
+
+func appendWKTCoordinates(b []byte, p Point) []byte {
+	b = strconv.AppendFloat(b, p.X, 'f', -1, 64)
+	b = append(b, ' ')
+	return strconv.AppendFloat(b, p.Y, 'f', -1, 64)
+}
+
+func appendWKTPoints(b []byte, points []Point) []byte {
+	b = append(b, '(')
+	for i, p := range points {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		b = appendWKTCoordinates(b, p)
+	}
+	return append(b, ')')
+}
+
+func appendWKTRings(b []byte, rings []LineString) []byte {
+	b = append(b, '(')
+	for i, ring := range rings {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		b = appendWKTPoints(b, ring)
+	}
+	return append(b, ')')
+}
+
+// appendWKTTag appends the keyword of a geometry, followed by EMPTY for empty geometries.
+func appendWKTTag(b []byte, tag string, empty bool) []byte {
+	b = append(b, tag...)
+	if empty {
+		return append(b, " EMPTY"...)
+	}
+	return append(b, ' ')
+}
+
+func (p Point) appendWKT(b []byte) []byte {
+	b = append(b, "POINT ("...)
+	b = appendWKTCoordinates(b, p)
+	return append(b, ')')
+}
+
+func (l LineString) appendWKT(b []byte) []byte {
+	b = appendWKTTag(b, "LINESTRING", len(l) == 0)
+	if len(l) == 0 {
+		return b
+	}
+	return appendWKTPoints(b, l)
+}
+
+func (p Polygon) appendWKT(b []byte) []byte {
+	b = appendWKTTag(b, "POLYGON", len(p) == 0)
+	if len(p) == 0 {
+		return b
+	}
+	return appendWKTRings(b, p)
+}
+
+func (m MultiPoint) appendWKT(b []byte) []byte {
+	b = appendWKTTag(b, "MULTIPOINT", len(m) == 0)
+	if len(m) == 0 {
+		return b
+	}
+	b = append(b, '(')
+	for i, p := range m {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		b = appendWKTPoints(b, []Point{p})
+	}
+	return append(b, ')')
+}
+
+func (m MultiLineString) appendWKT(b []byte) []byte {
+	b = appendWKTTag(b, "MULTILINESTRING", len(m) == 0)
+	if len(m) == 0 {
+		return b
+	}
+	return appendWKTRings(b, m)
+}
+
+func (m MultiPolygon) appendWKT(b []byte) []byte {
+	b = appendWKTTag(b, "MULTIPOLYGON", len(m) == 0)
+	if len(m) == 0 {
+		return b
+	}
+	b = append(b, '(')
+	for i, p := range m {
+		if i > 0 {
+			b = append(b, ", "...)
+		}
+		b = appendWKTRings(b, p)
+	}
+	return append(b, ')')
+}
+
+// String returns the WKT representation of the point.
+func (p Point) String() string { return MarshalWKT(p) }
+
+// String returns the WKT representation of the line string.
+func (l LineString) String() string { return MarshalWKT(l) }
+
+// String returns the WKT representation of the polygon.
+func (p Polygon) String() string { return MarshalWKT(p) }
+
+// String returns the WKT representation of the points.
+func (m MultiPoint) String() string { return MarshalWKT(m) }
+
+// String returns the WKT representation of the line strings.
+func (m MultiLineString) String() string { return MarshalWKT(m) }
+
+// String returns the WKT representation of the polygons.
+func (m MultiPolygon) String() string { return MarshalWKT(m) }
+
+// wktParser decodes WKT geometries. Tokens are words, numbers and the punctuation "(", ")"
+// and ",".
+type wktParser struct {
+	text string
+	pos  int
+	tok  string // lookahead token, if any
+}
+
+func (p *wktParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid WKT at offset %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
+
+// next returns the next token, or "" at the end of the text.
+func (p *wktParser) next() string {
+	if p.tok != "" {
+		tok := p.tok
+		p.tok = ""
+		return tok
+	}
+	for p.pos < len(p.text) && strings.ContainsRune(" \t\r\n", rune(p.text[p.pos])) {
+		p.pos++
+	}
+	if p.pos >= len(p.text) {
+		return ""
+	}
+	start := p.pos
+	if strings.ContainsRune("(),", rune(p.text[p.pos])) {
+		p.pos++
+		return p.text[start:p.pos]
+	}
+	for p.pos < len(p.text) && !strings.ContainsRune(" \t\r\n(),", rune(p.text[p.pos])) {
+		p.pos++
+	}
+	return p.text[start:p.pos]
+}
+
+func (p *wktParser) peek() string {
+	if p.tok == "" {
+		p.tok = p.next()
+	}
+	return p.tok
+}
+
+func (p *wktParser) expect(want string) error {
+	if tok := p.next(); tok != want {
+		return p.errorf("expected %q, got %q", want, tok)
+	}
+	return nil
+}
+
+func (p *wktParser) number() (float64, error) {
+	tok := p.next()
+	v, err := strconv.ParseFloat(tok, 64)
+	if err != nil {
+		return 0, p.errorf("expected a number, got %q", tok)
+	}
+	return v, nil
+}
+
+func (p *wktParser) point() (Point, error) {
+	x, err := p.number()
+	if err != nil {
+		return Point{}, err
+	}
+	y, err := p.number()
+	if err != nil {
+		return Point{}, err
+	}
+	if tok := p.peek(); tok != "," && tok != ")" {
+		return Point{}, p.errorf("only two-dimensional coordinates are supported, got %q", tok)
+	}
+	return Point{X: x, Y: y}, nil
+}
+
+// list parses a parenthesized, comma-separated list of elements.
+func (p *wktParser) list(element func() error) error {
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	for {
+		if err := element(); err != nil {
+			return err
+		}
+		switch tok := p.next(); tok {
+		case ",":
+		case ")":
+			return nil
+		default:
+			return p.errorf("expected \",\" or \")\", got %q", tok)
+		}
+	}
+}
+
+func (p *wktParser) points() (LineString, error) {
+	var points LineString
+	err := p.list(func() error {
+		pt, err := p.point()
+		points = append(points, pt)
+		return err
+	})
+	return points, err
+}
+
+func (p *wktParser) rings() (Polygon, error) {
+	var rings Polygon
+	err := p.list(func() error {
+		ring, err := p.points()
+		rings = append(rings, ring)
+		return err
+	})
+	return rings, err
+}
+
+// geometry parses a geometry tagged with its type.
+func (p *wktParser) geometry() (Geometry, error) {
+	tag := strings.ToUpper(p.next())
+	if tag == "" {
+		return nil, p.errorf("expected a geometry")
+	}
+
+	switch strings.ToUpper(p.peek()) {
+	case "EMPTY":
+		p.next()
+		return emptyWKTGeometry(p, tag)
+	case "Z", "M", "ZM":
+		return nil, p.errorf("only two-dimensional geometries are supported, got %s %s", tag, p.peek())
+	}
+
+	switch tag {
+	case "POINT":
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		pt, err := p.point()
+		if err != nil {
+			return nil, err
+		}
+		return pt, p.expect(")")
+	case "LINESTRING":
+		return p.points()
+	case "POLYGON":
+		return p.rings()
+	case "MULTIPOINT":
+		var points MultiPoint
+		err := p.list(func() error {
+			// Members may be parenthesized or bare coordinates.
+			parenthesized := p.peek() == "("
+			if parenthesized {
+				p.next()
+			}
+			pt, err := p.point()
+			if err != nil {
+				return err
+			}
+			points = append(points, pt)
+			if parenthesized {
+				return p.expect(")")
+			}
+			return nil
+		})
+		return points, err
+	case "MULTILINESTRING":
+		lines, err := p.rings()
+		return MultiLineString(lines), err
+	case "MULTIPOLYGON":
+		var polygons MultiPolygon
+		err := p.list(func() error {
+			polygon, err := p.rings()
+			polygons = append(polygons, polygon)
+			return err
+		})
+		return polygons, err
+	}
+	return nil, p.errorf("unsupported geometry type %q", tag)
+}
+
+func emptyWKTGeometry(p *wktParser, tag string) (Geometry, error) {
+	switch tag {
+	case "LINESTRING":
+		return LineString{}, nil
+	case "POLYGON":
+		return Polygon{}, nil
+	case "MULTIPOINT":
+		return MultiPoint{}, nil
+	case "MULTILINESTRING":
+		return MultiLineString{}, nil
+	case "MULTIPOLYGON":
+		return MultiPolygon{}, nil
+	case "POINT":
+		return nil, p.errorf("empty points are not supported")
+	}
+	return nil, p.errorf("unsupported geometry type %q", tag)
+}
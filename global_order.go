@@ -0,0 +1,331 @@
+package tiledb
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"math/bits"
+	"reflect"
+	"strings"
+)
+
+// orderDimension gives access to the coordinates of a dimension to sort cells in global order.
+type orderDimension interface {
+	// tile returns the index of the space tile of cell i along the dimension.
+	tile(i int) uint64
+	// compare compares the coordinates of cells i and j.
+	compare(i, j int) int
+	// bucket maps the coordinate of cell i to [0, maxBucket], as TileDB does for Hilbert order.
+	bucket(i int, maxBucket uint64) uint64
+}
+
+// denseOrderDimension is an orderDimension of an integer dimension, which can be used to locate
+// cells in dense arrays.
+type denseOrderDimension interface {
+	orderDimension
+	// offset returns the offset of the coordinate of cell i from origin.
+	offset(i int, origin any) uint64
+	// domainOffset returns the offset of the coordinate of cell i from the start of the domain.
+	domainOffset(i int) uint64
+	// tileExtent returns the tile extent of the dimension.
+	tileExtent() uint64
+}
+
+// integerOrderDimension is an orderDimension of an integer dimension.
+type integerOrderDimension[T partitionInteger] struct {
+	values             []T
+	domainLo, domainHi T
+	extent             uint64
+}
+
+func (d *integerOrderDimension[T]) tile(i int) uint64 {
+	if d.extent == 0 {
+		return 0
+	}
+	return (uint64(d.values[i]) - uint64(d.domainLo)) / d.extent
+}
+
+func (d *integerOrderDimension[T]) compare(i, j int) int {
+	return cmp.Compare(d.values[i], d.values[j])
+}
+
+func (d *integerOrderDimension[T]) bucket(i int, maxBucket uint64) uint64 {
+	return hilbertBucket(float64(d.values[i]), float64(d.domainLo), float64(d.domainHi), maxBucket)
+}
+
+func (d *integerOrderDimension[T]) offset(i int, origin any) uint64 {
+	return uint64(d.values[i]) - uint64(origin.(T))
+}
+
+func (d *integerOrderDimension[T]) domainOffset(i int) uint64 {
+	return uint64(d.values[i]) - uint64(d.domainLo)
+}
+
+func (d *integerOrderDimension[T]) tileExtent() uint64 {
+	return d.extent
+}
+
+// floatOrderDimension is an orderDimension of a floating point dimension.
+type floatOrderDimension[T float32 | float64] struct {
+	values             []T
+	domainLo, domainHi T
+	extent             float64
+}
+
+func (d *floatOrderDimension[T]) tile(i int) uint64 {
+	if d.extent == 0 {
+		return 0
+	}
+	return uint64((float64(d.values[i]) - float64(d.domainLo)) / d.extent)
+}
+
+func (d *floatOrderDimension[T]) compare(i, j int) int {
+	return cmp.Compare(d.values[i], d.values[j])
+}
+
+func (d *floatOrderDimension[T]) bucket(i int, maxBucket uint64) uint64 {
+	return hilbertBucket(float64(d.values[i]), float64(d.domainLo), float64(d.domainHi), maxBucket)
+}
+
+// stringOrderDimension is an orderDimension of a var-sized string dimension, which has no space tiles.
+type stringOrderDimension struct {
+	values []string
+}
+
+func (d *stringOrderDimension) tile(int) uint64 {
+	return 0
+}
+
+func (d *stringOrderDimension) compare(i, j int) int {
+	return strings.Compare(d.values[i], d.values[j])
+}
+
+// bucket maps the first 8 bytes of the coordinate, read as a big-endian integer, to the bucket range.
+func (d *stringOrderDimension) bucket(i int, maxBucket uint64) uint64 {
+	var v uint64
+	for k := range 8 {
+		v <<= 8
+		if k < len(d.values[i]) {
+			v |= uint64(d.values[i][k])
+		}
+	}
+	return v >> (64 - bits.Len64(maxBucket))
+}
+
+// hilbertBucket maps v in [lo, hi] to [0, maxBucket].
+func hilbertBucket(v, lo, hi float64, maxBucket uint64) uint64 {
+	if hi <= lo {
+		return 0
+	}
+	b := (v - lo) / (hi - lo) * float64(maxBucket)
+	if b >= float64(maxBucket) {
+		return maxBucket
+	}
+	return uint64(b)
+}
+
+// newOrderDimension returns the orderDimension of the coordinates b of dimension, described by info.
+func newOrderDimension(dimension *Dimension, info fieldInfo, b columnBuffers) (orderDimension, error) {
+	switch info.datatype.ReflectKind() {
+	case reflect.Int8:
+		return newIntegerOrderDimension[int8](dimension, b)
+	case reflect.Int16:
+		return newIntegerOrderDimension[int16](dimension, b)
+	case reflect.Int32:
+		return newIntegerOrderDimension[int32](dimension, b)
+	case reflect.Int64:
+		return newIntegerOrderDimension[int64](dimension, b)
+	case reflect.Uint8:
+		if info.isVar() {
+			return newStringOrderDimension(b), nil
+		}
+		return newIntegerOrderDimension[uint8](dimension, b)
+	case reflect.Uint16:
+		return newIntegerOrderDimension[uint16](dimension, b)
+	case reflect.Uint32:
+		return newIntegerOrderDimension[uint32](dimension, b)
+	case reflect.Uint64:
+		return newIntegerOrderDimension[uint64](dimension, b)
+	case reflect.Float32:
+		return newFloatOrderDimension[float32](dimension, b)
+	case reflect.Float64:
+		return newFloatOrderDimension[float64](dimension, b)
+	}
+	return nil, fmt.Errorf("cannot order cells of dimension %s of type %v", info.name, info.datatype)
+}
+
+func newIntegerOrderDimension[T partitionInteger](dimension *Dimension, b columnBuffers) (orderDimension, error) {
+	domain, err := domainInternal[T](dimension)
+	if err != nil {
+		return nil, err
+	}
+	extent, err := extentInternal[T](dimension)
+	if err != nil {
+		return nil, err
+	}
+	return &integerOrderDimension[T]{
+		values:   b.data.Interface().([]T),
+		domainLo: domain[0],
+		domainHi: domain[1],
+		extent:   uint64(extent),
+	}, nil
+}
+
+func newFloatOrderDimension[T float32 | float64](dimension *Dimension, b columnBuffers) (orderDimension, error) {
+	domain, err := domainInternal[T](dimension)
+	if err != nil {
+		return nil, err
+	}
+	extent, err := extentInternal[T](dimension)
+	if err != nil {
+		return nil, err
+	}
+	return &floatOrderDimension[T]{
+		values:   b.data.Interface().([]T),
+		domainLo: domain[0],
+		domainHi: domain[1],
+		extent:   float64(extent),
+	}, nil
+}
+
+func newStringOrderDimension(b columnBuffers) orderDimension {
+	data := b.data.Bytes()
+	values := make([]string, len(b.offsets))
+	for i, start := range b.offsets {
+		end := uint64(len(data))
+		if i+1 < len(b.offsets) {
+			end = b.offsets[i+1]
+		}
+		values[i] = string(data[start:end])
+	}
+	return &stringOrderDimension{values: values}
+}
+
+// globalOrder compares cells in the global order of an array schema.
+type globalOrder struct {
+	dims     []orderDimension
+	tileDims []int      // the dimension indexes in tile order
+	cellDims []int      // the dimension indexes in cell order
+	tiles    [][]uint64 // the tile indexes of the cells, by dimension
+	hilbert  []uint64   // the Hilbert values of the cells, for Hilbert cell order
+}
+
+// newGlobalOrder returns the global order of n cells with the coordinates dims, for the given
+// cell and tile orders. Sparse arrays with Hilbert cell order sort cells by Hilbert value, then by
+// row-major coordinates; other arrays sort them by space tile, in tile order, then in cell order.
+func newGlobalOrder(cellOrder, tileOrder Layout, dims []orderDimension, n int) *globalOrder {
+	o := &globalOrder{dims: dims}
+	rowMajor := make([]int, len(dims))
+	colMajor := make([]int, len(dims))
+	for d := range dims {
+		rowMajor[d] = d
+		colMajor[d] = len(dims) - 1 - d
+	}
+
+	if cellOrder == TILEDB_HILBERT {
+		o.cellDims = rowMajor
+		o.hilbert = hilbertValues(dims, n)
+		return o
+	}
+
+	o.tileDims, o.cellDims = rowMajor, rowMajor
+	if tileOrder == TILEDB_COL_MAJOR {
+		o.tileDims = colMajor
+	}
+	if cellOrder == TILEDB_COL_MAJOR {
+		o.cellDims = colMajor
+	}
+	o.tiles = make([][]uint64, len(dims))
+	for d, dim := range dims {
+		o.tiles[d] = make([]uint64, n)
+		for i := range n {
+			o.tiles[d][i] = dim.tile(i)
+		}
+	}
+	return o
+}
+
+// compare compares cells i and j in global order.
+func (o *globalOrder) compare(i, j int) int {
+	if o.hilbert != nil {
+		if c := cmp.Compare(o.hilbert[i], o.hilbert[j]); c != 0 {
+			return c
+		}
+	}
+	if o.tiles != nil {
+		for _, d := range o.tileDims {
+			if c := cmp.Compare(o.tiles[d][i], o.tiles[d][j]); c != 0 {
+				return c
+			}
+		}
+	}
+	for _, d := range o.cellDims {
+		if c := o.dims[d].compare(i, j); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// hilbertValues returns the Hilbert values of n cells, using the number of bits per
+// dimension and the mapping of coordinates to buckets of TileDB.
+func hilbertValues(dims []orderDimension, n int) []uint64 {
+	bitsPerDim := 64 / len(dims)
+	maxBucket := uint64(math.MaxUint64)
+	if bitsPerDim < 64 {
+		maxBucket = uint64(1)<<bitsPerDim - 1
+	}
+
+	values := make([]uint64, n)
+	coords := make([]uint64, len(dims))
+	for i := range n {
+		for d, dim := range dims {
+			coords[d] = dim.bucket(i, maxBucket)
+		}
+		values[i] = hilbertIndex(coords, bitsPerDim)
+	}
+	return values
+}
+
+// hilbertIndex returns the index on the Hilbert curve of coords, which have bitsPerDim bits each,
+// using Skilling's algorithm. coords is overwritten.
+func hilbertIndex(coords []uint64, bitsPerDim int) uint64 {
+	n := len(coords)
+
+	// Inverse undo excess work.
+	for q := uint64(1) << (bitsPerDim - 1); q > 1; q >>= 1 {
+		p := q - 1
+		for i := range n {
+			if coords[i]&q != 0 {
+				coords[0] ^= p
+			} else {
+				t := (coords[0] ^ coords[i]) & p
+				coords[0] ^= t
+				coords[i] ^= t
+			}
+		}
+	}
+
+	// Gray encode.
+	for i := 1; i < n; i++ {
+		coords[i] ^= coords[i-1]
+	}
+	var t uint64
+	for q := uint64(1) << (bitsPerDim - 1); q > 1; q >>= 1 {
+		if coords[n-1]&q != 0 {
+			t ^= q - 1
+		}
+	}
+	for i := range n {
+		coords[i] ^= t
+	}
+
+	// Interleave the transposed bits, most significant first.
+	var index uint64
+	for b := bitsPerDim - 1; b >= 0; b-- {
+		for i := range n {
+			index = index<<1 | (coords[i]>>b)&1
+		}
+	}
+	return index
+}
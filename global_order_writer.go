@@ -0,0 +1,700 @@
+package tiledb
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/TileDB-Inc/TileDB-Go/bytesizes"
+)
+
+// defaultWriterMemoryBudget is the default number of bytes buffered by a GlobalOrderWriter before it submits them.
+const defaultWriterMemoryBudget = 64 * 1024 * 1024
+
+// GlobalOrderWriterOption configures a GlobalOrderWriter.
+type GlobalOrderWriterOption func(w *GlobalOrderWriter)
+
+// WithWriterMemoryBudget sets the number of bytes of cells buffered before they are sorted and submitted.
+// It defaults to 64MiB. A single batch larger than the budget is submitted on its own.
+func WithWriterMemoryBudget(n uint64) GlobalOrderWriterOption {
+	return func(w *GlobalOrderWriter) {
+		w.budget = n
+	}
+}
+
+// WithWriterSubarray sets the subarray written to a dense array. It must have a single range per
+// dimension, aligned to the space tiles. It defaults to the whole domain.
+func WithWriterSubarray(subarray *Subarray) GlobalOrderWriterOption {
+	return func(w *GlobalOrderWriter) {
+		w.subarray = subarray
+	}
+}
+
+// ColumnData holds the cells of an attribute or dimension written with GlobalOrderWriter.WriteColumns,
+// in the same form as query buffers.
+type ColumnData struct {
+	// Data is a slice of the Go type of the field's Datatype.
+	Data any
+	// Offsets are the offsets in bytes of the cells in Data, for var-sized fields.
+	Offsets []uint64
+	// Validity holds one value per cell for nullable fields, 0 for null cells.
+	Validity []uint8
+}
+
+/*
+GlobalOrderWriter writes cells to an array with a single TILEDB_GLOBAL_ORDER write query.
+
+Batches of cells are buffered up to a memory budget, then sorted into the global order of the
+array schema (by space tile and cell order, or by Hilbert value) and submitted. Close submits
+the remaining cells and finalizes the query. The array must be open for writing.
+
+Each submission must follow the cells already submitted in global order, so the batches should
+arrive roughly in global order, for example one space tile after the other; a batch containing
+cells preceding cells already submitted is rejected with an error. For dense arrays, the batches
+must include the coordinates of their cells, which are only used for ordering, and each
+submission must continue exactly where the previous one stopped in the global order of the
+subarray. A rejected batch is not buffered, so the writer can still be used afterwards.
+
+	w, err := tiledb.NewGlobalOrderWriter(array)
+	if err != nil {
+		return err
+	}
+	for batch := range batches {
+		if err := w.WriteColumns(batch); err != nil {
+			w.Close()
+			return err
+		}
+	}
+	return w.Close()
+*/
+type GlobalOrderWriter struct {
+	array      *Array
+	schema     *ArraySchema
+	query      *Query
+	arrayType  ArrayType
+	cellOrder  Layout
+	tileOrder  Layout
+	fields     map[string]fieldInfo
+	dims       []fieldInfo
+	dimensions []*Dimension
+	budget     uint64
+	subarray   *Subarray
+
+	batchFields   []string
+	buffered      map[string]columnBuffers
+	bufferedBytes uint64
+	bufferedCells int
+	submitted     bool
+	closed        bool
+
+	// bufferedPositions holds the sorted positions in global order of the cells buffered for a
+	// dense array, to reject batches duplicating them.
+	bufferedPositions []uint64
+
+	// lastCell holds the coordinates of the last cell submitted to a sparse array.
+	lastCell map[string]columnBuffers
+
+	// The state of dense writes: the start of the subarray, the number of tiles and the tile extent per
+	// dimension, and the position in global order of the next cell.
+	denseOrigins []any
+	denseTiles   []uint64
+	denseExtents []uint64
+	nextPosition uint64
+}
+
+// NewGlobalOrderWriter returns a GlobalOrderWriter writing to array.
+func NewGlobalOrderWriter(array *Array, opts ...GlobalOrderWriterOption) (*GlobalOrderWriter, error) {
+	w := &GlobalOrderWriter{array: array, budget: defaultWriterMemoryBudget}
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	if err := w.init(); err != nil {
+		w.free()
+		return nil, fmt.Errorf("error creating global order writer: %w", err)
+	}
+	return w, nil
+}
+
+func (w *GlobalOrderWriter) init() error {
+	queryType, err := w.array.QueryType()
+	if err != nil {
+		return err
+	}
+	if queryType != TILEDB_WRITE {
+		return errors.New("the array is not open for writing")
+	}
+
+	if w.schema, err = w.array.Schema(); err != nil {
+		return err
+	}
+	if w.arrayType, err = w.schema.Type(); err != nil {
+		return err
+	}
+	if w.cellOrder, err = w.schema.CellOrder(); err != nil {
+		return err
+	}
+	if w.tileOrder, err = w.schema.TileOrder(); err != nil {
+		return err
+	}
+
+	names, err := schemaFieldNames(w.schema)
+	if err != nil {
+		return err
+	}
+	w.fields = make(map[string]fieldInfo, len(names))
+	for _, name := range names {
+		info, err := schemaFieldInfo(w.schema, name)
+		if err != nil {
+			return err
+		}
+		w.fields[name] = info
+		if info.isDimension {
+			w.dims = append(w.dims, info)
+		}
+	}
+
+	domain, err := w.schema.Domain()
+	if err != nil {
+		return err
+	}
+	defer domain.Free()
+	for _, info := range w.dims {
+		dimension, err := domain.DimensionFromName(info.name)
+		if err != nil {
+			return err
+		}
+		w.dimensions = append(w.dimensions, dimension)
+	}
+
+	if w.query, err = NewQuery(w.array.context, w.array); err != nil {
+		return err
+	}
+	if err := w.query.SetLayout(TILEDB_GLOBAL_ORDER); err != nil {
+		return err
+	}
+	if w.arrayType == TILEDB_DENSE {
+		return w.initDense()
+	}
+	return nil
+}
+
+// initDense sets the subarray of the query and checks that it is aligned to the space tiles.
+func (w *GlobalOrderWriter) initDense() error {
+	subarray := w.subarray
+	if subarray == nil {
+		var err error
+		if subarray, err = w.array.NewSubarray(); err != nil {
+			return err
+		}
+		defer subarray.Free()
+	}
+	if err := w.query.SetSubarray(subarray); err != nil {
+		return err
+	}
+
+	for d, info := range w.dims {
+		rangeNum, err := subarray.GetRangeNum(uint32(d))
+		if err != nil {
+			return err
+		}
+		if rangeNum != 1 {
+			return fmt.Errorf("dimension %s has %d ranges, a single range is supported", info.name, rangeNum)
+		}
+		rng, err := subarray.GetRange(uint32(d), 0)
+		if err != nil {
+			return err
+		}
+
+		// The bounds of the range are located like cells to check the alignment.
+		start, end := rng.Endpoints()
+		bounds := reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(start)), 2, 2)
+		bounds.Index(0).Set(reflect.ValueOf(start))
+		bounds.Index(1).Set(reflect.ValueOf(end))
+		dim, err := w.denseDimension(d, columnBuffers{data: bounds})
+		if err != nil {
+			return err
+		}
+		extent := dim.tileExtent()
+		length := dim.offset(1, start) + 1
+		if dim.domainOffset(0)%extent != 0 || length%extent != 0 {
+			return fmt.Errorf("the subarray is not aligned to the space tiles of dimension %s", info.name)
+		}
+		w.denseOrigins = append(w.denseOrigins, start)
+		w.denseTiles = append(w.denseTiles, length/extent)
+		w.denseExtents = append(w.denseExtents, extent)
+	}
+	return nil
+}
+
+// denseDimension returns the denseOrderDimension of the coordinates b of dimension d.
+func (w *GlobalOrderWriter) denseDimension(d int, b columnBuffers) (denseOrderDimension, error) {
+	dim, err := newOrderDimension(w.dimensions[d], w.dims[d], b)
+	if err != nil {
+		return nil, err
+	}
+	dense, ok := dim.(denseOrderDimension)
+	if !ok {
+		return nil, fmt.Errorf("dimension %s of type %v is not supported in dense arrays", w.dims[d].name, w.dims[d].datatype)
+	}
+	return dense, nil
+}
+
+// WriteColumns buffers a batch of cells, given by attribute and dimension name. Every batch must
+// contain the same fields, including all dimensions, and the same number of cells for each field.
+// The cells are submitted when the buffered cells exceed the memory budget.
+func (w *GlobalOrderWriter) WriteColumns(columns map[string]ColumnData) error {
+	batch := make(map[string]columnBuffers, len(columns))
+	cells := -1
+	for name, column := range columns {
+		info, ok := w.fields[name]
+		if !ok {
+			return errorf(ErrNotFound, "error writing columns: %s is not an attribute or dimension", name)
+		}
+		b, n, err := newColumnBuffers(info, column)
+		if err != nil {
+			return fmt.Errorf("error writing column %s: %w", name, err)
+		}
+		if cells >= 0 && n != cells {
+			return fmt.Errorf("error writing columns: column %s has %d cells, expected %d", name, n, cells)
+		}
+		cells = n
+		batch[name] = b
+	}
+	return w.write(batch, max(cells, 0))
+}
+
+/*
+GlobalOrderWriteStructs buffers rows in w. Fields are mapped like in WriteStructs, and T must
+have a field for every dimension.
+*/
+func GlobalOrderWriteStructs[T any](w *GlobalOrderWriter, rows []T) error {
+	columns, err := newStructColumns(w.schema, genericType[T]())
+	if err != nil {
+		return err
+	}
+
+	rowsValue := reflect.ValueOf(rows)
+	batch := make(map[string]columnBuffers, len(columns))
+	for _, column := range columns {
+		batch[column.name] = column.buffers(rowsValue)
+	}
+	return w.write(batch, len(rows))
+}
+
+// newColumnBuffers validates column against info and returns its buffers and number of cells.
+func newColumnBuffers(info fieldInfo, column ColumnData) (columnBuffers, int, error) {
+	elemType := info.datatype.ReflectType()
+	data := reflect.ValueOf(column.Data)
+	if data.Kind() != reflect.Slice || elemType == nil || data.Type().Elem().Kind() != elemType.Kind() {
+		return columnBuffers{}, 0, errorf(ErrSchemaMismatch, "data of %v field must be a slice of %v, got %T", info.datatype, elemType, column.Data)
+	}
+	b := columnBuffers{data: reflect.MakeSlice(reflect.SliceOf(elemType), 0, data.Len())}
+	b.data = appendConverted(b.data, data)
+
+	var n int
+	switch {
+	case info.isVar():
+		if column.Offsets == nil {
+			return columnBuffers{}, 0, errors.New("offsets are required for var-sized fields")
+		}
+		b.offsets = column.Offsets
+		n = len(column.Offsets)
+	case column.Offsets != nil:
+		return columnBuffers{}, 0, errors.New("offsets are only allowed for var-sized fields")
+	default:
+		if data.Len()%int(info.cellValNum) != 0 {
+			return columnBuffers{}, 0, fmt.Errorf("data length %d is not a multiple of %d values per cell", data.Len(), info.cellValNum)
+		}
+		n = data.Len() / int(info.cellValNum)
+	}
+
+	switch {
+	case info.nullable:
+		if len(column.Validity) != n {
+			return columnBuffers{}, 0, fmt.Errorf("nullable field requires %d validity values, got %d", n, len(column.Validity))
+		}
+		b.validity = column.Validity
+	case column.Validity != nil:
+		return columnBuffers{}, 0, errors.New("validity values are only allowed for nullable fields")
+	}
+	return b, n, nil
+}
+
+// appendConverted appends the values of src to the slice dst, converting them to its element type.
+func appendConverted(dst, src reflect.Value) reflect.Value {
+	if src.Type() == dst.Type() {
+		return reflect.AppendSlice(dst, src)
+	}
+	for i := 0; i < src.Len(); i++ {
+		dst = reflect.Append(dst, src.Index(i).Convert(dst.Type().Elem()))
+	}
+	return dst
+}
+
+// write buffers a batch of n cells and submits the buffered cells if they exceed the budget.
+func (w *GlobalOrderWriter) write(batch map[string]columnBuffers, n int) error {
+	if w.closed {
+		return errors.New("error writing cells: the writer is closed")
+	}
+	if n == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(batch))
+	for name := range batch {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	if w.batchFields == nil {
+		for _, info := range w.dims {
+			if _, ok := batch[info.name]; !ok {
+				return fmt.Errorf("error writing cells: dimension %s is missing", info.name)
+			}
+		}
+		w.batchFields = names
+	} else if !slices.Equal(names, w.batchFields) {
+		return fmt.Errorf("error writing cells: the batch has fields %s, expected %s",
+			strings.Join(names, ", "), strings.Join(w.batchFields, ", "))
+	}
+
+	var positions []uint64
+	var err error
+	if w.arrayType == TILEDB_DENSE {
+		positions, err = w.checkDenseBatch(batch, n)
+	} else {
+		err = w.checkSparseBatch(batch, n)
+	}
+	if err != nil {
+		return fmt.Errorf("error writing cells: %w", err)
+	}
+
+	// The batch is appended to new buffers so that it can be removed if it cannot be submitted.
+	prevBuffered, prevBytes, prevCells, prevPositions := w.buffered, w.bufferedBytes, w.bufferedCells, w.bufferedPositions
+	buffered := make(map[string]columnBuffers, len(batch))
+	for name, b := range batch {
+		info := w.fields[name]
+		buffered[name] = appendColumnBuffers(w.buffered[name], b, info)
+		w.bufferedBytes += uint64(b.data.Len())*info.datatype.Size() +
+			uint64(len(b.offsets))*bytesizes.Uint64 + uint64(len(b.validity))
+	}
+	w.buffered = buffered
+	w.bufferedCells += n
+	w.bufferedPositions = positions
+
+	if w.bufferedBytes >= w.budget {
+		if err := w.Flush(); err != nil {
+			w.buffered, w.bufferedBytes, w.bufferedCells, w.bufferedPositions = prevBuffered, prevBytes, prevCells, prevPositions
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSparseBatch checks that the n cells of batch follow the last cell submitted in global order.
+func (w *GlobalOrderWriter) checkSparseBatch(batch map[string]columnBuffers, n int) error {
+	if w.lastCell == nil {
+		return nil
+	}
+
+	// The last cell submitted is ordered along with the cells of the batch, at index 0.
+	dims := make([]orderDimension, len(w.dims))
+	for d, info := range w.dims {
+		dim, err := newOrderDimension(w.dimensions[d], info, appendColumnBuffers(w.lastCell[info.name], batch[info.name], info))
+		if err != nil {
+			return err
+		}
+		dims[d] = dim
+	}
+	order := newGlobalOrder(w.cellOrder, w.tileOrder, dims, n+1)
+	for i := 1; i <= n; i++ {
+		if order.compare(0, i) > 0 {
+			return fmt.Errorf("cells are out of global order: the cell %s precedes cells already submitted",
+				w.describeCell(batch, i-1))
+		}
+	}
+	return nil
+}
+
+// checkDenseBatch checks that the n cells of batch are in the subarray and neither precede the
+// cells already submitted nor duplicate buffered cells. It returns the sorted positions in global
+// order of the buffered cells and the cells of the batch.
+func (w *GlobalOrderWriter) checkDenseBatch(batch map[string]columnBuffers, n int) ([]uint64, error) {
+	positions, err := w.densePositions(batch, n)
+	if err != nil {
+		return nil, err
+	}
+	for i, position := range positions {
+		if position < w.nextPosition {
+			return nil, fmt.Errorf("cells are out of global order: the cell %s precedes cells already submitted",
+				w.describeCell(batch, i))
+		}
+	}
+
+	merged := append(slices.Clone(w.bufferedPositions), positions...)
+	slices.Sort(merged)
+	for k := 1; k < len(merged); k++ {
+		if merged[k] == merged[k-1] {
+			i := slices.Index(positions, merged[k])
+			return nil, fmt.Errorf("the cell %s is duplicated", w.describeCell(batch, i))
+		}
+	}
+	return merged, nil
+}
+
+// appendColumnBuffers appends the cells of src to dst.
+func appendColumnBuffers(dst, src columnBuffers, info fieldInfo) columnBuffers {
+	if !dst.data.IsValid() {
+		dst.data = reflect.MakeSlice(src.data.Type(), 0, src.data.Len())
+		if info.isVar() {
+			dst.offsets = []uint64{}
+		}
+		if info.nullable {
+			dst.validity = []uint8{}
+		}
+	}
+	dataBytes := uint64(dst.data.Len()) * info.datatype.Size()
+	for _, offset := range src.offsets {
+		dst.offsets = append(dst.offsets, dataBytes+offset)
+	}
+	dst.validity = append(dst.validity, src.validity...)
+	dst.data = reflect.AppendSlice(dst.data, src.data)
+	return dst
+}
+
+// Flush sorts the buffered cells into global order and submits them.
+func (w *GlobalOrderWriter) Flush() error {
+	if w.bufferedCells == 0 {
+		return nil
+	}
+
+	var perm []int
+	var err error
+	if w.arrayType == TILEDB_DENSE {
+		perm, err = w.denseOrder()
+	} else {
+		perm, err = w.sparseOrder()
+	}
+	if err != nil {
+		return fmt.Errorf("error flushing cells: %w", err)
+	}
+
+	buffers := make(map[string]columnBuffers, len(w.buffered))
+	for name, b := range w.buffered {
+		info := w.fields[name]
+		if info.isDimension && w.arrayType == TILEDB_DENSE {
+			continue
+		}
+		buffers[name] = permuteColumnBuffers(b, info, perm)
+	}
+	for name, b := range buffers {
+		if err := b.set(w.query, name); err != nil {
+			return fmt.Errorf("error setting buffers for %s: %w", name, err)
+		}
+	}
+	if err := w.query.Submit(); err != nil {
+		return err
+	}
+	w.submitted = true
+
+	if w.arrayType == TILEDB_DENSE {
+		w.nextPosition += uint64(len(perm))
+	} else {
+		w.lastCell = make(map[string]columnBuffers, len(w.dims))
+		for _, info := range w.dims {
+			w.lastCell[info.name] = permuteColumnBuffers(buffers[info.name], info, []int{len(perm) - 1})
+		}
+	}
+
+	w.buffered = nil
+	w.bufferedBytes = 0
+	w.bufferedCells = 0
+	w.bufferedPositions = nil
+	return nil
+}
+
+// sparseOrder returns the permutation sorting the buffered cells in global order, and checks
+// that they follow the last cell submitted.
+func (w *GlobalOrderWriter) sparseOrder() ([]int, error) {
+	// The last cell submitted is ordered along with the buffered cells, at index 0.
+	offset := 0
+	dims := make([]orderDimension, len(w.dims))
+	for d, info := range w.dims {
+		b := w.buffered[info.name]
+		if w.lastCell != nil {
+			b = appendColumnBuffers(w.lastCell[info.name], b, info)
+			offset = 1
+		}
+		dim, err := newOrderDimension(w.dimensions[d], info, b)
+		if err != nil {
+			return nil, err
+		}
+		dims[d] = dim
+	}
+
+	order := newGlobalOrder(w.cellOrder, w.tileOrder, dims, w.bufferedCells+offset)
+	perm := make([]int, w.bufferedCells)
+	for i := range perm {
+		perm[i] = i + offset
+	}
+	slices.SortStableFunc(perm, order.compare)
+
+	if offset == 1 && order.compare(0, perm[0]) > 0 {
+		return nil, fmt.Errorf("cells are out of global order: the cell %s precedes cells already submitted",
+			w.describeCell(w.buffered, perm[0]-offset))
+	}
+	for i := range perm {
+		perm[i] -= offset
+	}
+	return perm, nil
+}
+
+// denseOrder returns the permutation sorting the buffered cells in global order, and checks
+// that they continue the cells already submitted without gaps.
+func (w *GlobalOrderWriter) denseOrder() ([]int, error) {
+	positions, err := w.densePositions(w.buffered, w.bufferedCells)
+	if err != nil {
+		return nil, err
+	}
+
+	perm := make([]int, w.bufferedCells)
+	for i := range perm {
+		perm[i] = i
+	}
+	slices.SortFunc(perm, func(i, j int) int {
+		return cmp.Compare(positions[i], positions[j])
+	})
+
+	for k, i := range perm {
+		switch expected := w.nextPosition + uint64(k); {
+		case positions[i] < expected:
+			return nil, fmt.Errorf("cells are out of global order: the cell %s precedes cells already submitted or is duplicated",
+				w.describeCell(w.buffered, i))
+		case positions[i] > expected:
+			return nil, fmt.Errorf("cells are missing in global order before the cell %s; dense writes must be contiguous",
+				w.describeCell(w.buffered, i))
+		}
+	}
+	return perm, nil
+}
+
+// densePositions returns the positions in the global order of the subarray of the n cells of
+// buffers.
+func (w *GlobalOrderWriter) densePositions(buffers map[string]columnBuffers, n int) ([]uint64, error) {
+	dims := make([]denseOrderDimension, len(w.dims))
+	for d, info := range w.dims {
+		dim, err := w.denseDimension(d, buffers[info.name])
+		if err != nil {
+			return nil, err
+		}
+		dims[d] = dim
+	}
+
+	tileDims, cellDims := make([]int, len(w.dims)), make([]int, len(w.dims))
+	for d := range w.dims {
+		tileDims[d], cellDims[d] = d, d
+		if w.tileOrder == TILEDB_COL_MAJOR {
+			tileDims[d] = len(w.dims) - 1 - d
+		}
+		if w.cellOrder == TILEDB_COL_MAJOR {
+			cellDims[d] = len(w.dims) - 1 - d
+		}
+	}
+	cellsPerTile := uint64(1)
+	for _, extent := range w.denseExtents {
+		cellsPerTile *= extent
+	}
+
+	positions := make([]uint64, n)
+	for i := range positions {
+		var tile, cell uint64
+		for _, d := range tileDims {
+			offset := dims[d].offset(i, w.denseOrigins[d])
+			if offset >= w.denseTiles[d]*w.denseExtents[d] {
+				return nil, fmt.Errorf("the cell %s is outside of the subarray", w.describeCell(buffers, i))
+			}
+			tile = tile*w.denseTiles[d] + offset/w.denseExtents[d]
+		}
+		for _, d := range cellDims {
+			cell = cell*w.denseExtents[d] + dims[d].offset(i, w.denseOrigins[d])%w.denseExtents[d]
+		}
+		positions[i] = tile*cellsPerTile + cell
+	}
+	return positions, nil
+}
+
+// describeCell formats the coordinates of cell i of buffers.
+func (w *GlobalOrderWriter) describeCell(buffers map[string]columnBuffers, i int) string {
+	coords := make([]string, len(w.dims))
+	for d, info := range w.dims {
+		b := permuteColumnBuffers(buffers[info.name], info, []int{i})
+		if info.isVar() {
+			coords[d] = fmt.Sprintf("%q", b.data.Bytes())
+		} else {
+			coords[d] = fmt.Sprint(b.data.Index(0).Interface())
+		}
+	}
+	return "(" + strings.Join(coords, ", ") + ")"
+}
+
+// permuteColumnBuffers returns the cells of b in the order given by perm.
+func permuteColumnBuffers(b columnBuffers, info fieldInfo, perm []int) columnBuffers {
+	out := columnBuffers{data: reflect.MakeSlice(b.data.Type(), 0, b.data.Len())}
+	if b.validity != nil {
+		out.validity = make([]uint8, len(perm))
+		for k, i := range perm {
+			out.validity[k] = b.validity[i]
+		}
+	}
+
+	if info.isVar() {
+		size := info.datatype.Size()
+		out.offsets = make([]uint64, len(perm))
+		for k, i := range perm {
+			start, end := int(b.offsets[i]/size), b.data.Len()
+			if i+1 < len(b.offsets) {
+				end = int(b.offsets[i+1] / size)
+			}
+			out.offsets[k] = uint64(out.data.Len()) * size
+			out.data = reflect.AppendSlice(out.data, b.data.Slice(start, end))
+		}
+		return out
+	}
+
+	cellValNum := int(info.cellValNum)
+	out.data = out.data.Slice(0, len(perm)*cellValNum)
+	for k, i := range perm {
+		reflect.Copy(out.data.Slice(k*cellValNum, (k+1)*cellValNum), b.data.Slice(i*cellValNum, (i+1)*cellValNum))
+	}
+	return out
+}
+
+// Close submits the buffered cells, finalizes the query and releases the resources of the writer.
+// The writer cannot be used afterwards.
+func (w *GlobalOrderWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	err := w.Flush()
+	if err == nil && w.submitted {
+		err = w.query.Finalize()
+	}
+	w.closed = true
+	w.free()
+	return err
+}
+
+func (w *GlobalOrderWriter) free() {
+	for _, dimension := range w.dimensions {
+		dimension.Free()
+	}
+	if w.query != nil {
+		w.query.Free()
+	}
+	if w.schema != nil {
+		w.schema.Free()
+	}
+}
@@ -0,0 +1,172 @@
+package tiledb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type denseGridRow struct {
+	Y uint8  `tiledb:"y,dim"`
+	X uint8  `tiledb:"x,dim"`
+	V uint16 `tiledb:"v"`
+}
+
+func TestHilbertIndex(t *testing.T) {
+	expected := [][]uint64{
+		{0, 3, 4, 5},
+		{1, 2, 7, 6},
+		{14, 13, 8, 9},
+		{15, 12, 11, 10},
+	}
+	for x := range expected {
+		for y := range expected[x] {
+			assert.Equal(t, expected[x][y], hilbertIndex([]uint64{uint64(x), uint64(y)}, 2))
+		}
+	}
+}
+
+func TestGlobalOrderWriterSparse(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	defer array.Free()
+	require.NoError(t, array.Open(TILEDB_WRITE))
+
+	w, err := NewGlobalOrderWriter(array, WithWriterMemoryBudget(1))
+	require.NoError(t, err)
+	// Each batch is submitted on its own and sorted in the space tiles of 2x2 cells.
+	require.NoError(t, GlobalOrderWriteStructs(w, []basicTestRow{
+		{Row: 2, Col: 4, A1: 24, A2: "d"},
+		{Row: 1, Col: 3, A1: 13, A2: "ab"},
+		{Row: 2, Col: 3, A1: 23},
+	}))
+	require.NoError(t, w.WriteColumns(map[string]ColumnData{
+		"rows": {Data: []int32{4, 3, 4}},
+		"cols": {Data: []int32{4, 1, 2}},
+		"a1":   {Data: []int32{44, 31, 42}},
+		"a2":   {Data: []uint8("xyzw"), Offsets: []uint64{0, 1, 3}},
+		"a3":   {Data: []int64{0, 0, 0}},
+	}))
+	require.NoError(t, w.Close())
+	require.NoError(t, array.Close())
+
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+	query, err := NewQuery(array.context, array)
+	require.NoError(t, err)
+	defer query.Free()
+	require.NoError(t, query.SetLayout(TILEDB_GLOBAL_ORDER))
+
+	rows, err := ReadStructs[basicTestRow](query)
+	require.NoError(t, err)
+	a1 := make([]int32, len(rows))
+	for i, row := range rows {
+		a1[i] = row.A1
+	}
+	assert.Equal(t, []int32{1, 2, 3, 13, 23, 24, 31, 42, 44}, a1)
+	assert.Equal(t, "ab", rows[3].A2)
+	assert.Equal(t, "yz", rows[6].A2)
+	assert.Equal(t, "x", rows[8].A2)
+}
+
+func TestGlobalOrderWriterOutOfOrder(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	defer array.Free()
+	require.NoError(t, array.Open(TILEDB_WRITE))
+	defer array.Close()
+
+	w, err := NewGlobalOrderWriter(array, WithWriterMemoryBudget(1))
+	require.NoError(t, err)
+	defer w.Close()
+
+	require.NoError(t, GlobalOrderWriteStructs(w, []basicTestRow{{Row: 3, Col: 1, A2: "a"}}))
+	err = GlobalOrderWriteStructs(w, []basicTestRow{{Row: 1, Col: 3, A2: "b"}})
+	assert.ErrorContains(t, err, "out of global order")
+
+	err = w.WriteColumns(map[string]ColumnData{"rows": {Data: []int32{4}}})
+	assert.ErrorContains(t, err, "expected")
+	err = w.WriteColumns(map[string]ColumnData{"missing": {Data: []int32{4}}})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGlobalOrderWriterAfterRejection(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	defer array.Free()
+	require.NoError(t, array.Open(TILEDB_WRITE))
+
+	w, err := NewGlobalOrderWriter(array)
+	require.NoError(t, err)
+	require.NoError(t, GlobalOrderWriteStructs(w, []basicTestRow{{Row: 3, Col: 1, A1: 31, A2: "a"}}))
+	require.NoError(t, w.Flush())
+	// The rejected batch is not buffered, so the following batches are still submitted.
+	err = GlobalOrderWriteStructs(w, []basicTestRow{{Row: 4, Col: 4, A1: 44, A2: "b"}, {Row: 1, Col: 3, A1: 13, A2: "c"}})
+	assert.ErrorContains(t, err, "out of global order")
+	require.NoError(t, GlobalOrderWriteStructs(w, []basicTestRow{{Row: 4, Col: 4, A1: 44, A2: "b"}}))
+	require.NoError(t, GlobalOrderWriteStructs(w, []basicTestRow{{Row: 3, Col: 2, A1: 32, A2: "d"}}))
+	require.NoError(t, w.Close())
+	require.NoError(t, array.Close())
+
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+	query, err := NewQuery(array.context, array)
+	require.NoError(t, err)
+	defer query.Free()
+	require.NoError(t, query.SetLayout(TILEDB_GLOBAL_ORDER))
+	rows, err := ReadStructs[basicTestRow](query)
+	require.NoError(t, err)
+	a1 := make([]int32, len(rows))
+	for i, row := range rows {
+		a1[i] = row.A1
+	}
+	assert.Equal(t, []int32{1, 2, 3, 31, 32, 44}, a1)
+}
+
+func TestGlobalOrderWriterDense(t *testing.T) {
+	arrPath := createDenseIntegerGrid(t, 8)
+	arr := openArray(t, arrPath, TILEDB_WRITE)
+
+	cell := func(y, x uint8) denseGridRow {
+		return denseGridRow{Y: y, X: x, V: uint16(y)*8 + uint16(x)}
+	}
+	var first, rest []denseGridRow
+	for y := range uint8(8) {
+		for x := range uint8(8) {
+			if y < 4 && x < 4 {
+				first = append(first, cell(y, x))
+			} else {
+				rest = append([]denseGridRow{cell(y, x)}, rest...)
+			}
+		}
+	}
+
+	w, err := NewGlobalOrderWriter(arr, WithWriterMemoryBudget(1))
+	require.NoError(t, err)
+	// The cells of the second tile cannot be submitted before the first one, but the rejected
+	// batch leaves the writer usable.
+	err = GlobalOrderWriteStructs(w, rest[:1])
+	assert.ErrorContains(t, err, "missing")
+	require.NoError(t, GlobalOrderWriteStructs(w, first))
+	err = GlobalOrderWriteStructs(w, first[:1])
+	assert.ErrorContains(t, err, "out of global order")
+	require.NoError(t, GlobalOrderWriteStructs(w, rest))
+	require.NoError(t, w.Close())
+
+	arr = openArray(t, arrPath, TILEDB_READ)
+	q, err := NewQuery(arr.context, arr)
+	require.NoError(t, err)
+	defer q.Free()
+	require.NoError(t, q.SetLayout(TILEDB_ROW_MAJOR))
+	sa, err := arr.NewSubarray()
+	require.NoError(t, err)
+	require.NoError(t, q.SetSubarray(sa))
+	values := make([]uint16, 64)
+	_, err = q.SetDataBuffer("v", values)
+	require.NoError(t, err)
+	require.NoError(t, q.Submit())
+	for i, v := range values {
+		assert.EqualValues(t, i, v)
+	}
+}
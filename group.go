@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"time"
 	"unsafe"
 )
 
@@ -52,7 +53,7 @@ func CreateGroup(tdbCtx *Context, uri string) error {
 	ret := C.tiledb_group_create(tdbCtx.tiledbContext.Get(), curi)
 	runtime.KeepAlive(tdbCtx)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error in creating group: %w", tdbCtx.LastError())
+		return fmt.Errorf("error in creating group: %w", tdbCtx.lastError("tiledb_group_create", ret))
 	}
 	return nil
 }
@@ -65,7 +66,7 @@ func NewGroup(tdbCtx *Context, uri string) (*Group, error) {
 	ret := C.tiledb_group_alloc(tdbCtx.tiledbContext.Get(), curi, &groupPtr)
 	runtime.KeepAlive(tdbCtx)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error creating tiledb group: %w", tdbCtx.LastError())
+		return nil, fmt.Errorf("error creating tiledb group: %w", tdbCtx.lastError("tiledb_group_alloc", ret))
 	}
 
 	return newGroupFromHandle(tdbCtx, uri, newGroupHandle(groupPtr)), nil
@@ -81,7 +82,8 @@ func (g *Group) Open(queryType QueryType) error {
 	ret := C.tiledb_group_open(g.context.tiledbContext.Get(), g.group.Get(), C.tiledb_query_type_t(queryType))
 	runtime.KeepAlive(g)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error opening tiledb group for querying: %w", g.context.LastError())
+		err := g.context.lastError("tiledb_group_open", ret)
+		return fmt.Errorf("error opening tiledb group for querying: %w", notFoundError(g.context, g.uri, TILEDB_GROUP, err))
 	}
 	return nil
 }
@@ -94,7 +96,7 @@ func (g *Group) Close() error {
 	ret := C.tiledb_group_close(g.context.tiledbContext.Get(), g.group.Get())
 	runtime.KeepAlive(g)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error closing tiledb group: %w", g.context.LastError())
+		return fmt.Errorf("error closing tiledb group: %w", g.context.lastError("tiledb_group_close", ret))
 	}
 	return nil
 }
@@ -104,7 +106,7 @@ func (g *Group) SetConfig(config *Config) error {
 	runtime.KeepAlive(g)
 	runtime.KeepAlive(config)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting config on group: %w", g.context.LastError())
+		return fmt.Errorf("error setting config on group: %w", g.context.lastError("tiledb_group_set_config", ret))
 	}
 	return nil
 }
@@ -114,7 +116,7 @@ func (g *Group) Config() (*Config, error) {
 	ret := C.tiledb_group_get_config(g.context.tiledbContext.Get(), g.group.Get(), &configPtr)
 	runtime.KeepAlive(g)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting config from query: %w", g.context.LastError())
+		return nil, fmt.Errorf("error getting config from query: %w", g.context.lastError("tiledb_group_get_config", ret))
 	}
 
 	return newConfigFromHandle(newConfigHandle(configPtr)), nil
@@ -135,7 +137,7 @@ func (g *Group) AddMember(uri, name string, isRelativeURI bool) error {
 	ret := C.tiledb_group_add_member(g.context.tiledbContext.Get(), g.group.Get(), curi, cRelative, cname)
 	runtime.KeepAlive(g)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error adding member to group: %w", g.context.LastError())
+		return fmt.Errorf("error adding member to group: %w", g.context.lastError("tiledb_group_add_member", ret))
 	}
 	return nil
 }
@@ -160,7 +162,8 @@ func (g GroupMetadata) MarshalJSON() ([]byte, error) {
 }
 
 // PutMetadata puts a metadata key-value item to an open group. The group must
-// be opened in WRITE mode, otherwise the function will error out.
+// be opened in WRITE mode, otherwise the function will error out. Times are
+// written as TILEDB_DATETIME_NS timestamps.
 func (g *Group) PutMetadata(key string, value interface{}) error {
 	switch value := value.(type) {
 	case int:
@@ -215,6 +218,18 @@ func (g *Group) PutMetadata(key string, value interface{}) error {
 		return groupPutScalarMetadata(g, TILEDB_BOOL, key, value)
 	case []bool:
 		return groupPutSliceMetadata(g, TILEDB_BOOL, key, value)
+	case time.Time:
+		timestamp, err := TimestampFromTime(TILEDB_DATETIME_NS, value)
+		if err != nil {
+			return fmt.Errorf("can't write %q metadata: %w", key, err)
+		}
+		return groupPutScalarMetadata(g, TILEDB_DATETIME_NS, key, timestamp)
+	case []time.Time:
+		timestamps, err := TimestampsFromTimes(TILEDB_DATETIME_NS, value)
+		if err != nil {
+			return fmt.Errorf("can't write %q metadata: %w", key, err)
+		}
+		return groupPutSliceMetadata(g, TILEDB_DATETIME_NS, key, timestamps)
 	case string:
 		valPtr := unsafe.Pointer(C.CString(value))
 		defer C.free(valPtr)
@@ -247,7 +262,7 @@ func groupPutMetadata(g *Group, dt Datatype, key string, valuePtr unsafe.Pointer
 	)
 	runtime.KeepAlive(g)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("could not add metadata to group: %w", g.context.LastError())
+		return fmt.Errorf("could not add metadata to group: %w", g.context.lastError("tiledb_group_put_metadata", ret))
 	}
 	return nil
 }
@@ -258,7 +273,7 @@ func (g *Group) RemoveMember(uri string) error {
 	ret := C.tiledb_group_remove_member(g.context.tiledbContext.Get(), g.group.Get(), curi)
 	runtime.KeepAlive(g)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error removing member from group: %w", g.context.LastError())
+		return fmt.Errorf("error removing member from group: %w", g.context.lastError("tiledb_group_remove_member", ret))
 	}
 	return nil
 }
@@ -268,7 +283,7 @@ func (g *Group) GetMemberCount() (uint64, error) {
 	ret := C.tiledb_group_get_member_count(g.context.tiledbContext.Get(), g.group.Get(), &count)
 	runtime.KeepAlive(g)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error retrieving member count in group: %w", g.context.LastError())
+		return 0, fmt.Errorf("error retrieving member count in group: %w", g.context.lastError("tiledb_group_get_member_count", ret))
 	}
 	return uint64(count), nil
 }
@@ -282,7 +297,7 @@ func (g *Group) GetMemberFromIndex(index uint64) (string, string, ObjectTypeEnum
 	ret := C.tiledb_group_get_member_by_index_v2(g.context.tiledbContext.Get(), g.group.Get(), C.uint64_t(index), &curi, &objectTypeEnum, &cname)
 	runtime.KeepAlive(g)
 	if ret != C.TILEDB_OK {
-		return "", "", TILEDB_INVALID, fmt.Errorf("error getting member by index for group: %w", g.context.LastError())
+		return "", "", TILEDB_INVALID, fmt.Errorf("error getting member by index for group: %w", g.context.lastError("tiledb_group_get_member_by_index_v2", ret))
 	}
 	defer C.tiledb_string_free(&curi)
 
@@ -315,7 +330,7 @@ func (g *Group) GetMemberByName(name string) (string, string, ObjectTypeEnum, er
 	ret := C.tiledb_group_get_member_by_name_v2(g.context.tiledbContext.Get(), g.group.Get(), cname, &curi, &objectTypeEnum)
 	runtime.KeepAlive(g)
 	if ret != C.TILEDB_OK {
-		return "", "", TILEDB_INVALID, fmt.Errorf("error getting member by index for group: %w", g.context.LastError())
+		return "", "", TILEDB_INVALID, fmt.Errorf("error getting member by index for group: %w", g.context.lastError("tiledb_group_get_member_by_name_v2", ret))
 	}
 	defer C.tiledb_string_free(&curi)
 
@@ -341,12 +356,12 @@ func (g *Group) GetMetadata(key string) (Datatype, uint, interface{}, error) {
 
 	ret := C.tiledb_group_get_metadata(g.context.tiledbContext.Get(), g.group.Get(), ckey, &cType, &cValueNum, &cvalue)
 	if ret != C.TILEDB_OK {
-		return 0, 0, nil, fmt.Errorf("error getting metadata from group: %w, key: %s", g.context.LastError(), key)
+		return 0, 0, nil, fmt.Errorf("error getting metadata from group: %w, key: %s", g.context.lastError("tiledb_group_get_metadata", ret), key)
 	}
 
 	valueNum := uint(cValueNum)
 	if valueNum == 0 {
-		return 0, 0, nil, fmt.Errorf("error getting metadata from group, key: %s does not exist", key)
+		return 0, 0, nil, errorf(ErrNotFound, "error getting metadata from group, key: %s does not exist", key)
 	}
 
 	datatype := Datatype(cType)
@@ -395,7 +410,7 @@ func (g *Group) DeleteMetadata(key string) error {
 	ret := C.tiledb_group_delete_metadata(g.context.tiledbContext.Get(), g.group.Get(), ckey)
 	runtime.KeepAlive(g)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error deleting metadata from group: %w", g.context.LastError())
+		return fmt.Errorf("error deleting metadata from group: %w", g.context.lastError("tiledb_group_delete_metadata", ret))
 	}
 	return nil
 }
@@ -406,7 +421,7 @@ func (g *Group) GetMetadataNum() (uint64, error) {
 	ret := C.tiledb_group_get_metadata_num(g.context.tiledbContext.Get(), g.group.Get(), &cNum)
 	runtime.KeepAlive(g)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting number of metadata from group: %w", g.context.LastError())
+		return 0, fmt.Errorf("error getting number of metadata from group: %w", g.context.lastError("tiledb_group_get_metadata_num", ret))
 	}
 
 	return uint64(cNum), nil
@@ -428,12 +443,12 @@ func (g *Group) GetMetadataFromIndexWithValueLimit(index uint64, limit *uint) (*
 	ret := C.tiledb_group_get_metadata_from_index(g.context.tiledbContext.Get(),
 		g.group.Get(), cIndex, &cKey, &cKeyLen, &cType, &cValueNum, &cvalue)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting metadata from group: %s, index: %d", g.context.LastError(), index)
+		return nil, fmt.Errorf("error getting metadata from group, index: %d: %w", index, g.context.lastError("tiledb_group_get_metadata_from_index", ret))
 	}
 
 	valueNum := uint(cValueNum)
 	if valueNum == 0 {
-		return nil, fmt.Errorf("error getting metadata from group, Index: %d does not exist", index)
+		return nil, errorf(ErrNotFound, "error getting metadata from group, Index: %d does not exist", index)
 	}
 
 	datatype := Datatype(cType)
@@ -476,13 +491,13 @@ func (g *Group) Dump(recurse bool) (string, error) {
 	ret := C.tiledb_group_dump_str_v2(g.context.tiledbContext.Get(), g.group.Get(), &tdbString, cRecurse)
 	runtime.KeepAlive(g)
 	if ret != C.TILEDB_OK {
-		return "", fmt.Errorf("error dumping group contents: %w", g.context.LastError())
+		return "", fmt.Errorf("error dumping group contents: %w", g.context.lastError("tiledb_group_dump_str_v2", ret))
 	}
 	defer C.tiledb_string_free(&tdbString)
 
 	dumpStr, err := stringHandleToString(tdbString)
 	if err != nil {
-		return "", fmt.Errorf("error dumping group contents: %w", g.context.LastError())
+		return "", fmt.Errorf("error dumping group contents: %w", err)
 	}
 
 	return dumpStr, nil
@@ -497,7 +512,7 @@ func (g *Group) GetIsRelativeURIByName(name string) (bool, error) {
 	ret := C.tiledb_group_get_is_relative_uri_by_name(g.context.tiledbContext.Get(), g.group.Get(), cName, &isRelative)
 	runtime.KeepAlive(g)
 	if ret != C.TILEDB_OK {
-		return false, fmt.Errorf("error getting if member %s has a relative uri: %w", name, g.context.LastError())
+		return false, fmt.Errorf("error getting if member %s has a relative uri: %w", name, g.context.lastError("tiledb_group_get_is_relative_uri_by_name", ret))
 	}
 	return isRelative > 0, nil
 }
@@ -517,7 +532,7 @@ func (g *Group) Delete(recursive bool) error {
 	ret := C.tiledb_group_delete_group(g.context.tiledbContext.Get(), g.group.Get(), curi, cRecursive)
 	runtime.KeepAlive(g)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error deleting group: %w", g.context.LastError())
+		return fmt.Errorf("error deleting group: %w", g.context.lastError("tiledb_group_delete_group", ret))
 	}
 	return nil
 }
@@ -539,7 +554,7 @@ func (g *Group) AddMemberWithType(uri, name string, isRelativeURI bool, objectTy
 	ret := C.tiledb_group_add_member_with_type(g.context.tiledbContext.Get(), g.group.Get(), curi, cRelative, cname, C.tiledb_object_t(objectType))
 	runtime.KeepAlive(g)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error adding member with type to group: %w", g.context.LastError())
+		return fmt.Errorf("error adding member with type to group: %w", g.context.lastError("tiledb_group_add_member_with_type", ret))
 	}
 	return nil
 }
@@ -551,7 +566,7 @@ func (g *Group) IsOpen() (bool, error) {
 	ret := C.tiledb_group_is_open(g.context.tiledbContext.Get(), g.group.Get(), &isOpen)
 	runtime.KeepAlive(g)
 	if ret != C.TILEDB_OK {
-		return false, fmt.Errorf("error checking if group is open: %w", g.context.LastError())
+		return false, fmt.Errorf("error checking if group is open: %w", g.context.lastError("tiledb_group_is_open", ret))
 	}
 
 	return isOpen > 0, nil
@@ -564,7 +579,7 @@ func (g *Group) QueryType() (QueryType, error) {
 	ret := C.tiledb_group_get_query_type(g.context.tiledbContext.Get(), g.group.Get(), &queryType)
 	runtime.KeepAlive(g)
 	if ret != C.TILEDB_OK {
-		return -1, fmt.Errorf("error retrieving group QueryType: %w", g.context.LastError())
+		return -1, fmt.Errorf("error retrieving group QueryType: %w", g.context.lastError("tiledb_group_get_query_type", ret))
 	}
 
 	return QueryType(queryType), nil
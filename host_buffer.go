@@ -0,0 +1,100 @@
+package tiledb
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// HostBufferType is a constraint for the element types of a HostBuffer.
+type HostBufferType interface {
+	~int8 | ~int16 | ~int32 | ~int64 | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~float32 | ~float64 | ~bool
+}
+
+/*
+HostBuffer is a buffer of n values of type T allocated with the C allocator, outside of the Go heap.
+
+Its memory is not managed by the Go garbage collector, so it does not need to be pinned when it
+is set on a query and can be handed to other cgo libraries without copies. It can be reused
+across queries, and must be released with Free once no query uses it anymore. Unlike other
+objects of this package, a HostBuffer is not freed when it is garbage collected.
+
+SetDataBuffer accepts a *HostBuffer directly and sets its memory on the query without
+copies or schema lookups; SetOffsetsHostBuffer and SetValidityHostBuffer set the offsets
+and validity buffers of a field from a HostBuffer. Setting a freed HostBuffer fails.
+
+	data, err := tiledb.NewHostBuffer[int32](1024)
+	if err != nil {
+		return err
+	}
+	defer data.Free()
+	if _, err := query.SetDataBuffer("a1", data); err != nil {
+		return err
+	}
+*/
+type HostBuffer[T HostBufferType] struct {
+	ptr   unsafe.Pointer
+	slice []T
+}
+
+// errHostBufferFreed is returned when a HostBuffer is set on a query after it was freed.
+var errHostBufferFreed = errors.New("the host buffer was freed")
+
+// NewHostBuffer allocates a zeroed HostBuffer of n values.
+func NewHostBuffer[T HostBufferType](n int) (*HostBuffer[T], error) {
+	if n <= 0 {
+		return nil, errors.New("error allocating host buffer: length must be positive")
+	}
+	ptr := C.calloc(C.size_t(n), C.size_t(unsafe.Sizeof(*new(T))))
+	if ptr == nil {
+		return nil, errors.New("error allocating host buffer: out of memory")
+	}
+	return &HostBuffer[T]{ptr: ptr, slice: unsafeSlice[T](ptr, uint(n))}, nil
+}
+
+// Slice returns a view of the buffer as a []T, or nil if it was freed.
+// The view must not be used after the buffer is freed.
+func (b *HostBuffer[T]) Slice() []T {
+	return b.slice
+}
+
+// Len returns the number of values of the buffer, or 0 if it was freed.
+func (b *HostBuffer[T]) Len() int {
+	return len(b.slice)
+}
+
+// Bytes returns the size of the buffer in bytes, or 0 if it was freed.
+func (b *HostBuffer[T]) Bytes() uint64 {
+	return uint64(len(b.slice)) * uint64(unsafe.Sizeof(*new(T)))
+}
+
+// Pointer returns the address of the buffer, or nil if it was freed.
+func (b *HostBuffer[T]) Pointer() unsafe.Pointer {
+	return b.ptr
+}
+
+// Free releases the memory of the buffer. It is safe to call Free more than once.
+func (b *HostBuffer[T]) Free() {
+	if b.ptr != nil {
+		C.free(b.ptr)
+		b.ptr = nil
+		b.slice = nil
+	}
+}
+
+// cBuffer returns the address and size in bytes of the buffer, for the query buffer setters.
+func (b *HostBuffer[T]) cBuffer() (unsafe.Pointer, uint64, error) {
+	if b.ptr == nil {
+		return nil, 0, errHostBufferFreed
+	}
+	return b.ptr, b.Bytes(), nil
+}
+
+// hostBuffer is implemented by every HostBuffer.
+type hostBuffer interface {
+	cBuffer() (unsafe.Pointer, uint64, error)
+}
@@ -0,0 +1,75 @@
+package tiledb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostBuffer(t *testing.T) {
+	_, err := NewHostBuffer[int32](0)
+	assert.Error(t, err)
+
+	buffer, err := NewHostBuffer[int32](4)
+	require.NoError(t, err)
+	assert.Equal(t, []int32{0, 0, 0, 0}, buffer.Slice())
+	assert.EqualValues(t, 16, buffer.Bytes())
+
+	buffer.Free()
+	buffer.Free()
+	assert.Nil(t, buffer.Slice())
+	assert.Nil(t, buffer.Pointer())
+	assert.Zero(t, buffer.Len())
+}
+
+func TestQueryHostBuffer(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	defer array.Free()
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+
+	query, err := NewQuery(array.context, array)
+	require.NoError(t, err)
+	defer query.Free()
+	require.NoError(t, query.SetLayout(TILEDB_ROW_MAJOR))
+
+	a1, err := NewHostBuffer[int32](4)
+	require.NoError(t, err)
+	defer a1.Free()
+	_, err = query.SetDataBuffer("a1", a1)
+	require.NoError(t, err)
+
+	a2, err := NewHostBuffer[uint8](16)
+	require.NoError(t, err)
+	defer a2.Free()
+	_, err = query.SetDataBuffer("a2", a2)
+	require.NoError(t, err)
+	a2Offsets, err := NewHostBuffer[uint64](4)
+	require.NoError(t, err)
+	defer a2Offsets.Free()
+	_, err = query.SetOffsetsHostBuffer("a2", a2Offsets)
+	require.NoError(t, err)
+
+	require.NoError(t, query.Submit())
+	elements, err := query.ResultBufferElements()
+	require.NoError(t, err)
+	assert.Equal(t, testAttributeValues.Attribute1, a1.Slice()[:elements["a1"][1]])
+	assert.Equal(t, testAttributeValues.Attribute2, a2.Slice()[:elements["a2"][1]])
+	assert.Equal(t, testAttributeValues.Attribute2Offset, a2Offsets.Slice()[:elements["a2"][0]])
+
+	freed, err := NewHostBuffer[uint64](4)
+	require.NoError(t, err)
+	freed.Free()
+	_, err = query.SetDataBuffer("a1", freed)
+	assert.Error(t, err)
+	_, err = query.SetOffsetsHostBuffer("a2", freed)
+	assert.Error(t, err)
+
+	freedValidity, err := NewHostBuffer[uint8](4)
+	require.NoError(t, err)
+	freedValidity.Free()
+	_, err = query.SetValidityHostBuffer("a1", freedValidity)
+	assert.Error(t, err)
+}
@@ -0,0 +1,483 @@
+package tiledb
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+/*
+NDArray is an n-dimensional view of values of type T stored in a flat slice.
+
+The element at index (i0, i1, ...) is stored at offset + i0*strides[0] + i1*strides[1] + ...
+of the underlying slice. Slice and Transpose return views sharing the values of the array.
+Like Go slices, At, Set and Slice panic when given out of range indexes.
+*/
+type NDArray[T any] struct {
+	data    []T
+	offset  int
+	shape   []int
+	strides []int
+}
+
+// NewNDArray returns a zeroed NDArray of the given shape, with values stored in row-major order.
+func NewNDArray[T any](shape ...int) *NDArray[T] {
+	n := 1
+	for _, s := range shape {
+		if s < 0 {
+			panic(fmt.Sprintf("tiledb: negative NDArray dimension %d", s))
+		}
+		n *= s
+	}
+	return &NDArray[T]{data: make([]T, n), shape: slices.Clone(shape), strides: contiguousStrides(shape, TILEDB_ROW_MAJOR)}
+}
+
+// NDArrayFromSlice returns an NDArray of the given shape viewing data, which holds its values in
+// TILEDB_ROW_MAJOR or TILEDB_COL_MAJOR layout.
+func NDArrayFromSlice[T any](data []T, layout Layout, shape ...int) (*NDArray[T], error) {
+	if layout != TILEDB_ROW_MAJOR && layout != TILEDB_COL_MAJOR {
+		return nil, fmt.Errorf("error creating NDArray: unsupported layout %v", layout)
+	}
+	n := 1
+	for _, s := range shape {
+		if s < 0 {
+			return nil, fmt.Errorf("error creating NDArray: negative dimension %d", s)
+		}
+		n *= s
+	}
+	if n != len(data) {
+		return nil, fmt.Errorf("error creating NDArray: shape %v requires %d values, got %d", shape, n, len(data))
+	}
+	return &NDArray[T]{data: data, shape: slices.Clone(shape), strides: contiguousStrides(shape, layout)}, nil
+}
+
+// contiguousStrides returns the strides of values of the given shape stored contiguously in layout.
+func contiguousStrides(shape []int, layout Layout) []int {
+	strides := make([]int, len(shape))
+	stride := 1
+	for k := range shape {
+		d := len(shape) - 1 - k
+		if layout == TILEDB_COL_MAJOR {
+			d = k
+		}
+		strides[d] = stride
+		stride *= shape[d]
+	}
+	return strides
+}
+
+// NDim returns the number of dimensions of the array.
+func (a *NDArray[T]) NDim() int {
+	return len(a.shape)
+}
+
+// Shape returns the length of each dimension of the array.
+func (a *NDArray[T]) Shape() []int {
+	return slices.Clone(a.shape)
+}
+
+// Strides returns the distance in values between consecutive indexes of each dimension.
+func (a *NDArray[T]) Strides() []int {
+	return slices.Clone(a.strides)
+}
+
+// Len returns the number of values of the array.
+func (a *NDArray[T]) Len() int {
+	n := 1
+	for _, s := range a.shape {
+		n *= s
+	}
+	return n
+}
+
+// position returns the position in the underlying slice of the value at idx.
+func (a *NDArray[T]) position(idx []int) int {
+	if len(idx) != len(a.shape) {
+		panic(fmt.Sprintf("tiledb: %d indexes given for an NDArray of %d dimensions", len(idx), len(a.shape)))
+	}
+	p := a.offset
+	for d, i := range idx {
+		if i < 0 || i >= a.shape[d] {
+			panic(fmt.Sprintf("tiledb: index %d out of range [0:%d] of NDArray dimension %d", i, a.shape[d], d))
+		}
+		p += i * a.strides[d]
+	}
+	return p
+}
+
+// At returns the value at idx.
+func (a *NDArray[T]) At(idx ...int) T {
+	return a.data[a.position(idx)]
+}
+
+// Set sets the value at idx to v.
+func (a *NDArray[T]) Set(v T, idx ...int) {
+	a.data[a.position(idx)] = v
+}
+
+// Slice returns a view of the array restricted to the half-open range [start, end) of each
+// dimension given by bounds, in dimension order. Dimensions without bounds are not restricted.
+func (a *NDArray[T]) Slice(bounds ...[2]int) *NDArray[T] {
+	if len(bounds) > len(a.shape) {
+		panic(fmt.Sprintf("tiledb: %d bounds given for an NDArray of %d dimensions", len(bounds), len(a.shape)))
+	}
+	view := &NDArray[T]{data: a.data, offset: a.offset, shape: slices.Clone(a.shape), strides: a.strides}
+	for d, b := range bounds {
+		if b[0] < 0 || b[0] > b[1] || b[1] > a.shape[d] {
+			panic(fmt.Sprintf("tiledb: bounds [%d:%d] out of range [0:%d] of NDArray dimension %d", b[0], b[1], a.shape[d], d))
+		}
+		if b[0] < b[1] {
+			view.offset += b[0] * a.strides[d]
+		}
+		view.shape[d] = b[1] - b[0]
+	}
+	return view
+}
+
+// Transpose returns a view of the array with its dimensions permuted: dimension d of the view
+// is dimension axes[d] of the array. Without axes, the order of the dimensions is reversed.
+func (a *NDArray[T]) Transpose(axes ...int) *NDArray[T] {
+	if len(axes) == 0 {
+		for d := range a.shape {
+			axes = append(axes, len(a.shape)-1-d)
+		}
+	}
+	sorted := slices.Clone(axes)
+	slices.Sort(sorted)
+	for d, axis := range sorted {
+		if d != axis || len(axes) != len(a.shape) {
+			panic(fmt.Sprintf("tiledb: axes %v are not a permutation of the %d NDArray dimensions", axes, len(a.shape)))
+		}
+	}
+
+	view := &NDArray[T]{data: a.data, offset: a.offset, shape: make([]int, len(axes)), strides: make([]int, len(axes))}
+	for d, axis := range axes {
+		view.shape[d] = a.shape[axis]
+		view.strides[d] = a.strides[axis]
+	}
+	return view
+}
+
+// Values returns a copy of the values of the array in TILEDB_ROW_MAJOR or TILEDB_COL_MAJOR layout.
+func (a *NDArray[T]) Values(layout Layout) ([]T, error) {
+	if layout != TILEDB_ROW_MAJOR && layout != TILEDB_COL_MAJOR {
+		return nil, fmt.Errorf("error getting NDArray values: unsupported layout %v", layout)
+	}
+	n := a.Len()
+	values := make([]T, 0, n)
+	if n == 0 {
+		return values, nil
+	}
+
+	// Walk the indexes like an odometer, the fastest dimension being the last one in row-major
+	// layout and the first one in column-major layout.
+	order := make([]int, len(a.shape))
+	for k := range order {
+		order[k] = len(a.shape) - 1 - k
+		if layout == TILEDB_COL_MAJOR {
+			order[k] = k
+		}
+	}
+	idx := make([]int, len(a.shape))
+	p := a.offset
+	for {
+		values = append(values, a.data[p])
+		k := 0
+		for ; k < len(order); k++ {
+			d := order[k]
+			idx[d]++
+			p += a.strides[d]
+			if idx[d] < a.shape[d] {
+				break
+			}
+			p -= idx[d] * a.strides[d]
+			idx[d] = 0
+		}
+		if k == len(order) {
+			return values, nil
+		}
+	}
+}
+
+/*
+ReadDense reads an attribute of a dense array over subarray into an NDArray, laid out in
+TILEDB_ROW_MAJOR or TILEDB_COL_MAJOR order. The subarray must have a single range per dimension;
+a nil subarray reads the whole domain. The NDArray has one dimension per array dimension, with
+the length of its range, plus a last dimension holding the values of each cell if the attribute
+has more than one value per cell. Var-sized and nullable attributes are not supported.
+
+The array must be open for reading.
+*/
+func ReadDense[T any](array *Array, subarray *Subarray, attribute string, layout Layout) (*NDArray[T], error) {
+	if layout != TILEDB_ROW_MAJOR && layout != TILEDB_COL_MAJOR {
+		return nil, fmt.Errorf("error reading dense array: unsupported layout %v", layout)
+	}
+	cellValNum, err := denseAttributeCellValNum(array, attribute)
+	if err != nil {
+		return nil, fmt.Errorf("error reading dense array: %w", err)
+	}
+
+	if subarray == nil {
+		if subarray, err = array.NewSubarray(); err != nil {
+			return nil, err
+		}
+		defer subarray.Free()
+	}
+	ranges, err := subarray.GetRanges()
+	if err != nil {
+		return nil, err
+	}
+	names, err := dimensionNames(array)
+	if err != nil {
+		return nil, err
+	}
+	shape := make([]int, 0, len(names)+1)
+	for _, name := range names {
+		if len(ranges[name]) != 1 {
+			return nil, fmt.Errorf("error reading dense array: dimension %s has %d ranges, a single range is supported", name, len(ranges[name]))
+		}
+		n, err := integerRangeLength(ranges[name][0])
+		if err != nil {
+			return nil, fmt.Errorf("error reading dense array: dimension %s: %w", name, err)
+		}
+		// The length of a range over every value of a 64-bit dimension wraps around to zero.
+		if n == 0 || n > math.MaxInt {
+			return nil, fmt.Errorf("error reading dense array: the range of dimension %s has too many cells", name)
+		}
+		shape = append(shape, int(n))
+	}
+
+	cells := 1
+	for _, s := range shape {
+		if cells > math.MaxInt/s {
+			return nil, errors.New("error reading dense array: the subarray has too many cells")
+		}
+		cells *= s
+	}
+	if cells > math.MaxInt/int(cellValNum) {
+		return nil, errors.New("error reading dense array: the subarray has too many values")
+	}
+	data := make([]T, cells*int(cellValNum))
+	if len(data) == 0 {
+		return nil, errors.New("error reading dense array: the subarray is empty")
+	}
+
+	query, err := NewQuery(array.context, array)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Free()
+	if err := query.SetLayout(layout); err != nil {
+		return nil, err
+	}
+	if err := query.SetSubarray(subarray); err != nil {
+		return nil, err
+	}
+	if _, err := query.SetDataBuffer(attribute, data); err != nil {
+		return nil, err
+	}
+	if err := query.Submit(); err != nil {
+		return nil, err
+	}
+	status, err := query.Status()
+	if err != nil {
+		return nil, err
+	}
+	if status != TILEDB_COMPLETED {
+		return nil, fmt.Errorf("error reading dense array: query status is %v", status)
+	}
+
+	// The values of each cell are contiguous, whatever the layout of the cells.
+	strides := contiguousStrides(shape, layout)
+	if cellValNum > 1 {
+		for d := range strides {
+			strides[d] *= int(cellValNum)
+		}
+		shape = append(shape, int(cellValNum))
+		strides = append(strides, 1)
+	}
+	return &NDArray[T]{data: data, shape: shape, strides: strides}, nil
+}
+
+/*
+WriteDense writes nd to an attribute of a dense array, in the region starting at origin, which
+holds the coordinates of the first cell in each dimension. A nil origin is the start of the
+domain. The NDArray has one dimension per array dimension, plus a last dimension holding the
+values of each cell if the attribute has more than one value per cell. Var-sized and nullable
+attributes are not supported.
+
+The array must be open for writing.
+*/
+func WriteDense[T any](array *Array, attribute string, origin []any, nd *NDArray[T]) error {
+	cellValNum, err := denseAttributeCellValNum(array, attribute)
+	if err != nil {
+		return fmt.Errorf("error writing dense array: %w", err)
+	}
+	names, err := dimensionNames(array)
+	if err != nil {
+		return err
+	}
+
+	shape := nd.shape
+	if cellValNum > 1 {
+		if len(shape) != len(names)+1 || shape[len(shape)-1] != int(cellValNum) {
+			return fmt.Errorf("error writing dense array: NDArray of shape %v does not have a last dimension of %d values per cell", shape, cellValNum)
+		}
+		shape = shape[:len(shape)-1]
+	}
+	if len(shape) != len(names) {
+		return fmt.Errorf("error writing dense array: NDArray has %d dimensions, the array has %d", len(shape), len(names))
+	}
+	if origin != nil && len(origin) != len(names) {
+		return fmt.Errorf("error writing dense array: origin has %d coordinates, the array has %d dimensions", len(origin), len(names))
+	}
+
+	if nd.Len() == 0 {
+		return errors.New("error writing dense array: the NDArray is empty")
+	}
+
+	subarray, err := array.NewSubarray()
+	if err != nil {
+		return err
+	}
+	defer subarray.Free()
+	for d, name := range names {
+		var start any
+		if origin != nil {
+			start = origin[d]
+		} else {
+			// The default range of the subarray covers the domain.
+			rng, err := subarray.GetRange(uint32(d), 0)
+			if err != nil {
+				return err
+			}
+			start = rng.start
+		}
+		end, err := shiftCoordinate(start, uint64(shape[d]-1))
+		if err != nil {
+			return fmt.Errorf("error writing dense array: dimension %s: %w", name, err)
+		}
+		if err := subarray.AddRange(uint32(d), Range{start: start, end: end}); err != nil {
+			return err
+		}
+	}
+
+	data, err := nd.Values(TILEDB_ROW_MAJOR)
+	if err != nil {
+		return err
+	}
+
+	query, err := NewQuery(array.context, array)
+	if err != nil {
+		return err
+	}
+	defer query.Free()
+	if err := query.SetLayout(TILEDB_ROW_MAJOR); err != nil {
+		return err
+	}
+	if err := query.SetSubarray(subarray); err != nil {
+		return err
+	}
+	if _, err := query.SetDataBuffer(attribute, data); err != nil {
+		return err
+	}
+	return query.Submit()
+}
+
+// denseAttributeCellValNum returns the number of values per cell of an attribute of a dense
+// array, which must be fixed-size and not nullable.
+func denseAttributeCellValNum(array *Array, attribute string) (uint32, error) {
+	schema, err := array.Schema()
+	if err != nil {
+		return 0, err
+	}
+	defer schema.Free()
+
+	arrayType, err := schema.Type()
+	if err != nil {
+		return 0, err
+	}
+	if arrayType != TILEDB_DENSE {
+		return 0, errors.New("the array is not dense")
+	}
+	info, err := schemaFieldInfo(schema, attribute)
+	if err != nil {
+		return 0, err
+	}
+	if info.isDimension || info.isVar() || info.nullable {
+		return 0, errorf(ErrSchemaMismatch, "%s must be a fixed-size, non-nullable attribute", attribute)
+	}
+	return info.cellValNum, nil
+}
+
+// dimensionNames returns the names of the dimensions of array.
+func dimensionNames(array *Array) ([]string, error) {
+	schema, err := array.Schema()
+	if err != nil {
+		return nil, err
+	}
+	defer schema.Free()
+
+	var names []string
+	fieldNames, err := schemaFieldNames(schema)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range fieldNames {
+		info, err := schemaFieldInfo(schema, name)
+		if err != nil {
+			return nil, err
+		}
+		if info.isDimension {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// integerRangeLength returns the number of coordinates of a range of an integer dimension.
+func integerRangeLength(r Range) (uint64, error) {
+	switch start := r.start.(type) {
+	case int8:
+		return uint64(r.end.(int8)) - uint64(start) + 1, nil
+	case int16:
+		return uint64(r.end.(int16)) - uint64(start) + 1, nil
+	case int32:
+		return uint64(r.end.(int32)) - uint64(start) + 1, nil
+	case int64:
+		return uint64(r.end.(int64)) - uint64(start) + 1, nil
+	case uint8:
+		return uint64(r.end.(uint8)) - uint64(start) + 1, nil
+	case uint16:
+		return uint64(r.end.(uint16)) - uint64(start) + 1, nil
+	case uint32:
+		return uint64(r.end.(uint32)) - uint64(start) + 1, nil
+	case uint64:
+		return r.end.(uint64) - start + 1, nil
+	}
+	return 0, fmt.Errorf("unsupported range of %T", r.start)
+}
+
+// shiftCoordinate returns the coordinate n after start on an integer dimension.
+func shiftCoordinate(start any, n uint64) (any, error) {
+	switch s := start.(type) {
+	case int8:
+		return int8(uint64(s) + n), nil
+	case int16:
+		return int16(uint64(s) + n), nil
+	case int32:
+		return int32(uint64(s) + n), nil
+	case int64:
+		return int64(uint64(s) + n), nil
+	case uint8:
+		return uint8(uint64(s) + n), nil
+	case uint16:
+		return uint16(uint64(s) + n), nil
+	case uint32:
+		return uint32(uint64(s) + n), nil
+	case uint64:
+		return s + n, nil
+	}
+	return nil, fmt.Errorf("unsupported coordinate of %T", start)
+}
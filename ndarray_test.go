@@ -0,0 +1,112 @@
+package tiledb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNDArray(t *testing.T) {
+	nd, err := NDArrayFromSlice([]int{0, 1, 2, 3, 4, 5}, TILEDB_ROW_MAJOR, 2, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 1}, nd.Strides())
+	assert.Equal(t, 5, nd.At(1, 2))
+	assert.Panics(t, func() { nd.At(2, 0) })
+	assert.Panics(t, func() { nd.At(0) })
+
+	transposed := nd.Transpose()
+	assert.Equal(t, []int{3, 2}, transposed.Shape())
+	assert.Equal(t, 5, transposed.At(2, 1))
+	values, err := transposed.Values(TILEDB_ROW_MAJOR)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 3, 1, 4, 2, 5}, values)
+
+	slice := nd.Slice([2]int{1, 2}, [2]int{1, 3})
+	assert.Equal(t, []int{1, 2}, slice.Shape())
+	values, err = slice.Values(TILEDB_ROW_MAJOR)
+	require.NoError(t, err)
+	assert.Equal(t, []int{4, 5}, values)
+
+	// Views share the values of the array.
+	slice.Set(40, 0, 0)
+	assert.Equal(t, 40, nd.At(1, 1))
+
+	colMajor, err := NDArrayFromSlice([]int{0, 1, 2, 3, 4, 5}, TILEDB_COL_MAJOR, 2, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 3, colMajor.At(1, 1))
+	values, err = colMajor.Values(TILEDB_COL_MAJOR)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, values)
+
+	_, err = NDArrayFromSlice([]int{0, 1}, TILEDB_ROW_MAJOR, 3)
+	assert.Error(t, err)
+}
+
+func TestReadWriteDense(t *testing.T) {
+	arrPath := createDenseIntegerGrid(t, 8)
+
+	nd := NewNDArray[uint16](3, 4)
+	for y := range 3 {
+		for x := range 4 {
+			nd.Set(uint16(10*y+x), y, x)
+		}
+	}
+	arr := openArray(t, arrPath, TILEDB_WRITE)
+	require.NoError(t, WriteDense(arr, "v", []any{uint8(2), uint8(4)}, nd))
+	require.NoError(t, WriteDense(arr, "v", nil, nd.Slice([2]int{0, 1}, [2]int{0, 1})))
+	assert.ErrorIs(t, WriteDense(arr, "y", nil, nd), ErrSchemaMismatch)
+
+	arr = openArray(t, arrPath, TILEDB_READ)
+	sa, err := arr.NewSubarray()
+	require.NoError(t, err)
+	require.NoError(t, sa.AddRange(0, MakeRange[uint8](2, 4)))
+	require.NoError(t, sa.AddRange(1, MakeRange[uint8](4, 7)))
+	for _, layout := range []Layout{TILEDB_ROW_MAJOR, TILEDB_COL_MAJOR} {
+		read, err := ReadDense[uint16](arr, sa, "v", layout)
+		require.NoError(t, err)
+		assert.Equal(t, []int{3, 4}, read.Shape())
+		for y := range 3 {
+			for x := range 4 {
+				assert.Equal(t, nd.At(y, x), read.At(y, x))
+			}
+		}
+	}
+
+	full, err := ReadDense[uint16](arr, nil, "v", TILEDB_ROW_MAJOR)
+	require.NoError(t, err)
+	assert.Equal(t, []int{8, 8}, full.Shape())
+	assert.Equal(t, uint16(0), full.At(0, 0))
+	assert.Equal(t, uint16(23), full.At(4, 7))
+}
+
+func TestReadDenseTooLarge(t *testing.T) {
+	tdbCtx, err := NewContext(nil)
+	require.NoError(t, err)
+
+	domain, err := NewDomain(tdbCtx)
+	require.NoError(t, err)
+	defer domain.Free()
+	for _, name := range []string{"y", "x"} {
+		dimension, err := NewDimension(tdbCtx, name, TILEDB_INT64, []int64{0, 1 << 40}, int64(1<<10))
+		require.NoError(t, err)
+		require.NoError(t, domain.AddDimensions(dimension))
+		dimension.Free()
+	}
+	attribute, err := NewAttribute(tdbCtx, "v", TILEDB_INT32)
+	require.NoError(t, err)
+	defer attribute.Free()
+	schema, err := NewArraySchema(tdbCtx, TILEDB_DENSE)
+	require.NoError(t, err)
+	defer schema.Free()
+	require.NoError(t, schema.SetDomain(domain))
+	require.NoError(t, schema.AddAttributes(attribute))
+
+	arrPath := t.TempDir()
+	require.NoError(t, CreateArray(tdbCtx, arrPath, schema))
+
+	// The domain has 2^80 cells, which must not wrap around when computing the buffer size.
+	arr := openArray(t, arrPath, TILEDB_READ)
+	_, err = ReadDense[int32](arr, nil, "v", TILEDB_ROW_MAJOR)
+	assert.ErrorContains(t, err, "too many cells")
+}
@@ -0,0 +1,250 @@
+package tiledb
+
+/*
+#include <tiledb/tiledb.h>
+#include <tiledb/tiledb_experimental.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"unsafe"
+)
+
+type ndRectangleHandle struct{ *capiHandle }
+
+func freeCapiNDRectangle(c unsafe.Pointer) {
+	C.tiledb_ndrectangle_free((**C.tiledb_ndrectangle_t)(unsafe.Pointer(&c)))
+}
+
+func newNDRectangleHandle(ptr *C.tiledb_ndrectangle_t) ndRectangleHandle {
+	return ndRectangleHandle{newCapiHandle(unsafe.Pointer(ptr), freeCapiNDRectangle)}
+}
+
+func (x ndRectangleHandle) Get() *C.tiledb_ndrectangle_t {
+	return (*C.tiledb_ndrectangle_t)(x.capiHandle.Get())
+}
+
+// NDRectangle is a hyperrectangle with one range per dimension of a domain.
+// It is used to define the current domain of an array.
+type NDRectangle struct {
+	context     *Context
+	ndRectangle ndRectangleHandle
+}
+
+func newNDRectangleFromHandle(context *Context, handle ndRectangleHandle) *NDRectangle {
+	return &NDRectangle{context: context, ndRectangle: handle}
+}
+
+// NewNDRectangle allocates a new NDRectangle over the dimensions of domain.
+func NewNDRectangle(tdbCtx *Context, domain *Domain) (*NDRectangle, error) {
+	var ndrPtr *C.tiledb_ndrectangle_t
+	ret := C.tiledb_ndrectangle_alloc(tdbCtx.tiledbContext.Get(), domain.tiledbDomain.Get(), &ndrPtr)
+	runtime.KeepAlive(tdbCtx)
+	runtime.KeepAlive(domain)
+	if ret != C.TILEDB_OK {
+		return nil, fmt.Errorf("error creating tiledb ndrectangle: %w", tdbCtx.lastError("tiledb_ndrectangle_alloc", ret))
+	}
+
+	return newNDRectangleFromHandle(tdbCtx, newNDRectangleHandle(ndrPtr)), nil
+}
+
+// Free releases the internal TileDB core data that was allocated on the C heap.
+// It is automatically called when this object is garbage collected, but can be
+// called earlier to manually release memory if needed. Free is idempotent and
+// can safely be called many times on the same object; if it has already
+// been freed, it will not be freed again.
+func (n *NDRectangle) Free() {
+	n.ndRectangle.Free()
+}
+
+// Context exposes the internal TileDB context used to initialize the NDRectangle.
+func (n *NDRectangle) Context() *Context {
+	return n.context
+}
+
+// NDim returns the number of dimensions of the NDRectangle.
+func (n *NDRectangle) NDim() (uint32, error) {
+	var ndim C.uint32_t
+	ret := C.tiledb_ndrectangle_get_dim_num(n.context.tiledbContext.Get(), n.ndRectangle.Get(), &ndim)
+	runtime.KeepAlive(n)
+	if ret != C.TILEDB_OK {
+		return 0, fmt.Errorf("error getting number of dimensions of ndrectangle: %w", n.context.lastError("tiledb_ndrectangle_get_dim_num", ret))
+	}
+	return uint32(ndim), nil
+}
+
+// Datatype returns the datatype of the dimension at index dimIdx.
+func (n *NDRectangle) Datatype(dimIdx uint32) (Datatype, error) {
+	var dt C.tiledb_datatype_t
+	ret := C.tiledb_ndrectangle_get_dtype(n.context.tiledbContext.Get(), n.ndRectangle.Get(), C.uint32_t(dimIdx), &dt)
+	runtime.KeepAlive(n)
+	if ret != C.TILEDB_OK {
+		return 0, fmt.Errorf("error getting datatype of dimension %d of ndrectangle: %w", dimIdx, n.context.lastError("tiledb_ndrectangle_get_dtype", ret))
+	}
+	return Datatype(dt), nil
+}
+
+// DatatypeFromName returns the datatype of the dimension named dimName.
+func (n *NDRectangle) DatatypeFromName(dimName string) (Datatype, error) {
+	cDimName := C.CString(dimName)
+	defer C.free(unsafe.Pointer(cDimName))
+
+	var dt C.tiledb_datatype_t
+	ret := C.tiledb_ndrectangle_get_dtype_from_name(n.context.tiledbContext.Get(), n.ndRectangle.Get(), cDimName, &dt)
+	runtime.KeepAlive(n)
+	if ret != C.TILEDB_OK {
+		return 0, fmt.Errorf("error getting datatype of dimension %s of ndrectangle: %w", dimName, n.context.lastError("tiledb_ndrectangle_get_dtype_from_name", ret))
+	}
+	return Datatype(dt), nil
+}
+
+// SetRange sets the range of the dimension at index dimIdx. The range must be
+// created with MakeRange with the Go type of the dimension, or string for string dimensions.
+func (n *NDRectangle) SetRange(dimIdx uint32, r Range) error {
+	dt, err := n.Datatype(dimIdx)
+	if err != nil {
+		return err
+	}
+
+	var pinner runtime.Pinner
+	defer pinner.Unpin()
+	cRange, err := r.toC(dt, &pinner)
+	if err != nil {
+		return fmt.Errorf("error setting range of dimension %d of ndrectangle: %w", dimIdx, err)
+	}
+
+	ret := C.tiledb_ndrectangle_set_range(n.context.tiledbContext.Get(), n.ndRectangle.Get(), C.uint32_t(dimIdx), &cRange)
+	runtime.KeepAlive(n)
+	if ret != C.TILEDB_OK {
+		return fmt.Errorf("error setting range of dimension %d of ndrectangle: %w", dimIdx, n.context.lastError("tiledb_ndrectangle_set_range", ret))
+	}
+	return nil
+}
+
+// SetRangeForName sets the range of the dimension named dimName. The range must be
+// created with MakeRange with the Go type of the dimension, or string for string dimensions.
+func (n *NDRectangle) SetRangeForName(dimName string, r Range) error {
+	dt, err := n.DatatypeFromName(dimName)
+	if err != nil {
+		return err
+	}
+
+	var pinner runtime.Pinner
+	defer pinner.Unpin()
+	cRange, err := r.toC(dt, &pinner)
+	if err != nil {
+		return fmt.Errorf("error setting range of dimension %s of ndrectangle: %w", dimName, err)
+	}
+
+	cDimName := C.CString(dimName)
+	defer C.free(unsafe.Pointer(cDimName))
+
+	ret := C.tiledb_ndrectangle_set_range_for_name(n.context.tiledbContext.Get(), n.ndRectangle.Get(), cDimName, &cRange)
+	runtime.KeepAlive(n)
+	if ret != C.TILEDB_OK {
+		return fmt.Errorf("error setting range of dimension %s of ndrectangle: %w", dimName, n.context.lastError("tiledb_ndrectangle_set_range_for_name", ret))
+	}
+	return nil
+}
+
+// Range returns the range of the dimension at index dimIdx. Use ExtractRange to get its endpoints.
+func (n *NDRectangle) Range(dimIdx uint32) (Range, error) {
+	dt, err := n.Datatype(dimIdx)
+	if err != nil {
+		return Range{}, err
+	}
+
+	var cRange C.tiledb_range_t
+	ret := C.tiledb_ndrectangle_get_range_from_index(n.context.tiledbContext.Get(), n.ndRectangle.Get(), C.uint32_t(dimIdx), &cRange)
+	if ret != C.TILEDB_OK {
+		return Range{}, fmt.Errorf("error getting range of dimension %d of ndrectangle: %w", dimIdx, n.context.lastError("tiledb_ndrectangle_get_range_from_index", ret))
+	}
+	// The range points to memory owned by the NDRectangle; copy it before the NDRectangle can be freed.
+	r := rangeFromC(dt, cRange)
+	runtime.KeepAlive(n)
+	return r, nil
+}
+
+// RangeFromName returns the range of the dimension named dimName. Use ExtractRange to get its endpoints.
+func (n *NDRectangle) RangeFromName(dimName string) (Range, error) {
+	dt, err := n.DatatypeFromName(dimName)
+	if err != nil {
+		return Range{}, err
+	}
+
+	cDimName := C.CString(dimName)
+	defer C.free(unsafe.Pointer(cDimName))
+
+	var cRange C.tiledb_range_t
+	ret := C.tiledb_ndrectangle_get_range_from_name(n.context.tiledbContext.Get(), n.ndRectangle.Get(), cDimName, &cRange)
+	if ret != C.TILEDB_OK {
+		return Range{}, fmt.Errorf("error getting range of dimension %s of ndrectangle: %w", dimName, n.context.lastError("tiledb_ndrectangle_get_range_from_name", ret))
+	}
+	r := rangeFromC(dt, cRange)
+	runtime.KeepAlive(n)
+	return r, nil
+}
+
+// isVarDimensionDatatype returns whether dimensions of the datatype are of variable size.
+// TileDB only supports variable sized dimensions for TILEDB_STRING_ASCII.
+func isVarDimensionDatatype(dt Datatype) bool {
+	return dt == TILEDB_STRING_ASCII
+}
+
+// toC converts the range to a C API range of datatype dt. The endpoints are pinned
+// with pinner because the C API range refers to them.
+func (r Range) toC(dt Datatype, pinner *runtime.Pinner) (C.tiledb_range_t, error) {
+	isVar := isVarDimensionDatatype(dt)
+	r, err := r.withTimestamps(dt)
+	if err != nil {
+		return C.tiledb_range_t{}, err
+	}
+	if err := r.assertCompatibility(dt, isVar); err != nil {
+		return C.tiledb_range_t{}, err
+	}
+
+	var cRange C.tiledb_range_t
+	if isVar {
+		start := []byte(r.start.(string))
+		end := []byte(r.end.(string))
+		if len(start) > 0 {
+			pinner.Pin(&start[0])
+		}
+		if len(end) > 0 {
+			pinner.Pin(&end[0])
+		}
+		cRange.min = slicePtr(start)
+		cRange.min_size = C.uint64_t(len(start))
+		cRange.max = slicePtr(end)
+		cRange.max_size = C.uint64_t(len(end))
+	} else {
+		start := addressableValue(r.start)
+		end := addressableValue(r.end)
+		pinner.Pin(start.Interface())
+		pinner.Pin(end.Interface())
+		cRange.min = start.UnsafePointer()
+		cRange.min_size = C.uint64_t(dt.Size())
+		cRange.max = end.UnsafePointer()
+		cRange.max_size = C.uint64_t(dt.Size())
+	}
+	return cRange, nil
+}
+
+// rangeFromC copies a C API range of datatype dt to a Range.
+func rangeFromC(dt Datatype, cRange C.tiledb_range_t) Range {
+	if isVarDimensionDatatype(dt) {
+		return Range{
+			start: C.GoStringN((*C.char)(cRange.min), C.int(cRange.min_size)),
+			end:   C.GoStringN((*C.char)(cRange.max), C.int(cRange.max_size)),
+		}
+	}
+	typ := dt.ReflectType()
+	return Range{
+		start: reflect.NewAt(typ, unsafe.Pointer(cRange.min)).Elem().Interface(),
+		end:   reflect.NewAt(typ, unsafe.Pointer(cRange.max)).Elem().Interface(),
+	}
+}
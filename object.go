@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"slices"
 	"unsafe"
 
 	pointer "github.com/mattn/go-pointer"
@@ -29,96 +30,183 @@ func ObjectType(tdbCtx *Context, path string) (ObjectTypeEnum, error) {
 	runtime.KeepAlive(tdbCtx)
 	if ret != C.TILEDB_OK {
 		return TILEDB_INVALID, fmt.Errorf("cannot get object type from path %s: %w",
-			path, tdbCtx.LastError())
+			path, tdbCtx.lastError("tiledb_object_type", ret))
 	}
 
 	return ObjectTypeEnum(objectTypeEnum), nil
 }
 
-type groupDefinition struct {
-	objectTypeEnum ObjectTypeEnum
-	path           string
+// ObjectEntry is a TileDB object visited by ObjectWalk or ObjectLs.
+type ObjectEntry struct {
+	// URI is the URI of the object.
+	URI string
+	// Type is the type of the object, an array or a group.
+	Type ObjectTypeEnum
 }
 
-// ObjectList defines the value of data returned by object iteration callback
+// ObjectList is the list of objects returned by ObjectWalk and ObjectLs.
 type ObjectList struct {
-	objectList []groupDefinition
+	objectList []ObjectEntry
+}
+
+// Entries returns the objects of the list, in the order they were visited.
+func (o *ObjectList) Entries() []ObjectEntry {
+	return o.objectList
+}
+
+// ObjectVisitCallback is called by ObjectWalkFunc and ObjectLsFunc for every visited object.
+// The traversal stops if it returns false or an error. A panic of the callback also stops
+// the traversal, and is raised again once it returned. The callback runs inside a TileDB
+// callback, so it must not call runtime.Goexit, as testing.T.FailNow does.
+type ObjectVisitCallback = func(entry ObjectEntry) (doContinue bool, err error)
+
+// objectVisitState contains the state of a call to ObjectWalkFunc or ObjectLsFunc.
+type objectVisitState struct {
+	callback  ObjectVisitCallback
+	types     []ObjectTypeEnum
+	lastError error
+	// panicked and panicValue record a panic of the callback, raised again once TileDB returns.
+	panicked   bool
+	panicValue any
+}
+
+// visit calls the callback, recovering a panic so that it does not unwind through the C
+// frames of the traversal.
+func (s *objectVisitState) visit(entry ObjectEntry) (doContinue bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.panicked, s.panicValue = true, r
+			doContinue = false
+		}
+	}()
+	return s.callback(entry)
+}
+
+// repanic raises again the panic of the callback, if any.
+func (s *objectVisitState) repanic() {
+	if s.panicked {
+		panic(s.panicValue)
+	}
 }
 
 //export objectsInPath
 func objectsInPath(path *C.cchar_t, objectTypeEnum C.tiledb_object_t, data unsafe.Pointer) int32 {
-	objectData := pointer.Restore(data).(*ObjectList)
+	state := pointer.Restore(data).(*objectVisitState)
 
-	groupDefinition := groupDefinition{
-		objectTypeEnum: ObjectTypeEnum(objectTypeEnum),
-		path:           C.GoString(path),
+	entry := ObjectEntry{
+		URI:  C.GoString(path),
+		Type: ObjectTypeEnum(objectTypeEnum),
+	}
+	if len(state.types) > 0 && !slices.Contains(state.types, entry.Type) {
+		return 1
 	}
 
-	objectData.objectList = append(objectData.objectList, groupDefinition)
+	doContinue, err := state.visit(entry)
+	if err != nil || !doContinue {
+		// Save error to return to the user. Returning 0 stops the traversal
+		// without making TileDB report an error.
+		state.lastError = err
+		return 0
+	}
 
 	return 1
 }
 
-// ObjectWalk (iterates) over the TileDB objects contained in *path*. The traversal
-// is done recursively in the order defined by the user. The user provides
-// a callback function which is applied on each of the visited TileDB objects.
-// The iteration continues for as long the callback returns non-zero, and stops
-// when the callback returns 0. Note that this function ignores any object
-// (e.g., file or directory) that is not TileDB-related.
-func ObjectWalk(tdbCtx *Context, path string, walkOrder WalkOrder) (*ObjectList, error) {
+// ObjectWalkFunc calls callback for every TileDB object contained in path. The traversal
+// is done recursively in the order defined by walkOrder, and stops early when the
+// callback returns false or an error; the error is returned by ObjectWalkFunc.
+// If types are given, only objects of these types are visited. Note that this function
+// ignores any object (e.g., file or directory) that is not TileDB-related.
+func ObjectWalkFunc(tdbCtx *Context, path string, walkOrder WalkOrder, callback ObjectVisitCallback, types ...ObjectTypeEnum) error {
 	if tdbCtx == nil {
-		return nil, errors.New("error walking object, context is nil")
+		return errors.New("error walking object, context is nil")
 	}
 
 	cpath := C.CString(path)
 	defer C.free(unsafe.Pointer(cpath))
 
-	objectList := ObjectList{
-		objectList: []groupDefinition{},
+	state := &objectVisitState{
+		callback: callback,
+		types:    types,
 	}
-	data := pointer.Save(&objectList)
+	data := pointer.Save(state)
 	defer pointer.Unref(data)
 
 	ret := C._tiledb_object_walk(tdbCtx.tiledbContext.Get(), cpath,
-		C.tiledb_walk_order_t(walkOrder), unsafe.Pointer(data))
+		C.tiledb_walk_order_t(walkOrder), data)
 	runtime.KeepAlive(tdbCtx)
-
-	fmt.Println(objectList)
-
+	state.repanic()
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("cannot walk in path %s: %w", path,
-			tdbCtx.LastError())
+		return fmt.Errorf("cannot walk in path %s: %w", path,
+			tdbCtx.lastError("tiledb_object_walk", ret))
 	}
-	return &objectList, nil
+
+	return state.lastError
 }
 
-// ObjectLs is similar to `tiledb_walk`, but now the function visits only the children
-// of `path` (it does not recursively continue to the children directories).
-func ObjectLs(tdbCtx *Context, path string) (*ObjectList, error) {
+// ObjectLsFunc is similar to ObjectWalkFunc, but it visits only the children of path
+// (it does not recursively continue to the children directories).
+func ObjectLsFunc(tdbCtx *Context, path string, callback ObjectVisitCallback, types ...ObjectTypeEnum) error {
 	if tdbCtx == nil {
-		return nil, errors.New("error listing object, context is nil")
+		return errors.New("error listing object, context is nil")
 	}
 
 	cpath := C.CString(path)
 	defer C.free(unsafe.Pointer(cpath))
 
-	objectList := ObjectList{
-		objectList: []groupDefinition{},
+	state := &objectVisitState{
+		callback: callback,
+		types:    types,
 	}
-	data := pointer.Save(&objectList)
+	data := pointer.Save(state)
 	defer pointer.Unref(data)
 
-	ret := C._tiledb_object_ls(tdbCtx.tiledbContext.Get(), cpath,
-		unsafe.Pointer(data))
+	ret := C._tiledb_object_ls(tdbCtx.tiledbContext.Get(), cpath, data)
 	runtime.KeepAlive(tdbCtx)
-
+	state.repanic()
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("cannot walk in path %s: %w", path,
-			tdbCtx.LastError())
+		return fmt.Errorf("cannot walk in path %s: %w", path,
+			tdbCtx.lastError("tiledb_object_ls", ret))
+	}
+
+	return state.lastError
+}
+
+// ObjectWalk returns the TileDB objects contained in *path*. The traversal
+// is done recursively in the order defined by the user. If types are given,
+// only objects of these types are returned. Note that this function ignores
+// any object (e.g., file or directory) that is not TileDB-related.
+// Use ObjectWalkFunc to stop the traversal early.
+func ObjectWalk(tdbCtx *Context, path string, walkOrder WalkOrder, types ...ObjectTypeEnum) (*ObjectList, error) {
+	objectList := ObjectList{
+		objectList: []ObjectEntry{},
+	}
+	err := ObjectWalkFunc(tdbCtx, path, walkOrder, objectList.append, types...)
+	if err != nil {
+		return nil, err
+	}
+	return &objectList, nil
+}
+
+// ObjectLs is similar to `tiledb_walk`, but now the function visits only the children
+// of `path` (it does not recursively continue to the children directories).
+// If types are given, only objects of these types are returned.
+func ObjectLs(tdbCtx *Context, path string, types ...ObjectTypeEnum) (*ObjectList, error) {
+	objectList := ObjectList{
+		objectList: []ObjectEntry{},
+	}
+	err := ObjectLsFunc(tdbCtx, path, objectList.append, types...)
+	if err != nil {
+		return nil, err
 	}
 	return &objectList, nil
 }
 
+func (o *ObjectList) append(entry ObjectEntry) (bool, error) {
+	o.objectList = append(o.objectList, entry)
+	return true, nil
+}
+
 // ObjectMove moves a TileDB resource (group, array, key-value).
 // Param path is the new path to move to
 func ObjectMove(tdbCtx *Context, path string, newPath string) error {
@@ -134,7 +222,7 @@ func ObjectMove(tdbCtx *Context, path string, newPath string) error {
 	runtime.KeepAlive(tdbCtx)
 	if ret != C.TILEDB_OK {
 		return fmt.Errorf("cannot move object from %s to %s: %w", path,
-			newPath, tdbCtx.LastError())
+			newPath, tdbCtx.lastError("tiledb_object_move", ret))
 	}
 
 	return nil
@@ -151,7 +239,7 @@ func ObjectRemove(tdbCtx *Context, path string) error {
 	ret := C.tiledb_object_remove(tdbCtx.tiledbContext.Get(), cpath)
 	runtime.KeepAlive(tdbCtx)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("cannot delete object %s: %w", path, tdbCtx.LastError())
+		return fmt.Errorf("cannot delete object %s: %w", path, tdbCtx.lastError("tiledb_object_remove", ret))
 	}
 	return nil
 }
@@ -0,0 +1,74 @@
+//go:build go1.23
+
+package tiledb
+
+import "iter"
+
+// WalkObjects returns an iterator over the TileDB objects contained in path, for use
+// with range-over-func:
+//
+//	for entry, err := range tiledb.WalkObjects(tdbCtx, "s3://bucket/prefix", tiledb.TILEDB_PREORDER, tiledb.TILEDB_ARRAY) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// Breaking out of the loop stops the traversal. If the traversal fails, the error is
+// yielded as the last element. See ObjectWalkFunc for the meaning of the arguments.
+func WalkObjects(tdbCtx *Context, path string, walkOrder WalkOrder, types ...ObjectTypeEnum) iter.Seq2[ObjectEntry, error] {
+	return visitObjects(func(callback ObjectVisitCallback) error {
+		return ObjectWalkFunc(tdbCtx, path, walkOrder, callback, types...)
+	})
+}
+
+// LsObjects is similar to WalkObjects, but it visits only the children of path
+// (it does not recursively continue to the children directories).
+func LsObjects(tdbCtx *Context, path string, types ...ObjectTypeEnum) iter.Seq2[ObjectEntry, error] {
+	return visitObjects(func(callback ObjectVisitCallback) error {
+		return ObjectLsFunc(tdbCtx, path, callback, types...)
+	})
+}
+
+// visitObjects returns an iterator over the objects visited by visit. The traversal runs
+// in its own goroutine and hands every object over to the loop, so that the loop body
+// never runs inside the TileDB callback: a panic or runtime.Goexit in the loop body must
+// not unwind through C frames. The traversal waits for the loop body before continuing,
+// and is stopped and waited for when the loop ends early.
+func visitObjects(visit func(ObjectVisitCallback) error) iter.Seq2[ObjectEntry, error] {
+	return func(yield func(ObjectEntry, error) bool) {
+		entries := make(chan ObjectEntry)
+		resume := make(chan bool)
+		done := make(chan error, 1)
+		go func() {
+			defer close(entries)
+			done <- visit(func(entry ObjectEntry) (bool, error) {
+				entries <- entry
+				return <-resume, nil
+			})
+		}()
+
+		// waiting is set while the traversal waits to know whether to continue.
+		waiting := false
+		defer func() {
+			if waiting {
+				resume <- false
+			}
+			for range entries {
+				resume <- false
+			}
+		}()
+
+		for entry := range entries {
+			waiting = true
+			if !yield(entry, nil) {
+				return
+			}
+			waiting = false
+			resume <- true
+		}
+		if err := <-done; err != nil {
+			yield(ObjectEntry{}, err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+//go:build go1.23
+
+package tiledb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkObjects(t *testing.T) {
+	tdbCtx, root := createObjectTestTree(t)
+
+	var entries []ObjectEntry
+	for entry, err := range WalkObjects(tdbCtx, root, TILEDB_PREORDER) {
+		require.NoError(t, err)
+		entries = append(entries, entry)
+	}
+	assert.ElementsMatch(t, []string{"array1", "sub", "array2"}, objectEntryNames(entries))
+
+	entries = nil
+	for entry, err := range WalkObjects(tdbCtx, root, TILEDB_PREORDER, TILEDB_ARRAY) {
+		require.NoError(t, err)
+		entries = append(entries, entry)
+		break
+	}
+	require.Len(t, entries, 1)
+	assert.Equal(t, TILEDB_ARRAY, entries[0].Type)
+
+	entries = nil
+	for entry, err := range LsObjects(tdbCtx, root, TILEDB_GROUP) {
+		require.NoError(t, err)
+		entries = append(entries, entry)
+	}
+	assert.Equal(t, []string{"sub"}, objectEntryNames(entries))
+}
+
+func TestWalkObjectsPanic(t *testing.T) {
+	tdbCtx, root := createObjectTestTree(t)
+
+	// The loop body does not run inside the TileDB callback, so its panics propagate.
+	assert.PanicsWithValue(t, "stop", func() {
+		for range WalkObjects(tdbCtx, root, TILEDB_PREORDER) {
+			panic("stop")
+		}
+	})
+	assert.PanicsWithValue(t, "stop", func() {
+		_ = ObjectLsFunc(tdbCtx, root, func(ObjectEntry) (bool, error) {
+			panic("stop")
+		})
+	})
+
+	// The traversals were stopped, and the objects can be walked again.
+	var entries []ObjectEntry
+	for entry, err := range LsObjects(tdbCtx, root) {
+		require.NoError(t, err)
+		entries = append(entries, entry)
+	}
+	assert.ElementsMatch(t, []string{"array1", "sub"}, objectEntryNames(entries))
+}
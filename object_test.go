@@ -0,0 +1,81 @@
+package tiledb
+
+import (
+	"errors"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectWalk(t *testing.T) {
+	tdbCtx, root := createObjectTestTree(t)
+
+	list, err := ObjectWalk(tdbCtx, root, TILEDB_PREORDER)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"array1", "sub", "array2"}, objectEntryNames(list.Entries()))
+
+	list, err = ObjectWalk(tdbCtx, root, TILEDB_POSTORDER, TILEDB_ARRAY)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"array1", "array2"}, objectEntryNames(list.Entries()))
+	for _, entry := range list.Entries() {
+		assert.Equal(t, TILEDB_ARRAY, entry.Type)
+	}
+
+	list, err = ObjectLs(tdbCtx, root)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"array1", "sub"}, objectEntryNames(list.Entries()))
+
+	list, err = ObjectLs(tdbCtx, root, TILEDB_GROUP)
+	require.NoError(t, err)
+	require.Len(t, list.Entries(), 1)
+	assert.Equal(t, "sub", objectEntryNames(list.Entries())[0])
+	assert.Equal(t, TILEDB_GROUP, list.Entries()[0].Type)
+}
+
+func TestObjectWalkFunc(t *testing.T) {
+	tdbCtx, root := createObjectTestTree(t)
+
+	var visited int
+	require.NoError(t, ObjectWalkFunc(tdbCtx, root, TILEDB_PREORDER, func(entry ObjectEntry) (bool, error) {
+		visited++
+		return false, nil
+	}))
+	assert.Equal(t, 1, visited)
+
+	errStop := errors.New("stop")
+	visited = 0
+	err := ObjectLsFunc(tdbCtx, root, func(entry ObjectEntry) (bool, error) {
+		visited++
+		return true, errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, visited)
+}
+
+// createObjectTestTree creates a group containing an array and a group that contains another array.
+func createObjectTestTree(t *testing.T) (*Context, string) {
+	tdbCtx, err := NewContext(nil)
+	require.NoError(t, err)
+
+	root := t.TempDir()
+	require.NoError(t, CreateGroup(tdbCtx, root))
+	require.NoError(t, CreateGroup(tdbCtx, filepath.Join(root, "sub")))
+
+	arraySchema := buildArraySchema(tdbCtx, t)
+	require.NoError(t, CreateArray(tdbCtx, filepath.Join(root, "array1"), arraySchema))
+	require.NoError(t, CreateArray(tdbCtx, filepath.Join(root, "sub", "array2"), arraySchema))
+
+	return tdbCtx, root
+}
+
+func objectEntryNames(entries []ObjectEntry) []string {
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = path.Base(strings.TrimSuffix(entry.URI, "/"))
+	}
+	return names
+}
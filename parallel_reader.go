@@ -0,0 +1,526 @@
+package tiledb
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// ParallelReaderOption configures a ParallelReader.
+type ParallelReaderOption func(r *ParallelReader)
+
+// WithPartitions sets the maximum number of partitions the subarray is split into.
+// It defaults to runtime.GOMAXPROCS(0).
+func WithPartitions(n int) ParallelReaderOption {
+	return func(r *ParallelReader) {
+		r.maxPartitions = n
+	}
+}
+
+// WithWorkers sets the maximum number of partitions read concurrently.
+// It defaults to runtime.GOMAXPROCS(0).
+func WithWorkers(n int) ParallelReaderOption {
+	return func(r *ParallelReader) {
+		r.workers = n
+	}
+}
+
+// WithOrderedResults sets whether the partition results are delivered in partition
+// order, which is the default, or as soon as they are read.
+func WithOrderedResults(ordered bool) ParallelReaderOption {
+	return func(r *ParallelReader) {
+		r.ordered = ordered
+	}
+}
+
+// WithReadLayout sets the layout of the queries reading the partitions.
+// By default the layout of the queries is not set.
+func WithReadLayout(layout Layout) ParallelReaderOption {
+	return func(r *ParallelReader) {
+		r.layout = &layout
+	}
+}
+
+// WithPartitionIteratorOptions sets the options of the ResultIterator reading each partition.
+func WithPartitionIteratorOptions(opts ...ResultIteratorOption) ParallelReaderOption {
+	return func(r *ParallelReader) {
+		r.iteratorOpts = opts
+	}
+}
+
+/*
+ParallelReader reads a subarray with several concurrent queries, one per partition
+of the subarray.
+
+The subarray is split along the tiles of its integer dimensions: the boundaries of
+the partitions are tile boundaries, spread evenly over the part of the subarray that
+intersects the non-empty domain of the array. Dimensions of other types are not split.
+For sparse arrays, the partitions that do not intersect the non-empty domain of any
+fragment are skipped.
+
+	reader, err := tiledb.NewParallelReader(subarray, []string{"a1"}, tiledb.WithPartitions(16))
+	if err != nil {
+		return err
+	}
+	for res := range reader.Read(ctx) {
+		if res.Err != nil {
+			return res.Err
+		}
+		for _, batch := range res.Batches {
+			...
+		}
+	}
+*/
+type ParallelReader struct {
+	array         *Array
+	fieldNames    []string
+	partitions    [][]Range
+	maxPartitions int
+	workers       int
+	ordered       bool
+	layout        *Layout
+	iteratorOpts  []ResultIteratorOption
+}
+
+// PartitionResult holds the results of a partition read by a ParallelReader.
+type PartitionResult struct {
+	// Index is the index of the partition in ParallelReader.Partitions.
+	Index int
+	// Ranges are the ranges of the partition, one per dimension.
+	Ranges []Range
+	// Batches are the results of the partition. Unlike the batches of a ResultIterator,
+	// they remain valid after the next result is received.
+	Batches []*ResultBatch
+	// Err is the error that occurred while reading the partition, if any.
+	Err error
+}
+
+// NewParallelReader returns a ParallelReader reading the given attributes, dimensions or
+// dimension labels of subarray, which must have a single range per dimension. If no field
+// names are given, all dimensions and attributes of the array are read. The array of the
+// subarray must be open for reading while the reader is used.
+func NewParallelReader(subarray *Subarray, fieldNames []string, opts ...ParallelReaderOption) (*ParallelReader, error) {
+	r := &ParallelReader{
+		array:         subarray.array,
+		fieldNames:    fieldNames,
+		maxPartitions: runtime.GOMAXPROCS(0),
+		workers:       runtime.GOMAXPROCS(0),
+		ordered:       true,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.maxPartitions < 1 || r.workers < 1 {
+		return nil, errors.New("error creating parallel reader: the numbers of partitions and workers must be positive")
+	}
+
+	partitions, err := partitionSubarray(subarray, r.maxPartitions)
+	if err != nil {
+		return nil, fmt.Errorf("error creating parallel reader: %w", err)
+	}
+	r.partitions = partitions
+	return r, nil
+}
+
+// Partitions returns the ranges of the partitions, one per dimension.
+func (r *ParallelReader) Partitions() [][]Range {
+	return r.partitions
+}
+
+// Read reads the partitions on a pool of workers and returns a channel receiving their
+// results, which is closed when all the partitions have been read. An error reading a
+// partition is reported in its result and does not stop the other partitions.
+//
+// Cancelling ctx stops the workers after their current submission, and closes the
+// channel without delivering the remaining results.
+func (r *ParallelReader) Read(ctx context.Context) <-chan PartitionResult {
+	out := make(chan PartitionResult)
+	jobs := make(chan int)
+
+	// In order, each worker waits for its result to be delivered before reading another
+	// partition, so that at most one result per worker is held in memory.
+	var partitionResults []chan PartitionResult
+	if r.ordered {
+		partitionResults = make([]chan PartitionResult, len(r.partitions))
+		for i := range partitionResults {
+			partitionResults[i] = make(chan PartitionResult)
+		}
+	}
+
+	go func() {
+		defer close(jobs)
+		for i := range r.partitions {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range min(r.workers, len(r.partitions)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				res := r.readPartition(ctx, i)
+				results := out
+				if r.ordered {
+					results = partitionResults[i]
+				}
+				select {
+				case results <- res:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		if r.ordered {
+			deliverInOrder(ctx, partitionResults, out)
+		}
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// deliverInOrder forwards the result of each partition to out, in partition order.
+func deliverInOrder(ctx context.Context, partitionResults []chan PartitionResult, out chan<- PartitionResult) {
+	for _, results := range partitionResults {
+		var res PartitionResult
+		select {
+		case res = <-results:
+		case <-ctx.Done():
+			return
+		}
+		select {
+		case out <- res:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// readPartition reads the partition at index i.
+func (r *ParallelReader) readPartition(ctx context.Context, i int) PartitionResult {
+	res := PartitionResult{Index: i, Ranges: r.partitions[i]}
+	res.Batches, res.Err = r.readRanges(ctx, res.Ranges)
+	if res.Err != nil {
+		res.Err = fmt.Errorf("error reading partition %d: %w", i, res.Err)
+	}
+	return res
+}
+
+func (r *ParallelReader) readRanges(ctx context.Context, ranges []Range) ([]*ResultBatch, error) {
+	query, err := NewQuery(r.array.context, r.array)
+	if err != nil {
+		return nil, err
+	}
+	defer query.Free()
+
+	if r.layout != nil {
+		if err := query.SetLayout(*r.layout); err != nil {
+			return nil, err
+		}
+	}
+
+	subarray, err := r.array.NewSubarray()
+	if err != nil {
+		return nil, err
+	}
+	defer subarray.Free()
+	for dimIdx, rng := range ranges {
+		if err := subarray.AddRange(uint32(dimIdx), rng); err != nil {
+			return nil, err
+		}
+	}
+	if err := query.SetSubarray(subarray); err != nil {
+		return nil, err
+	}
+
+	it, err := query.Iterate(r.fieldNames, r.iteratorOpts...)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+	var batches []*ResultBatch
+	for it.Next() {
+		batch := it.Batch()
+		if !it.done || it.pool != nil {
+			// The next submission reuses the buffers of the iterator, which are returned
+			// to the pool by Release.
+			batch = batch.clone()
+		}
+		batches = append(batches, batch)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return batches, it.Err()
+}
+
+// partitionSubarray splits the single range per dimension of subarray into at most n
+// partitions, returning the ranges of each partition in row-major order.
+func partitionSubarray(subarray *Subarray, n int) ([][]Range, error) {
+	array := subarray.array
+
+	schema, err := array.Schema()
+	if err != nil {
+		return nil, err
+	}
+	defer schema.Free()
+
+	domain, err := schema.Domain()
+	if err != nil {
+		return nil, err
+	}
+	defer domain.Free()
+
+	ndim, err := domain.NDim()
+	if err != nil {
+		return nil, err
+	}
+
+	dimRanges := make([][]Range, ndim)
+	remaining := n
+	for dimIdx := range ndim {
+		rangeNum, err := subarray.GetRangeNum(uint32(dimIdx))
+		if err != nil {
+			return nil, err
+		}
+		if rangeNum != 1 {
+			return nil, fmt.Errorf("dimension %d has %d ranges, a single range is supported", dimIdx, rangeNum)
+		}
+		rng, err := subarray.GetRange(uint32(dimIdx), 0)
+		if err != nil {
+			return nil, err
+		}
+
+		dimension, err := domain.DimensionFromIndex(dimIdx)
+		if err != nil {
+			return nil, err
+		}
+		dimRanges[dimIdx], err = splitDimensionRange(array, dimension, dimIdx, rng, remaining)
+		dimension.Free()
+		if err != nil {
+			return nil, err
+		}
+		remaining = (remaining + len(dimRanges[dimIdx]) - 1) / len(dimRanges[dimIdx])
+	}
+
+	partitions := [][]Range{{}}
+	for _, ranges := range dimRanges {
+		next := make([][]Range, 0, len(partitions)*len(ranges))
+		for _, partition := range partitions {
+			for _, rng := range ranges {
+				next = append(next, append(partition[:len(partition):len(partition)], rng))
+			}
+		}
+		partitions = next
+	}
+
+	arrayType, err := schema.Type()
+	if err != nil {
+		return nil, err
+	}
+	if arrayType == TILEDB_SPARSE && len(partitions) > 1 {
+		return pruneSparsePartitions(array, partitions)
+	}
+	return partitions, nil
+}
+
+// partitionInteger is a constraint for the types of the dimensions split by partitionSubarray.
+type partitionInteger interface {
+	~int8 | ~int16 | ~int32 | ~int64 | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// splitDimensionRange splits rng along the tiles of the dimension at index dimIdx into
+// at most n ranges. Only integer dimensions are split.
+func splitDimensionRange(array *Array, dimension *Dimension, dimIdx uint, rng Range, n int) ([]Range, error) {
+	datatype, err := dimension.Type()
+	if err != nil {
+		return nil, err
+	}
+	switch datatype {
+	case TILEDB_INT8, TILEDB_INT16, TILEDB_INT32, TILEDB_INT64,
+		TILEDB_UINT8, TILEDB_UINT16, TILEDB_UINT32, TILEDB_UINT64:
+	default:
+		return []Range{rng}, nil
+	}
+	if n <= 1 {
+		return []Range{rng}, nil
+	}
+
+	domainBounds, err := dimension.Domain()
+	if err != nil {
+		return nil, err
+	}
+	extent, err := dimension.Extent()
+	if err != nil {
+		return nil, err
+	}
+	nonEmptyDomain, isEmpty, err := array.NonEmptyDomainFromIndex(dimIdx)
+	if err != nil {
+		return nil, err
+	}
+	if isEmpty {
+		nonEmptyDomain = nil
+	}
+
+	switch datatype {
+	case TILEDB_INT8:
+		return splitTileAligned[int8](rng, domainBounds, extent, nonEmptyDomain, n), nil
+	case TILEDB_INT16:
+		return splitTileAligned[int16](rng, domainBounds, extent, nonEmptyDomain, n), nil
+	case TILEDB_INT32:
+		return splitTileAligned[int32](rng, domainBounds, extent, nonEmptyDomain, n), nil
+	case TILEDB_INT64:
+		return splitTileAligned[int64](rng, domainBounds, extent, nonEmptyDomain, n), nil
+	case TILEDB_UINT8:
+		return splitTileAligned[uint8](rng, domainBounds, extent, nonEmptyDomain, n), nil
+	case TILEDB_UINT16:
+		return splitTileAligned[uint16](rng, domainBounds, extent, nonEmptyDomain, n), nil
+	case TILEDB_UINT32:
+		return splitTileAligned[uint32](rng, domainBounds, extent, nonEmptyDomain, n), nil
+	default:
+		return splitTileAligned[uint64](rng, domainBounds, extent, nonEmptyDomain, n), nil
+	}
+}
+
+// splitTileAligned splits rng into at most n ranges whose inner boundaries are tile boundaries,
+// spread evenly over the tiles of rng that intersect nonEmptyDomain. Tile arithmetic is done on
+// uint64 offsets from the start of the domain, which do not overflow for signed types.
+func splitTileAligned[T partitionInteger](rng Range, domainBounds, extent any, nonEmptyDomain *NonEmptyDomain, n int) []Range {
+	start, end := rng.start.(T), rng.end.(T)
+	domainStart, tileExtent := domainBounds.([]T)[0], uint64(extent.(T))
+	if tileExtent == 0 {
+		return []Range{rng}
+	}
+
+	lo, hi := start, end
+	if nonEmptyDomain != nil {
+		bounds := nonEmptyDomain.Bounds.([]T)
+		if bounds[0] <= end && bounds[1] >= start {
+			lo, hi = max(start, bounds[0]), min(end, bounds[1])
+		}
+	}
+
+	firstTile := (uint64(lo) - uint64(domainStart)) / tileExtent
+	lastTile := (uint64(hi) - uint64(domainStart)) / tileExtent
+	numTiles := lastTile - firstTile + 1
+	k := uint64(n)
+	if numTiles < k {
+		k = numTiles
+	}
+	if k <= 1 {
+		return []Range{rng}
+	}
+
+	ranges := make([]Range, 0, k)
+	from := start
+	for i := uint64(1); i < k; i++ {
+		tile := firstTile + i*(numTiles/k) + min(i, numTiles%k)
+		boundary := T(uint64(domainStart) + tile*tileExtent)
+		ranges = append(ranges, MakeRange(from, boundary-1))
+		from = boundary
+	}
+	return append(ranges, MakeRange(from, end))
+}
+
+// pruneSparsePartitions removes the partitions that do not intersect the non-empty domain
+// of any fragment of the sparse array.
+func pruneSparsePartitions(array *Array, partitions [][]Range) ([][]Range, error) {
+	fragmentInfo, err := NewFragmentInfo(array.context, array.uri)
+	if err != nil {
+		return nil, err
+	}
+	defer fragmentInfo.Free()
+	if err := fragmentInfo.Load(); err != nil {
+		return nil, err
+	}
+	fragmentNum, err := fragmentInfo.GetFragmentNum()
+	if err != nil {
+		return nil, err
+	}
+
+	// The non-empty domains of the fragments on the fixed-size dimensions, nil for the others.
+	fragmentBounds := make([][]any, fragmentNum)
+	for fid := range fragmentNum {
+		fragmentBounds[fid] = make([]any, len(partitions[0]))
+		for dimIdx, rng := range partitions[0] {
+			if _, isVar := rng.start.(string); isVar {
+				continue
+			}
+			nonEmptyDomain, err := fragmentInfo.GetNonEmptyDomainFromIndex(fid, uint32(dimIdx))
+			if err != nil {
+				return nil, err
+			}
+			if nonEmptyDomain != nil {
+				fragmentBounds[fid][dimIdx] = nonEmptyDomain.Bounds
+			}
+		}
+	}
+
+	var pruned [][]Range
+	for _, partition := range partitions {
+		for _, bounds := range fragmentBounds {
+			if partitionIntersects(partition, bounds) {
+				pruned = append(pruned, partition)
+				break
+			}
+		}
+	}
+	return pruned, nil
+}
+
+// partitionIntersects reports whether the ranges of a partition intersect the bounds of a
+// fragment on every dimension. Dimensions without bounds are assumed to intersect.
+func partitionIntersects(partition []Range, bounds []any) bool {
+	for dimIdx, rng := range partition {
+		var intersects bool
+		switch b := bounds[dimIdx].(type) {
+		case []int8:
+			intersects = rangeIntersects(rng, b)
+		case []int16:
+			intersects = rangeIntersects(rng, b)
+		case []int32:
+			intersects = rangeIntersects(rng, b)
+		case []int64:
+			intersects = rangeIntersects(rng, b)
+		case []uint8:
+			intersects = rangeIntersects(rng, b)
+		case []uint16:
+			intersects = rangeIntersects(rng, b)
+		case []uint32:
+			intersects = rangeIntersects(rng, b)
+		case []uint64:
+			intersects = rangeIntersects(rng, b)
+		case []float32:
+			intersects = rangeIntersects(rng, b)
+		case []float64:
+			intersects = rangeIntersects(rng, b)
+		default:
+			intersects = true
+		}
+		if !intersects {
+			return false
+		}
+	}
+	return true
+}
+
+func rangeIntersects[T cmp.Ordered](rng Range, bounds []T) bool {
+	start, ok := rng.start.(T)
+	if !ok {
+		return true
+	}
+	return start <= bounds[1] && rng.end.(T) >= bounds[0]
+}
@@ -0,0 +1,133 @@
+package tiledb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitTileAligned(t *testing.T) {
+	nonEmpty := func(lo, hi int32) *NonEmptyDomain {
+		return &NonEmptyDomain{Bounds: []int32{lo, hi}}
+	}
+	domain := []int32{1, 100}
+	full := MakeRange[int32](1, 100)
+
+	assert.Equal(t, []Range{
+		MakeRange[int32](1, 30), MakeRange[int32](31, 60), MakeRange[int32](61, 80), MakeRange[int32](81, 100),
+	}, splitTileAligned[int32](full, domain, int32(10), nonEmpty(1, 100), 4))
+
+	// The boundaries are spread over the tiles of the non-empty domain.
+	assert.Equal(t, []Range{
+		MakeRange[int32](1, 50), MakeRange[int32](51, 100),
+	}, splitTileAligned[int32](full, domain, int32(10), nonEmpty(41, 60), 4))
+
+	// The range is not split when it spans a single tile.
+	assert.Equal(t, []Range{MakeRange[int32](5, 8)},
+		splitTileAligned[int32](MakeRange[int32](5, 8), domain, int32(10), nil, 4))
+
+	assert.Equal(t, []Range{
+		MakeRange[int8](-50, -26), MakeRange[int8](-25, -1), MakeRange[int8](0, 24), MakeRange[int8](25, 49),
+	}, splitTileAligned[int8](MakeRange[int8](-50, 49), []int8{-50, 49}, int8(25), nil, 8))
+}
+
+func TestParallelReaderDense(t *testing.T) {
+	arrPath := createDenseIntegerGrid(t, 16)
+
+	// write the full grid
+	arr := openArray(t, arrPath, TILEDB_WRITE)
+	q, err := NewQuery(arr.context, arr)
+	require.NoError(t, err)
+	sa, err := arr.NewSubarray()
+	require.NoError(t, err)
+	require.NoError(t, sa.AddRange(0, MakeRange[uint8](0, 15)))
+	require.NoError(t, sa.AddRange(1, MakeRange[uint8](0, 15)))
+	require.NoError(t, q.SetSubarray(sa))
+	data := make([]uint16, 16*16)
+	for i := range data {
+		data[i] = uint16(i)
+	}
+	_, err = q.SetDataBuffer("v", data)
+	require.NoError(t, err)
+	require.NoError(t, q.Submit())
+
+	arr = openArray(t, arrPath, TILEDB_READ)
+	sa, err = arr.NewSubarray()
+	require.NoError(t, err)
+
+	reader, err := NewParallelReader(sa, []string{"v"}, WithPartitions(4), WithWorkers(2),
+		WithReadLayout(TILEDB_ROW_MAJOR), WithPartitionIteratorOptions(WithInitialBufferBytes(64)))
+	require.NoError(t, err)
+	require.Len(t, reader.Partitions(), 4)
+	assert.Equal(t, []Range{MakeRange[uint8](4, 7), MakeRange[uint8](0, 15)}, reader.Partitions()[1])
+
+	var values []uint16
+	var index int
+	for res := range reader.Read(context.Background()) {
+		require.NoError(t, res.Err)
+		assert.Equal(t, index, res.Index)
+		index++
+		for _, batch := range res.Batches {
+			v, err := ResultBatchData[uint16](batch, "v")
+			require.NoError(t, err)
+			values = append(values, v...)
+		}
+	}
+	assert.Equal(t, data, values)
+}
+
+func TestParallelReaderSparse(t *testing.T) {
+	tdbCtx, err := NewContext(nil)
+	require.NoError(t, err)
+	arrPath := t.TempDir()
+
+	schema, err := NewArraySchema(tdbCtx, TILEDB_SPARSE)
+	require.NoError(t, err)
+	domain, err := createDomain(tdbCtx)
+	require.NoError(t, err)
+	require.NoError(t, schema.SetDomain(domain))
+	attr, err := NewAttribute(tdbCtx, "a", TILEDB_INT32)
+	require.NoError(t, err)
+	require.NoError(t, schema.AddAttributes(attr))
+	require.NoError(t, CreateArray(tdbCtx, arrPath, schema))
+
+	// Two fragments in opposite corners of the domain.
+	for _, cell := range []int32{1, 4} {
+		arr := openArray(t, arrPath, TILEDB_WRITE)
+		q, err := NewQuery(arr.context, arr)
+		require.NoError(t, err)
+		require.NoError(t, q.SetLayout(TILEDB_UNORDERED))
+		_, err = q.SetDataBuffer("rows", []int32{cell})
+		require.NoError(t, err)
+		_, err = q.SetDataBuffer("cols", []int32{cell})
+		require.NoError(t, err)
+		_, err = q.SetDataBuffer("a", []int32{cell * 10})
+		require.NoError(t, err)
+		require.NoError(t, q.Submit())
+	}
+
+	arr := openArray(t, arrPath, TILEDB_READ)
+	sa, err := arr.NewSubarray()
+	require.NoError(t, err)
+
+	reader, err := NewParallelReader(sa, nil, WithPartitions(4), WithOrderedResults(false))
+	require.NoError(t, err)
+	// The partitions without cells are skipped.
+	assert.Equal(t, [][]Range{
+		{MakeRange[int32](1, 2), MakeRange[int32](1, 2)},
+		{MakeRange[int32](3, 4), MakeRange[int32](3, 4)},
+	}, reader.Partitions())
+
+	var values []int32
+	for res := range reader.Read(context.Background()) {
+		require.NoError(t, res.Err)
+		for _, batch := range res.Batches {
+			a, err := ResultBatchData[int32](batch, "a")
+			require.NoError(t, err)
+			values = append(values, a...)
+		}
+	}
+	assert.ElementsMatch(t, []int32{10, 40}, values)
+}
@@ -13,6 +13,8 @@ import (
 	"reflect"
 	"runtime"
 	"sync"
+	"sync/atomic"
+	"time"
 	"unsafe"
 
 	"github.com/TileDB-Inc/TileDB-Go/bytesizes"
@@ -55,11 +57,17 @@ type Query struct {
 	context              *Context
 	config               *Config
 	bufferMutex          sync.Mutex
+	submitMutex          sync.Mutex
+	submitting           atomic.Bool
 	resultBufferElements map[string][3]*uint64
+	aggregates           map[string]Datatype
+	// ownsContext is set for queries created by NewCancellableQuery, whose context is
+	// their own and is freed with them.
+	ownsContext bool
 }
 
 func newQueryFromHandle(context *Context, array *Array, handle queryHandle) *Query {
-	return &Query{tiledbQuery: handle, array: array, context: context, resultBufferElements: make(map[string][3]*uint64)}
+	return &Query{tiledbQuery: handle, array: array, context: context, resultBufferElements: make(map[string][3]*uint64), aggregates: make(map[string]Datatype)}
 }
 
 // RangeLimits defines a query range
@@ -103,7 +111,7 @@ func NewQuery(tdbCtx *Context, array *Array) (*Query, error) {
 	ret := C.tiledb_query_alloc(tdbCtx.tiledbContext.Get(), array.tiledbArray.Get(), C.tiledb_query_type_t(queryType), &queryPtr)
 	runtime.KeepAlive(tdbCtx)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error creating tiledb query: %w", tdbCtx.LastError())
+		return nil, fmt.Errorf("error creating tiledb query: %w", tdbCtx.lastError("tiledb_query_alloc", ret))
 	}
 
 	return newQueryFromHandle(tdbCtx, array, newQueryHandle(queryPtr)), nil
@@ -115,7 +123,13 @@ func NewQuery(tdbCtx *Context, array *Array) (*Query, error) {
 // can safely be called many times on the same object; if it has already
 // been freed, it will not be freed again.
 func (q *Query) Free() {
+	// Wait for a submission abandoned by SubmitContext to finish.
+	q.submitMutex.Lock()
+	defer q.submitMutex.Unlock()
 	q.tiledbQuery.Free()
+	if q.ownsContext {
+		q.context.Free()
+	}
 }
 
 // Context exposes the internal TileDB context used to initialize the query.
@@ -200,6 +214,13 @@ func (q *Query) GetRanges() (map[string][]RangeLimits, error) {
 // second is number of elements in the data buffer. For fixed sized attributes
 // (and coordinates), the first is always 0.
 func (q *Query) ResultBufferElements() (map[string][3]uint64, error) {
+	if q.submitting.Load() {
+		return nil, errSubmitInFlight
+	}
+	return q.resultBufferElementsUnchecked()
+}
+
+func (q *Query) resultBufferElementsUnchecked() (map[string][3]uint64, error) {
 	elements := make(map[string][3]uint64)
 
 	// Will need the schema to infer data type size for attributes
@@ -259,7 +280,9 @@ func (q *Query) ResultBufferElements() (map[string][3]uint64, error) {
 				return nil, err
 			}
 
-			if hasDim {
+			if aggregateType, ok := q.aggregateDatatype(attributeOrDimension); ok {
+				datatype = aggregateType
+			} else if hasDim {
 				dimension, err := domain.DimensionFromName(attributeOrDimension)
 				if err != nil {
 					return nil, fmt.Errorf("could not get attribute or dimension for SetBuffer: %s", attributeOrDimension)
@@ -291,7 +314,7 @@ func (q *Query) ResultBufferElements() (map[string][3]uint64, error) {
 					return nil, fmt.Errorf("could not get dimension label type for ResultBufferElements: %w", err)
 				}
 			} else {
-				return nil, fmt.Errorf("error in ResultBufferElements for %s: "+
+				return nil, errorf(ErrNotFound, "error in ResultBufferElements for %s: "+
 					"Attribute/dimension/label does not exist", attributeOrDimension)
 			}
 
@@ -308,16 +331,33 @@ func (q *Query) ResultBufferElements() (map[string][3]uint64, error) {
 func (q *Query) SetLayout(layout Layout) error {
 	ret := C.tiledb_query_set_layout(q.context.tiledbContext.Get(), q.tiledbQuery.Get(), C.tiledb_layout_t(layout))
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting query layout: %w", q.context.LastError())
+		return fmt.Errorf("error setting query layout: %w", q.context.lastError("tiledb_query_set_layout", ret))
 	}
 	runtime.KeepAlive(q)
 	return nil
 }
 
-// SetQueryCondition sets a query condition on a read query.
+// SetQueryCondition sets a query condition on a read query. Conditions on times are
+// converted to the timestamps of the datatypes of the array's fields.
 func (q *Query) SetQueryCondition(cond *QueryCondition) error {
+	if cond.unresolved() {
+		schema, err := q.array.Schema()
+		if err != nil {
+			return fmt.Errorf("could not get array schema for SetQueryCondition: %w", err)
+		}
+		defer schema.Free()
+
+		resolved, err := cond.resolved(schema)
+		if err != nil {
+			return err
+		}
+		// TileDB copies the condition, so the resolved one can be freed once it is set.
+		defer resolved.Free()
+		cond = resolved
+	}
+
 	if ret := C.tiledb_query_set_condition(q.context.tiledbContext.Get(), q.tiledbQuery.Get(), cond.cond.Get()); ret != C.TILEDB_OK {
-		return fmt.Errorf("error getting config from query: %w", q.context.LastError())
+		return fmt.Errorf("error getting config from query: %w", q.context.lastError("tiledb_query_set_condition", ret))
 	}
 	runtime.KeepAlive(q)
 	return nil
@@ -330,7 +370,7 @@ func (q *Query) Finalize() error {
 	ret := C.tiledb_query_finalize(q.context.tiledbContext.Get(), q.tiledbQuery.Get())
 	runtime.KeepAlive(q)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error finalizing query: %w", q.context.LastError())
+		return fmt.Errorf("error finalizing query: %w", q.context.lastError("tiledb_query_finalize", ret))
 	}
 	return nil
 }
@@ -354,10 +394,77 @@ to proceed. In this case, the users must reallocate their buffers
 and resubmit the query.
 */
 func (q *Query) Submit() error {
+	q.submitMutex.Lock()
+	defer q.submitMutex.Unlock()
+	return q.submit()
+}
+
+// QueryResult is the outcome of a query submitted with SubmitAsync.
+type QueryResult struct {
+	// Status is the status of the query after the submission.
+	Status QueryStatus
+	// Elements are the numbers of elements in the result buffers, as returned by
+	// ResultBufferElements. It is nil if the submission failed.
+	Elements map[string][3]uint64
+	// Err is the error of the submission, if any.
+	Err error
+}
+
+// errSubmitInFlight is returned when the buffers of a query are used while
+// a submission started by SubmitAsync or abandoned by SubmitContext is in progress.
+var errSubmitInFlight = errors.New("the query has a submission in progress, its buffers cannot be used")
+
+/*
+SubmitAsync submits the query in a new goroutine and returns a channel that receives
+the result of the submission and is then closed:
+
+	results := make([]<-chan tiledb.QueryResult, len(queries))
+	for i, query := range queries {
+		results[i] = query.SubmitAsync()
+	}
+	for _, result := range results {
+		res := <-result
+		if res.Err != nil {
+			return res.Err
+		}
+		...
+	}
+
+While the submission is in progress, setting the buffers of the query and calling
+ResultBufferElements fail, while Submit, SubmitAsync and Free wait for it to finish.
+The buffers must not be read or modified until the result is received.
+*/
+func (q *Query) SubmitAsync() <-chan QueryResult {
+	result := make(chan QueryResult, 1)
+
+	q.submitMutex.Lock()
+	q.submitting.Store(true)
+	go func() {
+		var res QueryResult
+		if res.Err = q.submit(); res.Err == nil {
+			res.Elements, res.Err = q.resultBufferElementsUnchecked()
+		}
+		status, err := q.Status()
+		if err != nil && res.Err == nil {
+			res.Err = err
+		}
+		res.Status = status
+
+		// The query is usable again once the result is received.
+		q.submitting.Store(false)
+		q.submitMutex.Unlock()
+		result <- res
+		close(result)
+	}()
+
+	return result
+}
+
+func (q *Query) submit() error {
 	ret := C.tiledb_query_submit(q.context.tiledbContext.Get(), q.tiledbQuery.Get())
 	runtime.KeepAlive(q)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error submitting query: %w", q.context.LastError())
+		return fmt.Errorf("error submitting query: %w", q.context.lastError("tiledb_query_submit", ret))
 	}
 
 	return nil
@@ -369,7 +476,7 @@ func (q *Query) Status() (QueryStatus, error) {
 	ret := C.tiledb_query_get_status(q.context.tiledbContext.Get(), q.tiledbQuery.Get(), &status)
 	runtime.KeepAlive(q)
 	if ret != C.TILEDB_OK {
-		return -1, fmt.Errorf("error getting query status: %w", q.context.LastError())
+		return -1, fmt.Errorf("error getting query status: %w", q.context.lastError("tiledb_query_get_status", ret))
 	}
 	return QueryStatus(status), nil
 }
@@ -380,7 +487,7 @@ func (q *Query) Type() (QueryType, error) {
 	ret := C.tiledb_query_get_type(q.context.tiledbContext.Get(), q.tiledbQuery.Get(), &queryType)
 	runtime.KeepAlive(q)
 	if ret != C.TILEDB_OK {
-		return -1, fmt.Errorf("error getting query type: %w", q.context.LastError())
+		return -1, fmt.Errorf("error getting query type: %w", q.context.lastError("tiledb_query_get_type", ret))
 	}
 	return QueryType(queryType), nil
 }
@@ -392,7 +499,7 @@ func (q *Query) HasResults() (bool, error) {
 	ret := C.tiledb_query_has_results(q.context.tiledbContext.Get(), q.tiledbQuery.Get(), &hasResults)
 	runtime.KeepAlive(q)
 	if ret != C.TILEDB_OK {
-		return false, fmt.Errorf("error checking if query has results: %w", q.context.LastError())
+		return false, fmt.Errorf("error checking if query has results: %w", q.context.lastError("tiledb_query_has_results", ret))
 	}
 	return int(hasResults) == 1, nil
 }
@@ -411,7 +518,7 @@ func (q *Query) EstResultSize(attributeName string) (*uint64, error) {
 		(*C.uint64_t)(unsafe.Pointer(&size)))
 	runtime.KeepAlive(q)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error estimating query result size: %w", q.context.LastError())
+		return nil, fmt.Errorf("error estimating query result size: %w", q.context.lastError("tiledb_query_get_est_result_size", ret))
 	}
 
 	return &size, nil
@@ -432,7 +539,7 @@ func (q *Query) EstResultSizeVar(attributeName string) (*uint64, *uint64, error)
 		(*C.uint64_t)(unsafe.Pointer(&sizeVal)))
 	runtime.KeepAlive(q)
 	if ret != C.TILEDB_OK {
-		return nil, nil, fmt.Errorf("error estimating query result var size: %w", q.context.LastError())
+		return nil, nil, fmt.Errorf("error estimating query result var size: %w", q.context.lastError("tiledb_query_get_est_result_size_var", ret))
 	}
 
 	return &sizeOff, &sizeVal, nil
@@ -453,7 +560,7 @@ func (q *Query) EstResultSizeNullable(attributeName string) (*uint64, *uint64, e
 		(*C.uint64_t)(unsafe.Pointer(&sizeValidity)))
 	runtime.KeepAlive(q)
 	if ret != C.TILEDB_OK {
-		return nil, nil, fmt.Errorf("error estimating query result size: %w", q.context.LastError())
+		return nil, nil, fmt.Errorf("error estimating query result size: %w", q.context.lastError("tiledb_query_get_est_result_size_nullable", ret))
 	}
 
 	return &size, &sizeValidity, nil
@@ -475,7 +582,7 @@ func (q *Query) EstResultSizeVarNullable(attributeName string) (*uint64, *uint64
 		(*C.uint64_t)(unsafe.Pointer(&sizeValidity)))
 	runtime.KeepAlive(q)
 	if ret != C.TILEDB_OK {
-		return nil, nil, nil, fmt.Errorf("error estimating query result var size: %w", q.context.LastError())
+		return nil, nil, nil, fmt.Errorf("error estimating query result var size: %w", q.context.lastError("tiledb_query_get_est_result_size_var_nullable", ret))
 	}
 
 	return &sizeOff, &sizeVal, &sizeValidity, nil
@@ -659,7 +766,7 @@ func (q *Query) GetFragmentNum() (*uint32, error) {
 		(*C.uint32_t)(unsafe.Pointer(&num)))
 	runtime.KeepAlive(q)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting num of fragments: %w", q.context.LastError())
+		return nil, fmt.Errorf("error getting num of fragments: %w", q.context.lastError("tiledb_query_get_fragment_num", ret))
 	}
 
 	return &num, nil
@@ -675,7 +782,7 @@ func (q *Query) GetFragmentURI(num uint64) (*string, error) {
 		(C.uint64_t)(num),
 		&cURI)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error uri for fragment : %d", q.context.LastError())
+		return nil, fmt.Errorf("error uri for fragment %d: %w", num, q.context.lastError("tiledb_query_get_fragment_uri", ret))
 	}
 
 	uri := C.GoString(cURI)
@@ -697,7 +804,7 @@ func (q *Query) GetFragmentTimestampRange(num uint64) (*uint64, *uint64, error)
 		(*C.uint64_t)(unsafe.Pointer(&t2)))
 	runtime.KeepAlive(q)
 	if ret != C.TILEDB_OK {
-		return nil, nil, fmt.Errorf("error getting fragment timestamp: %w", q.context.LastError())
+		return nil, nil, fmt.Errorf("error getting fragment timestamp: %w", q.context.lastError("tiledb_query_get_fragment_timestamp_range", ret))
 	}
 
 	return &t1, &t2, nil
@@ -709,7 +816,7 @@ func (q *Query) Array() (*Array, error) {
 	ret := C.tiledb_query_get_array(q.context.tiledbContext.Get(), q.tiledbQuery.Get(), &arrayPtr)
 	runtime.KeepAlive(q)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting array from query: %w", q.context.LastError())
+		return nil, fmt.Errorf("error getting array from query: %w", q.context.lastError("tiledb_query_get_array", ret))
 	}
 	return newArrayFromHandle(q.context, newArrayHandle(arrayPtr)), nil
 }
@@ -722,7 +829,7 @@ func (q *Query) SetConfig(config *Config) error {
 	runtime.KeepAlive(q)
 	runtime.KeepAlive(config)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting config on query: %w", q.context.LastError())
+		return fmt.Errorf("error setting config on query: %w", q.context.lastError("tiledb_query_set_config", ret))
 	}
 
 	return nil
@@ -734,7 +841,7 @@ func (q *Query) Config() (*Config, error) {
 	ret := C.tiledb_query_get_config(q.context.tiledbContext.Get(), q.tiledbQuery.Get(), &configPtr)
 	runtime.KeepAlive(q)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting config from query: %w", q.context.LastError())
+		return nil, fmt.Errorf("error getting config from query: %w", q.context.lastError("tiledb_query_get_config", ret))
 	}
 
 	return newConfigFromHandle(newConfigHandle(configPtr)), nil
@@ -744,7 +851,7 @@ func (q *Query) Config() (*Config, error) {
 func (q *Query) Stats() ([]byte, error) {
 	var stats *C.char
 	if ret := C.tiledb_query_get_stats(q.context.tiledbContext.Get(), q.tiledbQuery.Get(), &stats); ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting stats from query: %w", q.context.LastError())
+		return nil, fmt.Errorf("error getting stats from query: %w", q.context.lastError("tiledb_query_get_stats", ret))
 	}
 	runtime.KeepAlive(q)
 
@@ -775,6 +882,9 @@ func (q *Query) setResultBufferPointer(attribute string, pos int, ptr *uint64) {
 // SetDataBufferUnsafe sets the buffer for a fixed-sized attribute to a query.
 // This takes an unsafe pointer which is passsed straight to tiledb c_api for advanced usage.
 func (q *Query) SetDataBufferUnsafe(attribute string, buffer unsafe.Pointer, bufferSize uint64) (*uint64, error) {
+	if q.submitting.Load() {
+		return nil, errSubmitInFlight
+	}
 	q.bufferMutex.Lock()
 	defer q.bufferMutex.Unlock()
 
@@ -793,7 +903,7 @@ func (q *Query) SetDataBufferUnsafe(attribute string, buffer unsafe.Pointer, buf
 	runtime.KeepAlive(q)
 
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error setting query data buffer: %w", q.context.LastError())
+		return nil, fmt.Errorf("error setting query data buffer: %w", q.context.lastError("tiledb_query_set_data_buffer", ret))
 	}
 
 	q.setResultBufferPointer(attribute, 1, &bufferSize)
@@ -801,8 +911,34 @@ func (q *Query) SetDataBufferUnsafe(attribute string, buffer unsafe.Pointer, buf
 	return &bufferSize, nil
 }
 
+// emptyDataBuffer is the data buffer set by setEmptyDataBuffer. TileDB does not access
+// the contents of an empty buffer, so all queries share it.
+var emptyDataBuffer [1]byte
+
+// setEmptyDataBuffer sets an empty data buffer for a var-sized attribute/dimension.
+// Var-sized fields where every cell is empty or null have no data, but TileDB still
+// requires a valid data buffer.
+func (q *Query) setEmptyDataBuffer(attributeOrDimension string) error {
+	_, err := q.SetDataBufferUnsafe(attributeOrDimension, unsafe.Pointer(&emptyDataBuffer[0]), 0)
+	return err
+}
+
 // SetDataBuffer sets the buffer for a fixed-sized attribute to a query.
+// The buffer is either a slice or a *HostBuffer, which is set as is. The buffer of a datetime or time
+// field may also be a []time.Time, which is converted to the timestamps of the
+// field's Datatype; read its results with GetTimeResults.
 func (q *Query) SetDataBuffer(attributeOrDimension string, buffer interface{}) (*uint64, error) {
+	if q.submitting.Load() {
+		return nil, errSubmitInFlight
+	}
+	// Host buffers are set without a schema lookup, so the datatype of their values is not checked.
+	if hb, ok := buffer.(hostBuffer); ok {
+		cbuffer, bufferSize, err := hb.cBuffer()
+		if err != nil {
+			return nil, fmt.Errorf("error setting query data buffer of %s: %w", attributeOrDimension, err)
+		}
+		return q.SetDataBufferUnsafe(attributeOrDimension, cbuffer, bufferSize)
+	}
 	bufferReflectType := reflect.TypeOf(buffer)
 	bufferReflectValue := reflect.ValueOf(buffer)
 	if bufferReflectValue.Kind() != reflect.Slice {
@@ -827,7 +963,9 @@ func (q *Query) SetDataBuffer(attributeOrDimension string, buffer interface{}) (
 	// If we are setting tiledb coordinates for a sparse array we want to check
 	// the domain type. The TILEDB_COORDS attribute is only materialized after
 	// the first write
-	if attributeOrDimension == TILEDB_COORDS {
+	if aggregateType, ok := q.aggregateDatatype(attributeOrDimension); ok {
+		attributeOrDimensionType = aggregateType
+	} else if attributeOrDimension == TILEDB_COORDS {
 		attributeOrDimensionType, err = domain.Type()
 		if err != nil {
 			return nil, fmt.Errorf("could not get domainType for SetDataBuffer: %s", attributeOrDimension)
@@ -881,14 +1019,23 @@ func (q *Query) SetDataBuffer(attributeOrDimension string, buffer interface{}) (
 					attributeOrDimension)
 			}
 		} else {
-			return nil, fmt.Errorf("error in SetDataBuffer for %s: "+
+			return nil, errorf(ErrNotFound, "error in SetDataBuffer for %s: "+
 				"Attribute/dimension/label does not exist", attributeOrDimension)
 		}
 	}
 
+	if times, ok := buffer.([]time.Time); ok {
+		timestamps, err := TimestampsFromTimes(attributeOrDimensionType, times)
+		if err != nil {
+			return nil, fmt.Errorf("error converting times of %s for SetDataBuffer: %w", attributeOrDimension, err)
+		}
+		bufferReflectType = reflect.TypeOf(timestamps)
+		bufferReflectValue = reflect.ValueOf(timestamps)
+	}
+
 	bufferType := bufferReflectType.Elem().Kind()
 	if attributeOrDimensionType.ReflectKind() != bufferType {
-		return nil, fmt.Errorf("buffer and attribute do not have the same data types. Buffer: %s, Attribute: %s",
+		return nil, errorf(ErrSchemaMismatch, "buffer and attribute do not have the same data types. Buffer: %s, Attribute: %s",
 			bufferType.String(),
 			attributeOrDimensionType.ReflectKind().String())
 	}
@@ -966,7 +1113,7 @@ func (q *Query) SetDataBuffer(attributeOrDimension string, buffer interface{}) (
 	// cbuffer is being kept alive by passing it to cgo call.
 
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error setting query data buffer: %w", q.context.LastError())
+		return nil, fmt.Errorf("error setting query data buffer: %w", q.context.lastError("tiledb_query_set_data_buffer", ret))
 	}
 
 	q.setResultBufferPointer(attributeOrDimension, 1, &bufferSize)
@@ -981,6 +1128,37 @@ func (q *Query) GetDataBuffer(attributeOrDimension string) (interface{}, error)
 	return buf, err
 }
 
+// GetTimeResults returns the times read into the data buffer of a datetime or time attribute
+// or dimension by the last submission of the query.
+func (q *Query) GetTimeResults(attributeOrDimension string) ([]time.Time, error) {
+	schema, err := q.array.Schema()
+	if err != nil {
+		return nil, fmt.Errorf("could not get array schema for GetTimeResults: %w", err)
+	}
+	defer schema.Free()
+
+	info, err := schemaFieldInfo(schema, attributeOrDimension)
+	if err != nil {
+		return nil, err
+	}
+	if !isTimeDatatype(info.datatype) {
+		return nil, errorf(ErrSchemaMismatch, "cannot get times of %s: %v is not a datetime or time datatype", attributeOrDimension, info.datatype)
+	}
+
+	timestamps, err := dataResults[int64](q, attributeOrDimension)
+	if err != nil {
+		return nil, err
+	}
+	elements, err := q.ResultBufferElements()
+	if err != nil {
+		return nil, err
+	}
+	if n := elements[attributeOrDimension][1]; n < uint64(len(timestamps)) {
+		timestamps = timestamps[:n]
+	}
+	return TimesFromTimestamps(info.datatype, timestamps), nil
+}
+
 // GetExpectedDataBufferLength retrieves the size of the data buffer of an attribute/dimension.
 // This is equivalent to calling GetDataBuffer and taking the length of the returned buffer except
 // in the case of a deserialized server side read query where GetDataBuffer returns nil.
@@ -1007,7 +1185,9 @@ func (q *Query) getDataBufferAndSize(attributeOrDimension string) (interface{},
 		return nil, 0, fmt.Errorf("could not get domain from array schema for GetDataBuffer: %w", err)
 	}
 
-	if attributeOrDimension == TILEDB_COORDS {
+	if aggregateType, ok := q.aggregateDatatype(attributeOrDimension); ok {
+		datatype = aggregateType
+	} else if attributeOrDimension == TILEDB_COORDS {
 		datatype, err = domain.Type()
 		if err != nil {
 			return nil, 0, err
@@ -1055,7 +1235,7 @@ func (q *Query) getDataBufferAndSize(attributeOrDimension string) (interface{},
 					attributeOrDimension)
 			}
 		} else {
-			return nil, 0, fmt.Errorf("error in getDataBufferAndSize for %s: "+
+			return nil, 0, errorf(ErrNotFound, "error in getDataBufferAndSize for %s: "+
 				"Attribute/dimension/label does not exist", attributeOrDimension)
 		}
 	}
@@ -1072,7 +1252,7 @@ func (q *Query) getDataBufferAndSize(attributeOrDimension string) (interface{},
 	runtime.KeepAlive(q)
 	// cbuffer and cbufferSize are in Go-owned memory and don't need a KeepAlive.
 	if ret != C.TILEDB_OK {
-		return nil, 0, fmt.Errorf("error getting tiledb query data buffer for %s: %w", attributeOrDimension, q.context.LastError())
+		return nil, 0, fmt.Errorf("error getting tiledb query data buffer for %s: %w", attributeOrDimension, q.context.lastError("tiledb_query_get_data_buffer", ret))
 	}
 
 	var dataNumElements uint64
@@ -1170,6 +1350,9 @@ func (q *Query) getDataBufferAndSize(attributeOrDimension string) (interface{},
 // SetValidityBufferUnsafe sets the validity buffer for nullable attribute/dimension.
 // This takes an unsafe pointer which is passed straight to tiledb c_api for advanced usage.
 func (q *Query) SetValidityBufferUnsafe(attribute string, buffer unsafe.Pointer, bufferSize uint64) (*uint64, error) {
+	if q.submitting.Load() {
+		return nil, errSubmitInFlight
+	}
 	q.bufferMutex.Lock()
 	defer q.bufferMutex.Unlock()
 
@@ -1188,7 +1371,7 @@ func (q *Query) SetValidityBufferUnsafe(attribute string, buffer unsafe.Pointer,
 	runtime.KeepAlive(q)
 
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error setting query validity buffer: %w", q.context.LastError())
+		return nil, fmt.Errorf("error setting query validity buffer: %w", q.context.lastError("tiledb_query_set_validity_buffer", ret))
 	}
 
 	q.setResultBufferPointer(attribute, 2, &bufferSize)
@@ -1196,8 +1379,20 @@ func (q *Query) SetValidityBufferUnsafe(attribute string, buffer unsafe.Pointer,
 	return &bufferSize, nil
 }
 
+// SetValidityHostBuffer sets a HostBuffer as the validity buffer for nullable attribute/dimension.
+func (q *Query) SetValidityHostBuffer(attributeOrDimension string, buffer *HostBuffer[uint8]) (*uint64, error) {
+	cbuffer, bufferSize, err := buffer.cBuffer()
+	if err != nil {
+		return nil, fmt.Errorf("error setting query validity buffer of %s: %w", attributeOrDimension, err)
+	}
+	return q.SetValidityBufferUnsafe(attributeOrDimension, cbuffer, bufferSize)
+}
+
 // SetValidityBuffer sets the validity buffer for nullable attribute/dimension.
 func (q *Query) SetValidityBuffer(attributeOrDimension string, buffer []uint8) (*uint64, error) {
+	if q.submitting.Load() {
+		return nil, errSubmitInFlight
+	}
 	q.bufferMutex.Lock()
 	defer q.bufferMutex.Unlock()
 
@@ -1222,7 +1417,7 @@ func (q *Query) SetValidityBuffer(attributeOrDimension string, buffer []uint8) (
 	runtime.KeepAlive(q)
 
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error setting query validity buffer: %w", q.context.LastError())
+		return nil, fmt.Errorf("error setting query validity buffer: %w", q.context.lastError("tiledb_query_set_validity_buffer", ret))
 	}
 
 	q.setResultBufferPointer(attributeOrDimension, 2, &bufferSize)
@@ -1260,7 +1455,7 @@ func (q *Query) getValidityBufferAndSize(attributeOrDimension string) ([]uint8,
 	runtime.KeepAlive(q)
 	// cvalidityByteMapSize and cvalidityByteMap are in Go-owned memory and do not need a KeepAlive.
 	if ret != C.TILEDB_OK {
-		return nil, 0, fmt.Errorf("error getting tiledb query validity buffer for %s: %w", attributeOrDimension, q.context.LastError())
+		return nil, 0, fmt.Errorf("error getting tiledb query validity buffer for %s: %w", attributeOrDimension, q.context.lastError("tiledb_query_get_validity_buffer", ret))
 	}
 
 	var validityNumElements uint64
@@ -1283,6 +1478,9 @@ func (q *Query) getValidityBufferAndSize(attributeOrDimension string) ([]uint8,
 // SetOffsetsBufferUnsafe sets the offset buffer for a var-sized attribute/dimension.
 // This takes an unsafe pointer which is passed straight to tiledb c_api for advanced usage.
 func (q *Query) SetOffsetsBufferUnsafe(attribute string, offset unsafe.Pointer, offsetSize uint64) (*uint64, error) {
+	if q.submitting.Load() {
+		return nil, errSubmitInFlight
+	}
 	q.bufferMutex.Lock()
 	defer q.bufferMutex.Unlock()
 
@@ -1301,7 +1499,7 @@ func (q *Query) SetOffsetsBufferUnsafe(attribute string, offset unsafe.Pointer,
 	runtime.KeepAlive(q)
 
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error setting query offsets buffer: %w", q.context.LastError())
+		return nil, fmt.Errorf("error setting query offsets buffer: %w", q.context.lastError("tiledb_query_set_offsets_buffer", ret))
 	}
 
 	q.setResultBufferPointer(attribute, 0, &offsetSize)
@@ -1309,8 +1507,20 @@ func (q *Query) SetOffsetsBufferUnsafe(attribute string, offset unsafe.Pointer,
 	return &offsetSize, nil
 }
 
+// SetOffsetsHostBuffer sets a HostBuffer as the offset buffer for a var-sized attribute/dimension.
+func (q *Query) SetOffsetsHostBuffer(attributeOrDimension string, offset *HostBuffer[uint64]) (*uint64, error) {
+	cbuffer, bufferSize, err := offset.cBuffer()
+	if err != nil {
+		return nil, fmt.Errorf("error setting query offsets buffer of %s: %w", attributeOrDimension, err)
+	}
+	return q.SetOffsetsBufferUnsafe(attributeOrDimension, cbuffer, bufferSize)
+}
+
 // SetOffsetsBuffer sets the offset buffer for a var-sized attribute/dimension.
 func (q *Query) SetOffsetsBuffer(attributeOrDimension string, offset []uint64) (*uint64, error) {
+	if q.submitting.Load() {
+		return nil, errSubmitInFlight
+	}
 	q.bufferMutex.Lock()
 	defer q.bufferMutex.Unlock()
 
@@ -1335,7 +1545,7 @@ func (q *Query) SetOffsetsBuffer(attributeOrDimension string, offset []uint64) (
 	runtime.KeepAlive(q)
 
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error setting query offsets buffer: %w", q.context.LastError())
+		return nil, fmt.Errorf("error setting query offsets buffer: %w", q.context.lastError("tiledb_query_set_offsets_buffer", ret))
 	}
 
 	q.setResultBufferPointer(attributeOrDimension, 0, &offsetSize)
@@ -1373,7 +1583,7 @@ func (q *Query) getOffsetsBufferAndSize(attributeOrDimension string) ([]uint64,
 	runtime.KeepAlive(q)
 	// coffsetsSize and coffsets point to Go-owned memory and do not need a KeepAlive
 	if ret != C.TILEDB_OK {
-		return nil, 0, fmt.Errorf("error getting tiledb query offset buffer for %s: %w", attributeOrDimension, q.context.LastError())
+		return nil, 0, fmt.Errorf("error getting tiledb query offset buffer for %s: %w", attributeOrDimension, q.context.lastError("tiledb_query_get_offsets_buffer", ret))
 	}
 
 	var offsetNumElements uint64
@@ -1399,7 +1609,7 @@ func (q *Query) SetSubarray(sa *Subarray) error {
 	runtime.KeepAlive(q)
 	runtime.KeepAlive(sa)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting tiledb query subarray: %w", q.context.LastError())
+		return fmt.Errorf("error setting tiledb query subarray: %w", q.context.lastError("tiledb_query_set_subarray_t", ret))
 	}
 	return nil
 }
@@ -1411,7 +1621,7 @@ func (q *Query) GetSubarray() (*Subarray, error) {
 	ret := C.tiledb_query_get_subarray_t(q.context.tiledbContext.Get(), q.tiledbQuery.Get(), &sa)
 	runtime.KeepAlive(q)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error getting tiledb query subarray: %w", q.context.LastError())
+		return nil, fmt.Errorf("error getting tiledb query subarray: %w", q.context.lastError("tiledb_query_get_subarray_t", ret))
 	}
 
 	return newSubarrayFromHandle(q.context, q.array, newSubarrayHandle(sa)), nil
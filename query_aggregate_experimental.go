@@ -0,0 +1,255 @@
+package tiledb
+
+/*
+#include <tiledb/tiledb.h>
+#include <tiledb/tiledb_experimental.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"unsafe"
+)
+
+// AggregateOperator is a built-in aggregate that can be applied to a query channel.
+type AggregateOperator uint8
+
+const (
+	// TILEDB_AGGREGATE_COUNT counts the cells of the query. It takes no input field. The output is a uint64.
+	TILEDB_AGGREGATE_COUNT AggregateOperator = iota
+	// TILEDB_AGGREGATE_SUM sums a numeric field. The output is an int64, uint64 or float64
+	// depending on whether the field is a signed integer, an unsigned integer or a float.
+	TILEDB_AGGREGATE_SUM
+	// TILEDB_AGGREGATE_MIN is the minimum value of a field. The output has the type of the field.
+	TILEDB_AGGREGATE_MIN
+	// TILEDB_AGGREGATE_MAX is the maximum value of a field. The output has the type of the field.
+	TILEDB_AGGREGATE_MAX
+	// TILEDB_AGGREGATE_MEAN is the mean of a numeric field. The output is a float64.
+	TILEDB_AGGREGATE_MEAN
+	// TILEDB_AGGREGATE_NULL_COUNT counts the null cells of a nullable attribute. The output is a uint64.
+	TILEDB_AGGREGATE_NULL_COUNT
+)
+
+// String returns a string representation.
+func (op AggregateOperator) String() string {
+	switch op {
+	case TILEDB_AGGREGATE_COUNT:
+		return "COUNT"
+	case TILEDB_AGGREGATE_SUM:
+		return "SUM"
+	case TILEDB_AGGREGATE_MIN:
+		return "MIN"
+	case TILEDB_AGGREGATE_MAX:
+		return "MAX"
+	case TILEDB_AGGREGATE_MEAN:
+		return "MEAN"
+	case TILEDB_AGGREGATE_NULL_COUNT:
+		return "NULL_COUNT"
+	}
+	return fmt.Sprintf("AggregateOperator(%d)", uint8(op))
+}
+
+// outputDatatype returns the datatype of the aggregate of a field.
+func (op AggregateOperator) outputDatatype(field fieldInfo) (Datatype, error) {
+	switch op {
+	case TILEDB_AGGREGATE_COUNT:
+		return TILEDB_UINT64, nil
+	case TILEDB_AGGREGATE_NULL_COUNT:
+		if !field.nullable {
+			return TILEDB_ANY, fmt.Errorf("%v requires a nullable attribute, %s is not nullable", op, field.name)
+		}
+		return TILEDB_UINT64, nil
+	case TILEDB_AGGREGATE_MIN, TILEDB_AGGREGATE_MAX:
+		return field.datatype, nil
+	case TILEDB_AGGREGATE_SUM, TILEDB_AGGREGATE_MEAN:
+		if field.isVar() || field.cellValNum > 1 {
+			return TILEDB_ANY, fmt.Errorf("%v requires a field with a single value per cell, %s has %d", op, field.name, field.cellValNum)
+		}
+		kind := field.datatype.ReflectKind()
+		switch {
+		case op == TILEDB_AGGREGATE_MEAN && kind >= reflect.Int8 && kind <= reflect.Float64:
+			return TILEDB_FLOAT64, nil
+		case kind >= reflect.Int8 && kind <= reflect.Int64:
+			return TILEDB_INT64, nil
+		case kind >= reflect.Uint8 && kind <= reflect.Uint64:
+			return TILEDB_UINT64, nil
+		case kind == reflect.Float32 || kind == reflect.Float64:
+			return TILEDB_FLOAT64, nil
+		}
+		return TILEDB_ANY, fmt.Errorf("%v requires a numeric field, %s is %v", op, field.name, field.datatype)
+	}
+	return TILEDB_ANY, fmt.Errorf("unrecognized aggregate operator: %v", op)
+}
+
+// cOperator returns the C API handle of a unary aggregate operator.
+func (op AggregateOperator) cOperator(tdbCtx *Context) (*C.tiledb_channel_operator_t, error) {
+	var cOp *C.tiledb_channel_operator_t
+	var ret C.int32_t
+	var cFunc string
+	switch op {
+	case TILEDB_AGGREGATE_SUM:
+		cFunc = "tiledb_channel_operator_sum_get"
+		ret = C.tiledb_channel_operator_sum_get(tdbCtx.tiledbContext.Get(), &cOp)
+	case TILEDB_AGGREGATE_MIN:
+		cFunc = "tiledb_channel_operator_min_get"
+		ret = C.tiledb_channel_operator_min_get(tdbCtx.tiledbContext.Get(), &cOp)
+	case TILEDB_AGGREGATE_MAX:
+		cFunc = "tiledb_channel_operator_max_get"
+		ret = C.tiledb_channel_operator_max_get(tdbCtx.tiledbContext.Get(), &cOp)
+	case TILEDB_AGGREGATE_MEAN:
+		cFunc = "tiledb_channel_operator_mean_get"
+		ret = C.tiledb_channel_operator_mean_get(tdbCtx.tiledbContext.Get(), &cOp)
+	case TILEDB_AGGREGATE_NULL_COUNT:
+		cFunc = "tiledb_channel_operator_null_count_get"
+		ret = C.tiledb_channel_operator_null_count_get(tdbCtx.tiledbContext.Get(), &cOp)
+	default:
+		return nil, fmt.Errorf("%v is not a unary aggregate operator", op)
+	}
+	runtime.KeepAlive(tdbCtx)
+	if ret != C.TILEDB_OK {
+		return nil, fmt.Errorf("error getting %v aggregate operator: %w", op, tdbCtx.lastError(cFunc, ret))
+	}
+	return cOp, nil
+}
+
+/*
+QueryChannel is a channel of a read query through which aggregates are computed.
+Aggregates are computed over the cells selected by the query's subarray and query
+condition, and each aggregate produces a single value under its output name. The
+output is read by setting a data buffer (and an offsets buffer for var-sized MIN
+and MAX, and a validity buffer for aggregates of nullable attributes) on the query
+like for any other field:
+
+	channel, err := query.DefaultChannel()
+	if err != nil {
+		return err
+	}
+	if err := channel.ApplyAggregate("total", TILEDB_AGGREGATE_SUM, "a1"); err != nil {
+		return err
+	}
+	total := make([]int64, 1)
+	if _, err := query.SetDataBuffer("total", total); err != nil {
+		return err
+	}
+	err = query.Submit()
+*/
+type QueryChannel struct {
+	query *Query
+}
+
+// DefaultChannel returns the default channel of the query, which covers all the cells
+// the query selects.
+func (q *Query) DefaultChannel() (*QueryChannel, error) {
+	queryType, err := q.Type()
+	if err != nil {
+		return nil, err
+	}
+	if queryType != TILEDB_READ {
+		return nil, errors.New("error getting default channel: aggregates require a read query")
+	}
+
+	// The C API channel handle is acquired whenever it is used; check it can be acquired.
+	if err := q.withDefaultChannel(func(*C.tiledb_query_channel_t) error { return nil }); err != nil {
+		return nil, err
+	}
+
+	return &QueryChannel{query: q}, nil
+}
+
+// withDefaultChannel calls f with the C API handle of the default channel of the query.
+// The handle is freed when f returns.
+func (q *Query) withDefaultChannel(f func(channel *C.tiledb_query_channel_t) error) error {
+	var channel *C.tiledb_query_channel_t
+	ret := C.tiledb_query_get_default_channel(q.context.tiledbContext.Get(), q.tiledbQuery.Get(), &channel)
+	if ret != C.TILEDB_OK {
+		return fmt.Errorf("error getting default channel: %w", q.context.lastError("tiledb_query_get_default_channel", ret))
+	}
+	defer C.tiledb_query_channel_free(q.context.tiledbContext.Get(), &channel)
+	err := f(channel)
+	runtime.KeepAlive(q)
+	return err
+}
+
+// ApplyAggregate computes the aggregate op of field and makes it available as the output
+// field outputName. field is ignored for TILEDB_AGGREGATE_COUNT.
+func (c *QueryChannel) ApplyAggregate(outputName string, op AggregateOperator, field string) error {
+	q := c.query
+	if outputName == "" {
+		return errors.New("error applying aggregate: output name is empty")
+	}
+	if _, ok := q.aggregateDatatype(outputName); ok {
+		return fmt.Errorf("error applying aggregate: output %s is already used", outputName)
+	}
+
+	var info fieldInfo
+	if op != TILEDB_AGGREGATE_COUNT {
+		schema, err := q.array.Schema()
+		if err != nil {
+			return fmt.Errorf("could not get array schema for ApplyAggregate: %w", err)
+		}
+		info, err = schemaFieldInfo(schema, field)
+		schema.Free()
+		if err != nil {
+			return fmt.Errorf("error applying aggregate %v to %s: %w", op, field, err)
+		}
+	}
+	datatype, err := op.outputDatatype(info)
+	if err != nil {
+		return fmt.Errorf("error applying aggregate: %w", err)
+	}
+
+	cOutputName := C.CString(outputName)
+	defer C.free(unsafe.Pointer(cOutputName))
+
+	err = q.withDefaultChannel(func(channel *C.tiledb_query_channel_t) error {
+		if op == TILEDB_AGGREGATE_COUNT {
+			var operation *C.tiledb_channel_operation_t
+			if ret := C.tiledb_aggregate_count_get(q.context.tiledbContext.Get(), &operation); ret != C.TILEDB_OK {
+				return fmt.Errorf("error getting count aggregate: %w", q.context.lastError("tiledb_aggregate_count_get", ret))
+			}
+			if ret := C.tiledb_channel_apply_aggregate(q.context.tiledbContext.Get(), channel, cOutputName, operation); ret != C.TILEDB_OK {
+				return fmt.Errorf("error applying count aggregate: %w", q.context.lastError("tiledb_channel_apply_aggregate", ret))
+			}
+			return nil
+		}
+
+		cOp, err := op.cOperator(q.context)
+		if err != nil {
+			return err
+		}
+
+		cField := C.CString(field)
+		defer C.free(unsafe.Pointer(cField))
+
+		var operation *C.tiledb_channel_operation_t
+		if ret := C.tiledb_create_unary_aggregate(q.context.tiledbContext.Get(), q.tiledbQuery.Get(), cOp, cField, &operation); ret != C.TILEDB_OK {
+			return fmt.Errorf("error creating %v aggregate of %s: %w", op, field, q.context.lastError("tiledb_create_unary_aggregate", ret))
+		}
+		defer C.tiledb_aggregate_free(q.context.tiledbContext.Get(), &operation)
+
+		if ret := C.tiledb_channel_apply_aggregate(q.context.tiledbContext.Get(), channel, cOutputName, operation); ret != C.TILEDB_OK {
+			return fmt.Errorf("error applying %v aggregate of %s: %w", op, field, q.context.lastError("tiledb_channel_apply_aggregate", ret))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	q.bufferMutex.Lock()
+	defer q.bufferMutex.Unlock()
+	q.aggregates[outputName] = datatype
+	return nil
+}
+
+// aggregateDatatype returns the datatype of the output of the aggregate named name, if any.
+func (q *Query) aggregateDatatype(name string) (Datatype, bool) {
+	q.bufferMutex.Lock()
+	defer q.bufferMutex.Unlock()
+	datatype, ok := q.aggregates[name]
+	return datatype, ok
+}
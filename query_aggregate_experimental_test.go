@@ -0,0 +1,114 @@
+package tiledb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryChannelAggregates(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+
+	newAggregateQuery := func(t *testing.T) (*Query, *QueryChannel) {
+		query, err := NewQuery(array.context, array)
+		require.NoError(t, err)
+		require.NoError(t, query.SetLayout(TILEDB_UNORDERED))
+		channel, err := query.DefaultChannel()
+		require.NoError(t, err)
+		return query, channel
+	}
+
+	t.Run("AllOperators", func(t *testing.T) {
+		query, channel := newAggregateQuery(t)
+		defer query.Free()
+
+		require.NoError(t, channel.ApplyAggregate("count", TILEDB_AGGREGATE_COUNT, ""))
+		require.NoError(t, channel.ApplyAggregate("sum", TILEDB_AGGREGATE_SUM, "a1"))
+		require.NoError(t, channel.ApplyAggregate("min", TILEDB_AGGREGATE_MIN, "a1"))
+		require.NoError(t, channel.ApplyAggregate("max", TILEDB_AGGREGATE_MAX, "a1"))
+		require.NoError(t, channel.ApplyAggregate("mean", TILEDB_AGGREGATE_MEAN, "a1"))
+
+		count := make([]uint64, 1)
+		sum := make([]int64, 1)
+		minimum := make([]int32, 1)
+		maximum := make([]int32, 1)
+		mean := make([]float64, 1)
+		_, err := query.SetDataBuffer("count", count)
+		require.NoError(t, err)
+		_, err = query.SetDataBuffer("sum", sum)
+		require.NoError(t, err)
+		_, err = query.SetDataBuffer("min", minimum)
+		require.NoError(t, err)
+		_, err = query.SetDataBuffer("max", maximum)
+		require.NoError(t, err)
+		_, err = query.SetDataBuffer("mean", mean)
+		require.NoError(t, err)
+
+		// The output type of each aggregate is checked like for attributes.
+		_, err = query.SetDataBuffer("sum", make([]int32, 1))
+		require.Error(t, err)
+
+		require.NoError(t, query.Submit())
+		assert.Equal(t, []uint64{3}, count)
+		assert.Equal(t, []int64{6}, sum)
+		assert.Equal(t, []int32{1}, minimum)
+		assert.Equal(t, []int32{3}, maximum)
+		assert.Equal(t, []float64{2}, mean)
+
+		elements, err := query.ResultBufferElements()
+		require.NoError(t, err)
+		assert.Equal(t, [3]uint64{0, 1, 0}, elements["count"])
+	})
+
+	t.Run("QueryCondition", func(t *testing.T) {
+		query, channel := newAggregateQuery(t)
+		defer query.Free()
+
+		qc, err := NewQueryCondition(array.context, "a1", TILEDB_QUERY_CONDITION_GT, int32(1))
+		require.NoError(t, err)
+		defer qc.Free()
+		require.NoError(t, query.SetQueryCondition(qc))
+
+		require.NoError(t, channel.ApplyAggregate("sum", TILEDB_AGGREGATE_SUM, "a1"))
+		sum := make([]int64, 1)
+		_, err = query.SetDataBuffer("sum", sum)
+		require.NoError(t, err)
+
+		require.NoError(t, query.Submit())
+		assert.Equal(t, []int64{5}, sum)
+	})
+
+	t.Run("Subarray", func(t *testing.T) {
+		query, channel := newAggregateQuery(t)
+		defer query.Free()
+
+		sa, err := array.NewSubarray()
+		require.NoError(t, err)
+		defer sa.Free()
+		require.NoError(t, sa.AddRangeByName("rows", MakeRange[int32](2, 2)))
+		require.NoError(t, query.SetSubarray(sa))
+
+		require.NoError(t, channel.ApplyAggregate("count", TILEDB_AGGREGATE_COUNT, ""))
+		count := make([]uint64, 1)
+		_, err = query.SetDataBuffer("count", count)
+		require.NoError(t, err)
+
+		require.NoError(t, query.Submit())
+		assert.Equal(t, []uint64{2}, count)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		query, channel := newAggregateQuery(t)
+		defer query.Free()
+
+		assert.Error(t, channel.ApplyAggregate("nulls", TILEDB_AGGREGATE_NULL_COUNT, "a1"))
+		assert.Error(t, channel.ApplyAggregate("sum", TILEDB_AGGREGATE_SUM, "a2"))
+		assert.Error(t, channel.ApplyAggregate("sum", TILEDB_AGGREGATE_SUM, "missing"))
+		require.NoError(t, channel.ApplyAggregate("sum", TILEDB_AGGREGATE_SUM, "a1"))
+		assert.Error(t, channel.ApplyAggregate("sum", TILEDB_AGGREGATE_MAX, "a1"))
+	})
+}
@@ -0,0 +1,167 @@
+package tiledb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/TileDB-Inc/TileDB-Go/bytesizes"
+)
+
+// defaultAllocationBudget is the default total size of the buffers allocated by Query.AllocateBuffers.
+const defaultAllocationBudget = 64 * 1024 * 1024
+
+// AllocateBuffersOption configures Query.AllocateBuffers.
+type AllocateBuffersOption func(b *QueryBuffers)
+
+// WithAllocationBudget limits the total size in bytes of the buffers allocated by Query.AllocateBuffers.
+// It defaults to 64MiB. The buffers of every field still have room for at least one cell.
+func WithAllocationBudget(n uint64) AllocateBuffersOption {
+	return func(b *QueryBuffers) {
+		b.budget = n
+	}
+}
+
+// WithAllocationPool draws the buffers allocated by Query.AllocateBuffers from pool.
+// They are returned to the pool by QueryBuffers.Release.
+func WithAllocationPool(pool *BufferPool) AllocateBuffersOption {
+	return func(b *QueryBuffers) {
+		b.pool = pool
+	}
+}
+
+/*
+QueryBuffers holds the buffers allocated and set on a read query by Query.AllocateBuffers.
+
+After the query is submitted, Results returns the results trimmed to the number of elements
+read for each field. If the query is incomplete, the buffers are reused by the next submission
+and the previous results are overwritten.
+
+	buffers, err := query.AllocateBuffers([]string{"rows", "a1"})
+	if err != nil {
+		return err
+	}
+	if err := query.Submit(); err != nil {
+		return err
+	}
+	a1, err := tiledb.QueryBuffersData[int32](buffers, "a1")
+*/
+type QueryBuffers struct {
+	query  *Query
+	fields []*iteratorField
+	budget uint64
+	pool   *BufferPool
+}
+
+// AllocateBuffers allocates the data, offsets and validity buffers of the given attributes,
+// dimensions or dimension labels, and sets them on the query. If no field names are given,
+// all dimensions and attributes of the array are read. The buffers are sized from the result
+// size estimates of the query, which depend on its subarray, and scaled down to fit the memory
+// budget, so the subarray must be set beforehand.
+func (q *Query) AllocateBuffers(fieldNames []string, opts ...AllocateBuffersOption) (*QueryBuffers, error) {
+	queryType, err := q.Type()
+	if err != nil {
+		return nil, err
+	}
+	if queryType != TILEDB_READ {
+		return nil, errors.New("error allocating buffers: query must be a read query")
+	}
+
+	b := &QueryBuffers{query: q, budget: defaultAllocationBudget}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	schema, err := q.array.Schema()
+	if err != nil {
+		return nil, fmt.Errorf("could not get array schema for AllocateBuffers: %w", err)
+	}
+	defer schema.Free()
+
+	if len(fieldNames) == 0 {
+		fieldNames, err = schemaFieldNames(schema)
+		if err != nil {
+			return nil, fmt.Errorf("could not get field names for AllocateBuffers: %w", err)
+		}
+	}
+
+	type estimate struct {
+		data, offsets uint64
+	}
+	estimates := make([]estimate, len(fieldNames))
+	var total uint64
+	for i, name := range fieldNames {
+		info, err := schemaFieldInfo(schema, name)
+		if err != nil {
+			return nil, fmt.Errorf("could not get field %s for AllocateBuffers: %w", name, err)
+		}
+		data, offsets, validity, err := estimateFieldBytes(q, info)
+		if err != nil {
+			return nil, fmt.Errorf("error estimating result size of %s: %w", name, err)
+		}
+		b.fields = append(b.fields, &iteratorField{fieldInfo: info})
+		estimates[i] = estimate{data: data, offsets: offsets}
+		total += data + offsets + validity
+	}
+
+	// Every buffer is scaled by the same ratio so that the results of all fields fit.
+	scale := 1.0
+	if total > b.budget {
+		scale = float64(b.budget) / float64(total)
+	}
+	for i, field := range b.fields {
+		dataBytes := uint64(float64(estimates[i].data) * scale)
+		offsetsBytes := uint64(float64(estimates[i].offsets) * scale)
+		if err := allocateField(q, b.pool, field, dataBytes, offsetsBytes); err != nil {
+			return nil, fmt.Errorf("error allocating buffers for %s: %w", field.name, err)
+		}
+	}
+	return b, nil
+}
+
+// Fields returns the names of the fields whose buffers were allocated.
+func (b *QueryBuffers) Fields() []string {
+	names := make([]string, len(b.fields))
+	for i, field := range b.fields {
+		names[i] = field.name
+	}
+	return names
+}
+
+// Bytes returns the total size in bytes of the allocated buffers.
+func (b *QueryBuffers) Bytes() uint64 {
+	var n uint64
+	for _, field := range b.fields {
+		n += uint64(reflect.ValueOf(field.data).Len())*field.datatype.Size() +
+			uint64(len(field.offsets))*bytesizes.Uint64 + uint64(len(field.validity))
+	}
+	return n
+}
+
+// Results returns the results of the last submission of the query. The batch aliases the
+// buffers, so it is only valid until the query is submitted again.
+func (b *QueryBuffers) Results() (*ResultBatch, error) {
+	elements, err := b.query.ResultBufferElements()
+	if err != nil {
+		return nil, err
+	}
+	return newResultBatch(b.fields, elements), nil
+}
+
+// QueryBuffersData returns the results of a field of the last submission of the query as a []T.
+// It returns an error if the field has no buffers or T does not match the field's Datatype.
+func QueryBuffersData[T any](b *QueryBuffers, name string) ([]T, error) {
+	batch, err := b.Results()
+	if err != nil {
+		return nil, err
+	}
+	return ResultBatchData[T](batch, name)
+}
+
+// Release returns the buffers to the BufferPool of the QueryBuffers, if any. Neither the
+// buffers nor the query they are set on must be used afterwards.
+func (b *QueryBuffers) Release() {
+	for _, field := range b.fields {
+		field.release(b.pool)
+	}
+}
@@ -0,0 +1,54 @@
+package tiledb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryAllocateBuffers(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	defer array.Free()
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+
+	query, err := NewQuery(array.context, array)
+	require.NoError(t, err)
+	defer query.Free()
+	require.NoError(t, query.SetLayout(TILEDB_ROW_MAJOR))
+
+	buffers, err := query.AllocateBuffers(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rows", "cols", "a1", "a2", "a3"}, buffers.Fields())
+	require.NoError(t, query.Submit())
+
+	a1, err := QueryBuffersData[int32](buffers, "a1")
+	require.NoError(t, err)
+	assert.Equal(t, testAttributeValues.Attribute1, a1)
+	_, err = QueryBuffersData[int64](buffers, "a1")
+	assert.ErrorIs(t, err, ErrSchemaMismatch)
+
+	batch, err := buffers.Results()
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, batch.NumCells())
+	assert.Equal(t, testAttributeValues.Attribute2Offset, batch.Offsets("a2"))
+}
+
+func TestQueryAllocateBuffersBudget(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	defer array.Free()
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+
+	query, err := NewQuery(array.context, array)
+	require.NoError(t, err)
+	defer query.Free()
+
+	// The buffers still hold a single cell.
+	buffers, err := query.AllocateBuffers([]string{"a1", "a3"}, WithAllocationBudget(1))
+	require.NoError(t, err)
+	assert.EqualValues(t, 12, buffers.Bytes())
+}
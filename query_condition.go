@@ -8,7 +8,9 @@ import "C"
 
 import (
 	"fmt"
+	"reflect"
 	"runtime"
+	"time"
 	"unsafe"
 )
 
@@ -30,53 +32,193 @@ func (x queryConditionHandle) Get() *C.tiledb_query_condition_t {
 type QueryCondition struct {
 	context *Context
 	cond    queryConditionHandle
+	expr    qcExpr
+	// build builds a new condition equal to this one against the schema of the queried array,
+	// from the fields, operators and values it was created with. Conditions on times have no
+	// handle until they are built, because their timestamps depend on the datatype of the field.
+	build func(schema *ArraySchema) (*QueryCondition, error)
+	// useEnumeration is the flag set with UseEnumeration, applied again when the condition is
+	// built.
+	useEnumeration *bool
 }
 
 func newQueryConditionFromHandle(tdbCtx *Context, handle queryConditionHandle) *QueryCondition {
 	return &QueryCondition{context: tdbCtx, cond: handle}
 }
 
-// NewQueryCondition allocates and initializes a new query condition.
-func NewQueryCondition(tdbCtx *Context, attributeName string, op QueryConditionOp, value interface{}) (*QueryCondition, error) {
+// allocQueryCondition allocates a new query condition without initializing it.
+func allocQueryCondition(tdbCtx *Context) (*QueryCondition, error) {
 	var qcPtr *C.tiledb_query_condition_t
 	if ret := C.tiledb_query_condition_alloc(tdbCtx.tiledbContext.Get(), &qcPtr); ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error allocating tiledb query condition: %w", tdbCtx.LastError())
+		return nil, fmt.Errorf("error allocating tiledb query condition: %w", tdbCtx.lastError("tiledb_query_condition_alloc", ret))
 	}
 	runtime.KeepAlive(tdbCtx)
 
-	qc := newQueryConditionFromHandle(tdbCtx, newQueryConditionHandle(qcPtr))
+	return newQueryConditionFromHandle(tdbCtx, newQueryConditionHandle(qcPtr)), nil
+}
+
+// newUnresolvedQueryCondition returns a query condition without a handle, which is built by
+// build when it is set on a query.
+func newUnresolvedQueryCondition(tdbCtx *Context, expr qcExpr, build func(schema *ArraySchema) (*QueryCondition, error)) *QueryCondition {
+	return &QueryCondition{context: tdbCtx, expr: expr, build: build}
+}
+
+// unresolved reports whether the condition is built only when it is set on a query.
+func (qc *QueryCondition) unresolved() bool {
+	return qc.cond.capiHandle == nil
+}
+
+// builder returns a function building a new condition equal to qc. It holds no reference to
+// qc, so qc can be freed once it has been combined or negated.
+func (qc *QueryCondition) builder() func(schema *ArraySchema) (*QueryCondition, error) {
+	build, useEnumeration := qc.build, qc.useEnumeration
+	return func(schema *ArraySchema) (*QueryCondition, error) {
+		built, err := build(schema)
+		if err != nil {
+			return nil, err
+		}
+		if useEnumeration != nil {
+			if err := built.UseEnumeration(*useEnumeration); err != nil {
+				built.Free()
+				return nil, err
+			}
+		}
+		return built, nil
+	}
+}
+
+// resolved returns the condition built against schema. Conditions that need no resolution
+// are returned as is; otherwise the caller owns the returned condition.
+func (qc *QueryCondition) resolved(schema *ArraySchema) (*QueryCondition, error) {
+	if !qc.unresolved() {
+		return qc, nil
+	}
+	return qc.builder()(schema)
+}
+
+// cloneQueryConditionValue returns a copy of a slice value, so that conditions built again
+// later do not see changes the caller made to it.
+func cloneQueryConditionValue(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice || v.IsNil() {
+		return value
+	}
+	return reflect.AppendSlice(reflect.MakeSlice(v.Type(), 0, v.Len()), v).Interface()
+}
+
+// timeFieldValue converts a time.Time or []time.Time value to the timestamps of the datatype
+// of the field of schema.
+func timeFieldValue(schema *ArraySchema, fieldName string, value interface{}) (interface{}, error) {
+	info, err := schemaFieldInfo(schema, fieldName)
+	if err != nil {
+		return nil, err
+	}
+	switch value := value.(type) {
+	case time.Time:
+		return TimestampFromTime(info.datatype, value)
+	case []time.Time:
+		return TimestampsFromTimes(info.datatype, value)
+	}
+	return value, nil
+}
+
+// NewQueryCondition allocates and initializes a new query condition.
+// A nil value checks whether a nullable attribute is null (TILEDB_QUERY_CONDITION_EQ)
+// or not null (TILEDB_QUERY_CONDITION_NE). A time.Time value is compared with the
+// timestamps of a datetime or time field, converted according to the field's Datatype
+// when the condition is set on a query.
+func NewQueryCondition(tdbCtx *Context, attributeName string, op QueryConditionOp, value interface{}) (*QueryCondition, error) {
+	value = cloneQueryConditionValue(value)
+	switch value.(type) {
+	case time.Time, []time.Time:
+		return newUnresolvedQueryCondition(tdbCtx, qcComparisonExpr(attributeName, op, value), func(schema *ArraySchema) (*QueryCondition, error) {
+			timestamps, err := timeFieldValue(schema, attributeName, value)
+			if err != nil {
+				return nil, fmt.Errorf("could not init %q query condition: %w", attributeName, err)
+			}
+			return NewQueryCondition(tdbCtx, attributeName, op, timestamps)
+		}), nil
+	}
+
+	qc, err := allocQueryCondition(tdbCtx)
+	if err != nil {
+		return nil, err
+	}
 	if err := qc.init(attributeName, value, op); err != nil {
+		qc.Free()
 		return nil, err
 	}
+	qc.expr = qcComparisonExpr(attributeName, op, value)
+	qc.build = func(*ArraySchema) (*QueryCondition, error) {
+		return NewQueryCondition(tdbCtx, attributeName, op, value)
+	}
 
 	return qc, nil
 }
 
 // NewQueryConditionCombination combines two query conditions to create a new query condition. The underlying conditions
-// are unchanged.
+// are unchanged and can be freed once combined.
 func NewQueryConditionCombination(tdbCtx *Context, left *QueryCondition, op QueryConditionCombinationOp, right *QueryCondition) (*QueryCondition, error) {
+	buildLeft, buildRight := left.builder(), right.builder()
+	build := func(schema *ArraySchema) (*QueryCondition, error) {
+		builtLeft, err := buildLeft(schema)
+		if err != nil {
+			return nil, err
+		}
+		defer builtLeft.Free()
+		builtRight, err := buildRight(schema)
+		if err != nil {
+			return nil, err
+		}
+		defer builtRight.Free()
+		return NewQueryConditionCombination(tdbCtx, builtLeft, op, builtRight)
+	}
+	expr := qcCombinationExpr(left.expr, op, right.expr)
+	if left.unresolved() || right.unresolved() {
+		return newUnresolvedQueryCondition(tdbCtx, expr, build), nil
+	}
+
 	var qcPtr *C.tiledb_query_condition_t
 	if ret := C.tiledb_query_condition_combine(tdbCtx.tiledbContext.Get(), left.cond.Get(), right.cond.Get(), C.tiledb_query_condition_combination_op_t(op), &qcPtr); ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error allocating tiledb query condition: %w", tdbCtx.LastError())
+		return nil, fmt.Errorf("error allocating tiledb query condition: %w", tdbCtx.lastError("tiledb_query_condition_combine", ret))
 	}
 	runtime.KeepAlive(tdbCtx)
 	runtime.KeepAlive(left)
 	runtime.KeepAlive(right)
 
-	return newQueryConditionFromHandle(tdbCtx, newQueryConditionHandle(qcPtr)), nil
+	combined := newQueryConditionFromHandle(tdbCtx, newQueryConditionHandle(qcPtr))
+	combined.expr = expr
+	combined.build = build
+	return combined, nil
 }
 
 // NewQueryConditionNegated returns the negation of the query condition. The initial condition
-// is unchanged.
+// is unchanged and can be freed once negated.
 func NewQueryConditionNegated(tdbCtx *Context, qc *QueryCondition) (*QueryCondition, error) {
+	buildQC := qc.builder()
+	build := func(schema *ArraySchema) (*QueryCondition, error) {
+		built, err := buildQC(schema)
+		if err != nil {
+			return nil, err
+		}
+		defer built.Free()
+		return NewQueryConditionNegated(tdbCtx, built)
+	}
+	if qc.unresolved() {
+		return newUnresolvedQueryCondition(tdbCtx, qcNegatedExpr(qc.expr), build), nil
+	}
+
 	var nqcPtr *C.tiledb_query_condition_t
 	if ret := C.tiledb_query_condition_negate(qc.context.tiledbContext.Get(), qc.cond.Get(), &nqcPtr); ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error allocating tiledb query condition: %w", tdbCtx.LastError())
+		return nil, fmt.Errorf("error allocating tiledb query condition: %w", tdbCtx.lastError("tiledb_query_condition_negate", ret))
 	}
 	runtime.KeepAlive(tdbCtx)
 	runtime.KeepAlive(qc)
 
-	return newQueryConditionFromHandle(tdbCtx, newQueryConditionHandle(nqcPtr)), nil
+	negated := newQueryConditionFromHandle(tdbCtx, newQueryConditionHandle(nqcPtr))
+	negated.expr = qcNegatedExpr(qc.expr)
+	negated.build = build
+	return negated, nil
 }
 
 // Free releases the internal TileDB core data that was allocated on the C heap.
@@ -85,7 +227,9 @@ func NewQueryConditionNegated(tdbCtx *Context, qc *QueryCondition) (*QueryCondit
 // can safely be called many times on the same object; if it has already
 // been freed, it will not be freed again.
 func (qc *QueryCondition) Free() {
-	qc.cond.Free()
+	if !qc.unresolved() {
+		qc.cond.Free()
+	}
 }
 
 // Context exposes the internal TileDB context used to initialize the query condition
@@ -95,6 +239,8 @@ func (qc *QueryCondition) Context() *Context {
 
 func (qc *QueryCondition) init(attributeName string, value interface{}, op QueryConditionOp) error {
 	switch value := value.(type) {
+	case nil:
+		return qcInitInternal(qc, attributeName, nil, 0, op)
 	case int:
 		return qcInitScalar(qc, attributeName, value, op)
 	case []int:
@@ -178,7 +324,7 @@ func qcInitInternal(qc *QueryCondition, attributeName string, valuePtr unsafe.Po
 	)
 	runtime.KeepAlive(qc)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("could not init %q query condition: %w", attributeName, qc.context.LastError())
+		return fmt.Errorf("could not init %q query condition: %w", attributeName, qc.context.lastError("tiledb_query_condition_init", ret))
 	}
 	return nil
 }
@@ -0,0 +1,137 @@
+package tiledb
+
+/*
+#include <tiledb/tiledb.h>
+#include <tiledb/tiledb_experimental.h>
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+	"unsafe"
+
+	"github.com/TileDB-Inc/TileDB-Go/bytesizes"
+)
+
+// NewQueryConditionSet allocates a query condition that checks whether the value of a field is
+// (TILEDB_QUERY_CONDITION_IN) or is not (TILEDB_QUERY_CONDITION_NOT_IN) one of values.
+// values must be a non-empty slice of the Go type of the field, a []string for string fields,
+// or a []time.Time for datetime and time fields.
+// For enumerated attributes, values may be a []string of enumeration values; such conditions
+// use the enumeration unless UseEnumeration(false) is called.
+func NewQueryConditionSet(tdbCtx *Context, fieldName string, op QueryConditionOp, values interface{}) (*QueryCondition, error) {
+	if op != TILEDB_QUERY_CONDITION_IN && op != TILEDB_QUERY_CONDITION_NOT_IN {
+		return nil, fmt.Errorf("error creating %q set query condition: operator must be TILEDB_QUERY_CONDITION_IN or TILEDB_QUERY_CONDITION_NOT_IN", fieldName)
+	}
+
+	values = cloneQueryConditionValue(values)
+	var qc *QueryCondition
+	var err error
+	switch values := values.(type) {
+	case []int:
+		qc, err = qcSetSlice(tdbCtx, fieldName, values, op)
+	case []int8:
+		qc, err = qcSetSlice(tdbCtx, fieldName, values, op)
+	case []int16:
+		qc, err = qcSetSlice(tdbCtx, fieldName, values, op)
+	case []int32:
+		qc, err = qcSetSlice(tdbCtx, fieldName, values, op)
+	case []int64:
+		qc, err = qcSetSlice(tdbCtx, fieldName, values, op)
+	case []uint:
+		qc, err = qcSetSlice(tdbCtx, fieldName, values, op)
+	case []uint8:
+		qc, err = qcSetSlice(tdbCtx, fieldName, values, op)
+	case []uint16:
+		qc, err = qcSetSlice(tdbCtx, fieldName, values, op)
+	case []uint32:
+		qc, err = qcSetSlice(tdbCtx, fieldName, values, op)
+	case []uint64:
+		qc, err = qcSetSlice(tdbCtx, fieldName, values, op)
+	case []float32:
+		qc, err = qcSetSlice(tdbCtx, fieldName, values, op)
+	case []float64:
+		qc, err = qcSetSlice(tdbCtx, fieldName, values, op)
+	case []bool:
+		qc, err = qcSetSlice(tdbCtx, fieldName, values, op)
+	case []string:
+		qc, err = qcSetStrings(tdbCtx, fieldName, values, op)
+	case []time.Time:
+		if len(values) == 0 {
+			return nil, fmt.Errorf("error creating %q set query condition: no values", fieldName)
+		}
+		return newUnresolvedQueryCondition(tdbCtx, qcSetExpr(fieldName, op, values), func(schema *ArraySchema) (*QueryCondition, error) {
+			timestamps, err := timeFieldValue(schema, fieldName, values)
+			if err != nil {
+				return nil, fmt.Errorf("error creating %q set query condition: %w", fieldName, err)
+			}
+			return NewQueryConditionSet(tdbCtx, fieldName, op, timestamps)
+		}), nil
+	default:
+		return nil, fmt.Errorf("cannot create set query condition for type %T", values)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	qc.expr = qcSetExpr(fieldName, op, values)
+	qc.build = func(*ArraySchema) (*QueryCondition, error) {
+		return NewQueryConditionSet(tdbCtx, fieldName, op, values)
+	}
+	return qc, nil
+}
+
+func qcSetSlice[T scalarType](tdbCtx *Context, fieldName string, values []T, op QueryConditionOp) (*QueryCondition, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("error creating %q set query condition: no values", fieldName)
+	}
+	var t T
+	size := uint64(unsafe.Sizeof(t)) * uint64(len(values))
+	return qcSetInternal(tdbCtx, fieldName, slicePtr(values), size, nil, 0, op)
+}
+
+func qcSetStrings(tdbCtx *Context, fieldName string, values []string, op QueryConditionOp) (*QueryCondition, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("error creating %q set query condition: no values", fieldName)
+	}
+
+	var data []byte
+	offsets := make([]uint64, len(values))
+	for i, v := range values {
+		offsets[i] = uint64(len(data))
+		data = append(data, v...)
+	}
+	dataSize := uint64(len(data))
+	if dataSize == 0 {
+		// Every value is the empty string, but TileDB still requires a valid data pointer.
+		data = make([]byte, 1)
+	}
+
+	return qcSetInternal(tdbCtx, fieldName, slicePtr(data), dataSize, slicePtr(offsets), uint64(len(offsets))*bytesizes.Uint64, op)
+}
+
+func qcSetInternal(tdbCtx *Context, fieldName string, data unsafe.Pointer, dataSize uint64, offsets unsafe.Pointer, offsetsSize uint64, op QueryConditionOp) (*QueryCondition, error) {
+	cname := C.CString(fieldName)
+	defer C.free(unsafe.Pointer(cname))
+
+	var qcPtr *C.tiledb_query_condition_t
+	ret := C.tiledb_query_condition_alloc_set_membership(
+		tdbCtx.tiledbContext.Get(),
+		cname,
+		data,
+		C.uint64_t(dataSize),
+		offsets,
+		C.uint64_t(offsetsSize),
+		C.tiledb_query_condition_op_t(op),
+		&qcPtr,
+	)
+	runtime.KeepAlive(tdbCtx)
+	if ret != C.TILEDB_OK {
+		return nil, fmt.Errorf("could not create %q set query condition: %w", fieldName, tdbCtx.lastError("tiledb_query_condition_alloc_set_membership", ret))
+	}
+
+	return newQueryConditionFromHandle(tdbCtx, newQueryConditionHandle(qcPtr)), nil
+}
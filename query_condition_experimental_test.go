@@ -0,0 +1,59 @@
+package tiledb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryConditionSet(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+
+	cases := []struct {
+		name           string
+		field          string
+		op             QueryConditionOp
+		values         interface{}
+		expectedValues []int32
+	}{
+		{"InInt32", "a1", TILEDB_QUERY_CONDITION_IN, []int32{1, 3, 7}, []int32{1, 3}},
+		{"NotInInt32", "a1", TILEDB_QUERY_CONDITION_NOT_IN, []int32{1, 3, 7}, []int32{2}},
+		{"InStrings", "a2", TILEDB_QUERY_CONDITION_IN, []string{"i", "string", "other"}, []int32{1, 3}},
+		{"NotInStrings", "a2", TILEDB_QUERY_CONDITION_NOT_IN, []string{"i", "string"}, []int32{2}},
+		{"InEmptyString", "a2", TILEDB_QUERY_CONDITION_IN, []string{""}, []int32{}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			query, err := NewQuery(array.context, array)
+			require.NoError(t, err)
+			defer query.Free()
+			require.NoError(t, query.SetLayout(TILEDB_ROW_MAJOR))
+
+			a1DataRead := make([]int32, 3)
+			_, err = query.SetDataBuffer("a1", a1DataRead)
+			require.NoError(t, err)
+
+			qc, err := NewQueryConditionSet(array.context, c.field, c.op, c.values)
+			require.NoError(t, err)
+			require.NoError(t, query.SetQueryCondition(qc))
+
+			require.NoError(t, query.Submit())
+			elements, err := query.ResultBufferElements()
+			require.NoError(t, err)
+			assert.Equal(t, c.expectedValues, a1DataRead[:elements["a1"][1]])
+		})
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := NewQueryConditionSet(array.context, "a1", TILEDB_QUERY_CONDITION_EQ, []int32{1})
+		assert.Error(t, err)
+		_, err = NewQueryConditionSet(array.context, "a1", TILEDB_QUERY_CONDITION_IN, []int32{})
+		assert.Error(t, err)
+		_, err = NewQueryConditionSet(array.context, "a1", TILEDB_QUERY_CONDITION_IN, int32(1))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,656 @@
+package tiledb
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Precedence levels of query condition expressions, from the tightest binding.
+const (
+	qcPrecComparison = iota
+	qcPrecNot
+	qcPrecAnd
+	qcPrecOr
+)
+
+// qcExpr is the textual form of a query condition, kept alongside the C API handle so that
+// conditions can be rendered by QueryCondition.String.
+type qcExpr struct {
+	text       string
+	precedence int
+}
+
+// operand renders e as an operand of an expression of the given precedence.
+func (e qcExpr) operand(precedence int) string {
+	if e.precedence > precedence {
+		return "(" + e.text + ")"
+	}
+	return e.text
+}
+
+// qcOpSymbol returns the symbol of a comparison operator.
+func qcOpSymbol(op QueryConditionOp) string {
+	switch op {
+	case TILEDB_QUERY_CONDITION_LT:
+		return "<"
+	case TILEDB_QUERY_CONDITION_LE:
+		return "<="
+	case TILEDB_QUERY_CONDITION_GT:
+		return ">"
+	case TILEDB_QUERY_CONDITION_GE:
+		return ">="
+	case TILEDB_QUERY_CONDITION_EQ:
+		return "=="
+	case TILEDB_QUERY_CONDITION_NE:
+		return "!="
+	}
+	return fmt.Sprintf("QueryConditionOp(%d)", op)
+}
+
+// qcComparisonExpr returns the expression of a comparison of a field with a value.
+// A nil value is a null check.
+func qcComparisonExpr(field string, op QueryConditionOp, value interface{}) qcExpr {
+	name := formatQueryConditionField(field)
+	if value == nil {
+		if op == TILEDB_QUERY_CONDITION_NE {
+			return qcExpr{text: name + " is not null"}
+		}
+		return qcExpr{text: name + " is null"}
+	}
+	return qcExpr{text: name + " " + qcOpSymbol(op) + " " + formatQueryConditionValue(value)}
+}
+
+// qcSetExpr returns the expression of a set membership test.
+func qcSetExpr(field string, op QueryConditionOp, values interface{}) qcExpr {
+	keyword := " in "
+	if op == TILEDB_QUERY_CONDITION_NOT_IN {
+		keyword = " not in "
+	}
+	return qcExpr{text: formatQueryConditionField(field) + keyword + formatQueryConditionList(reflect.ValueOf(values))}
+}
+
+// qcCombinationExpr returns the expression of a combination of two conditions.
+func qcCombinationExpr(left qcExpr, op QueryConditionCombinationOp, right qcExpr) qcExpr {
+	switch op {
+	case TILEDB_QUERY_CONDITION_AND:
+		return qcExpr{text: left.operand(qcPrecAnd) + " and " + right.operand(qcPrecAnd), precedence: qcPrecAnd}
+	case TILEDB_QUERY_CONDITION_OR:
+		return qcExpr{text: left.operand(qcPrecOr) + " or " + right.operand(qcPrecOr), precedence: qcPrecOr}
+	}
+	return qcNegatedExpr(left)
+}
+
+// qcNegatedExpr returns the expression of the negation of a condition.
+func qcNegatedExpr(e qcExpr) qcExpr {
+	return qcExpr{text: "not " + e.operand(qcPrecNot), precedence: qcPrecNot}
+}
+
+// String renders the condition in the syntax accepted by ParseQueryCondition.
+func (qc *QueryCondition) String() string {
+	return qc.expr.text
+}
+
+// formatQueryConditionField renders a field name, quoting it with backticks unless it is a plain identifier.
+func formatQueryConditionField(name string) string {
+	if isQueryConditionIdent(name) && !qcKeywords[strings.ToLower(name)] {
+		return name
+	}
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// formatQueryConditionValue renders a literal.
+func formatQueryConditionValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return quoteQueryConditionString(v)
+	case []byte:
+		return quoteQueryConditionString(string(v))
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case time.Time:
+		return quoteQueryConditionString(v.UTC().Format(time.RFC3339Nano))
+	}
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Slice {
+		return formatQueryConditionList(v)
+	}
+	return fmt.Sprint(value)
+}
+
+// formatQueryConditionList renders a slice as a list of literals.
+func formatQueryConditionList(values reflect.Value) string {
+	items := make([]string, values.Len())
+	for i := range items {
+		items[i] = formatQueryConditionValue(values.Index(i).Interface())
+	}
+	return "[" + strings.Join(items, ", ") + "]"
+}
+
+// quoteQueryConditionString renders a string literal with single quotes.
+func quoteQueryConditionString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
+
+var qcKeywords = map[string]bool{
+	"and": true, "or": true, "not": true, "in": true, "is": true, "null": true, "true": true, "false": true,
+}
+
+func isQueryConditionIdent(s string) bool {
+	for i, r := range s {
+		if !(r == '_' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// QueryConditionParseError reports a syntax or type error in a query condition expression.
+type QueryConditionParseError struct {
+	// Expr is the expression being parsed.
+	Expr string
+	// Offset is the byte offset in Expr at which the error was found.
+	Offset int
+	// Msg describes the error.
+	Msg string
+}
+
+// Error implements the error interface.
+func (e *QueryConditionParseError) Error() string {
+	return fmt.Sprintf("invalid query condition at column %d: %s", e.Offset+1, e.Msg)
+}
+
+type qcTokenKind int
+
+const (
+	qcTokenEOF qcTokenKind = iota
+	qcTokenIdent
+	qcTokenKeyword
+	qcTokenNumber
+	qcTokenString
+	qcTokenOp
+	qcTokenPunct
+)
+
+type qcToken struct {
+	kind   qcTokenKind
+	text   string // the identifier, keyword (lower case), number, unquoted string, operator or punctuation
+	offset int
+}
+
+func (t qcToken) String() string {
+	switch t.kind {
+	case qcTokenEOF:
+		return "end of expression"
+	case qcTokenString:
+		return quoteQueryConditionString(t.text)
+	}
+	return strconv.Quote(t.text)
+}
+
+// qcLex splits a query condition expression into tokens.
+func qcLex(expr string) ([]qcToken, error) {
+	var tokens []qcToken
+	errorAt := func(offset int, format string, args ...interface{}) error {
+		return &QueryConditionParseError{Expr: expr, Offset: offset, Msg: fmt.Sprintf(format, args...)}
+	}
+
+	for i := 0; i < len(expr); {
+		r, size := utf8.DecodeRuneInString(expr[i:])
+		start := i
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case r == '(' || r == ')' || r == '[' || r == ']' || r == ',':
+			tokens = append(tokens, qcToken{kind: qcTokenPunct, text: string(r), offset: start})
+			i++
+		case r == '<' || r == '>' || r == '=' || r == '!':
+			op := string(r)
+			if i+1 < len(expr) && expr[i+1] == '=' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, errorAt(start, "unexpected %q, did you mean \"!=\"?", op)
+			}
+			tokens = append(tokens, qcToken{kind: qcTokenOp, text: op, offset: start})
+			i += len(op)
+		case r == '\'' || r == '"' || r == '`':
+			var sb strings.Builder
+			closed := false
+			for i++; i < len(expr); i++ {
+				c := expr[i]
+				if c == byte(r) {
+					// Backticks are escaped by doubling them.
+					if r == '`' && i+1 < len(expr) && expr[i+1] == '`' {
+						sb.WriteByte('`')
+						i++
+						continue
+					}
+					closed = true
+					break
+				}
+				if r != '`' && c == '\\' && i+1 < len(expr) {
+					i++
+					c = expr[i]
+				}
+				sb.WriteByte(c)
+			}
+			if !closed {
+				return nil, errorAt(start, "unterminated %c", r)
+			}
+			i++
+			kind := qcTokenString
+			if r == '`' {
+				kind = qcTokenIdent
+			}
+			tokens = append(tokens, qcToken{kind: kind, text: sb.String(), offset: start})
+		case unicode.IsDigit(r) || r == '.' || ((r == '-' || r == '+') && i+1 < len(expr) && (isDigitByte(expr[i+1]) || expr[i+1] == '.')):
+			i++
+			for i < len(expr) {
+				c := expr[i]
+				if isDigitByte(c) || c == '.' || c == '_' || unicode.IsLetter(rune(c)) ||
+					((c == '-' || c == '+') && (expr[i-1] == 'e' || expr[i-1] == 'E')) {
+					i++
+					continue
+				}
+				break
+			}
+			tokens = append(tokens, qcToken{kind: qcTokenNumber, text: expr[start:i], offset: start})
+		case r == '_' || unicode.IsLetter(r):
+			for i < len(expr) {
+				c, n := utf8.DecodeRuneInString(expr[i:])
+				if c != '_' && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+					break
+				}
+				i += n
+			}
+			word := expr[start:i]
+			if qcKeywords[strings.ToLower(word)] {
+				tokens = append(tokens, qcToken{kind: qcTokenKeyword, text: strings.ToLower(word), offset: start})
+			} else {
+				tokens = append(tokens, qcToken{kind: qcTokenIdent, text: word, offset: start})
+			}
+		default:
+			return nil, errorAt(start, "unexpected character %q", r)
+		}
+	}
+
+	return append(tokens, qcToken{kind: qcTokenEOF, offset: len(expr)}), nil
+}
+
+func isDigitByte(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// qcParser parses a query condition expression into a QueryCondition. Intermediate conditions
+// are freed as soon as they have been combined.
+type qcParser struct {
+	tdbCtx *Context
+	schema *ArraySchema
+	expr   string
+	tokens []qcToken
+	pos    int
+}
+
+/*
+ParseQueryCondition parses a query condition expression and type checks it against the
+attributes and dimensions of schema. The syntax is:
+
+	expr       := expr "or" expr | expr "and" expr | "not" expr | "(" expr ")" | comparison
+	comparison := field op literal
+	            | field ["not"] "in" "[" literal {"," literal} "]"
+	            | field "is" ["not"] "null"
+	op         := "<" | "<=" | ">" | ">=" | "==" | "=" | "!="
+
+"not" binds tighter than "and", which binds tighter than "or"; keywords are case insensitive.
+Fields are identifiers, or any name quoted with backticks. Literals are numbers, true or false,
+and strings quoted with single or double quotes, in which backslash escapes the next character.
+Numbers must fit the datatype of the field; strings are accepted for string fields, as RFC 3339
+times for datetime and time fields and, as enumeration values, for enumerated attributes.
+For example:
+
+	a1 > 5 and (label in ['x', 'y'] or not b is null)
+
+Syntax and type errors are reported as a *QueryConditionParseError.
+*/
+func ParseQueryCondition(tdbCtx *Context, schema *ArraySchema, expr string) (*QueryCondition, error) {
+	tokens, err := qcLex(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &qcParser{tdbCtx: tdbCtx, schema: schema, expr: expr, tokens: tokens}
+	qc, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != qcTokenEOF {
+		qc.Free()
+		return nil, p.errorAt(tok, "unexpected %v", tok)
+	}
+	return qc, nil
+}
+
+func (p *qcParser) peek() qcToken {
+	return p.tokens[p.pos]
+}
+
+func (p *qcParser) next() qcToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != qcTokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// accept consumes the next token if it is the given keyword or punctuation.
+func (p *qcParser) accept(kind qcTokenKind, text string) bool {
+	if tok := p.peek(); tok.kind == kind && tok.text == text {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *qcParser) expect(kind qcTokenKind, text string) error {
+	if !p.accept(kind, text) {
+		tok := p.peek()
+		return p.errorAt(tok, "expected %q, got %v", text, tok)
+	}
+	return nil
+}
+
+func (p *qcParser) errorAt(tok qcToken, format string, args ...interface{}) error {
+	return &QueryConditionParseError{Expr: p.expr, Offset: tok.offset, Msg: fmt.Sprintf(format, args...)}
+}
+
+// combine combines two conditions and frees them.
+func (p *qcParser) combine(left *QueryCondition, op QueryConditionCombinationOp, right *QueryCondition) (*QueryCondition, error) {
+	defer left.Free()
+	defer right.Free()
+	return NewQueryConditionCombination(p.tdbCtx, left, op, right)
+}
+
+func (p *qcParser) parseOr() (*QueryCondition, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(qcTokenKeyword, "or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			left.Free()
+			return nil, err
+		}
+		if left, err = p.combine(left, TILEDB_QUERY_CONDITION_OR, right); err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+func (p *qcParser) parseAnd() (*QueryCondition, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(qcTokenKeyword, "and") {
+		right, err := p.parseNot()
+		if err != nil {
+			left.Free()
+			return nil, err
+		}
+		if left, err = p.combine(left, TILEDB_QUERY_CONDITION_AND, right); err != nil {
+			return nil, err
+		}
+	}
+	return left, nil
+}
+
+func (p *qcParser) parseNot() (*QueryCondition, error) {
+	if !p.accept(qcTokenKeyword, "not") {
+		return p.parsePrimary()
+	}
+	qc, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	defer qc.Free()
+	return NewQueryConditionNegated(p.tdbCtx, qc)
+}
+
+func (p *qcParser) parsePrimary() (*QueryCondition, error) {
+	if p.accept(qcTokenPunct, "(") {
+		qc, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(qcTokenPunct, ")"); err != nil {
+			qc.Free()
+			return nil, err
+		}
+		return qc, nil
+	}
+
+	fieldTok := p.next()
+	if fieldTok.kind != qcTokenIdent {
+		return nil, p.errorAt(fieldTok, "expected a field name, got %v", fieldTok)
+	}
+	field, err := p.field(fieldTok)
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok := p.next(); {
+	case tok.kind == qcTokenOp:
+		op := map[string]QueryConditionOp{
+			"<": TILEDB_QUERY_CONDITION_LT, "<=": TILEDB_QUERY_CONDITION_LE,
+			">": TILEDB_QUERY_CONDITION_GT, ">=": TILEDB_QUERY_CONDITION_GE,
+			"=": TILEDB_QUERY_CONDITION_EQ, "==": TILEDB_QUERY_CONDITION_EQ,
+			"!=": TILEDB_QUERY_CONDITION_NE,
+		}[tok.text]
+		valueTok := p.next()
+		value, useEnumeration, err := p.literal(field, valueTok)
+		if err != nil {
+			return nil, err
+		}
+		qc, err := NewQueryCondition(p.tdbCtx, field.name, op, value)
+		if err != nil {
+			return nil, err
+		}
+		if field.enumerated && !useEnumeration {
+			if err := qc.UseEnumeration(false); err != nil {
+				qc.Free()
+				return nil, err
+			}
+		}
+		return qc, nil
+	case tok.kind == qcTokenKeyword && tok.text == "is":
+		op := TILEDB_QUERY_CONDITION_EQ
+		if p.accept(qcTokenKeyword, "not") {
+			op = TILEDB_QUERY_CONDITION_NE
+		}
+		if err := p.expect(qcTokenKeyword, "null"); err != nil {
+			return nil, err
+		}
+		if !field.nullable {
+			return nil, p.errorAt(fieldTok, "%s is not nullable", field.name)
+		}
+		return NewQueryCondition(p.tdbCtx, field.name, op, nil)
+	case tok.kind == qcTokenKeyword && (tok.text == "in" || tok.text == "not"):
+		op := TILEDB_QUERY_CONDITION_IN
+		if tok.text == "not" {
+			op = TILEDB_QUERY_CONDITION_NOT_IN
+			if err := p.expect(qcTokenKeyword, "in"); err != nil {
+				return nil, err
+			}
+		}
+		return p.parseSet(field, op)
+	default:
+		return nil, p.errorAt(tok, "expected a comparison operator, \"in\" or \"is\" after %s, got %v", field.name, tok)
+	}
+}
+
+func (p *qcParser) parseSet(field qcField, op QueryConditionOp) (*QueryCondition, error) {
+	if err := p.expect(qcTokenPunct, "["); err != nil {
+		return nil, err
+	}
+
+	var values reflect.Value
+	useEnumeration := false
+	for {
+		valueTok := p.next()
+		value, isEnumValue, err := p.literal(field, valueTok)
+		if err != nil {
+			return nil, err
+		}
+		if !values.IsValid() {
+			values = reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(value)), 0, 1)
+			useEnumeration = isEnumValue
+		} else if isEnumValue != useEnumeration {
+			return nil, p.errorAt(valueTok, "cannot mix enumeration values and indexes for %s", field.name)
+		}
+		values = reflect.Append(values, reflect.ValueOf(value))
+
+		if p.accept(qcTokenPunct, "]") {
+			break
+		}
+		if err := p.expect(qcTokenPunct, ","); err != nil {
+			return nil, err
+		}
+	}
+
+	qc, err := NewQueryConditionSet(p.tdbCtx, field.name, op, values.Interface())
+	if err != nil {
+		return nil, err
+	}
+	if field.enumerated && !useEnumeration {
+		if err := qc.UseEnumeration(false); err != nil {
+			qc.Free()
+			return nil, err
+		}
+	}
+	return qc, nil
+}
+
+// qcField is a field referenced by a query condition expression.
+type qcField struct {
+	fieldInfo
+	enumerated bool
+}
+
+// field resolves a field name against the schema.
+func (p *qcParser) field(tok qcToken) (qcField, error) {
+	info, err := schemaFieldInfo(p.schema, tok.text)
+	if err != nil {
+		return qcField{}, p.errorAt(tok, "unknown field %s", tok.text)
+	}
+	field := qcField{fieldInfo: info}
+
+	if !info.isDimension {
+		hasAttr, err := p.schema.HasAttribute(tok.text)
+		if err != nil {
+			return field, err
+		}
+		if hasAttr {
+			attribute, err := p.schema.AttributeFromName(tok.text)
+			if err != nil {
+				return field, err
+			}
+			// Attributes without an enumeration have no enumeration name to view.
+			name, err := attribute.GetEnumerationName()
+			attribute.Free()
+			field.enumerated = err == nil && name != ""
+		}
+	}
+	return field, nil
+}
+
+// literal converts a literal token to a value of the Go type of the field. It also reports
+// whether the value is an enumeration value rather than an index into the enumeration.
+func (p *qcParser) literal(field qcField, tok qcToken) (interface{}, bool, error) {
+	isString := field.datatype == TILEDB_STRING_ASCII || field.datatype == TILEDB_STRING_UTF8 || field.datatype == TILEDB_CHAR
+	switch tok.kind {
+	case qcTokenString:
+		if isString || field.enumerated {
+			return tok.text, field.enumerated, nil
+		}
+		if isTimeDatatype(field.datatype) {
+			t, err := time.Parse(time.RFC3339Nano, tok.text)
+			if err != nil {
+				return nil, false, p.errorAt(tok, "invalid time for %s: %v", field.name, err)
+			}
+			timestamp, err := TimestampFromTime(field.datatype, t)
+			if err != nil {
+				return nil, false, p.errorAt(tok, "invalid %v value for %s: %v", field.datatype, field.name, err)
+			}
+			return timestamp, false, nil
+		}
+		return nil, false, p.errorAt(tok, "cannot compare %v field %s with a string", field.datatype, field.name)
+	case qcTokenKeyword:
+		if tok.text != "true" && tok.text != "false" {
+			break
+		}
+		if field.datatype != TILEDB_BOOL {
+			return nil, false, p.errorAt(tok, "cannot compare %v field %s with a boolean", field.datatype, field.name)
+		}
+		return tok.text == "true", false, nil
+	case qcTokenNumber:
+		if isString {
+			return nil, false, p.errorAt(tok, "cannot compare %v field %s with a number", field.datatype, field.name)
+		}
+		value, err := parseQueryConditionNumber(tok.text, field.datatype)
+		if err != nil {
+			return nil, false, p.errorAt(tok, "invalid %v value for %s: %v", field.datatype, field.name, err)
+		}
+		return value, false, nil
+	}
+	return nil, false, p.errorAt(tok, "expected a literal, got %v", tok)
+}
+
+// parseQueryConditionNumber parses a number as a value of the Go type of datatype.
+func parseQueryConditionNumber(text string, datatype Datatype) (interface{}, error) {
+	text = strings.ReplaceAll(text, "_", "")
+	t := datatype.ReflectType()
+	if t == nil {
+		return nil, fmt.Errorf("unsupported datatype %v", datatype)
+	}
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 0, t.Bits())
+		if err != nil {
+			return nil, numError(err)
+		}
+		v.SetInt(n)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 0, t.Bits())
+		if err != nil {
+			return nil, numError(err)
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, t.Bits())
+		if err != nil {
+			return nil, numError(err)
+		}
+		v.SetFloat(f)
+	default:
+		return nil, fmt.Errorf("numbers are not supported for datatype %v", datatype)
+	}
+	return v.Interface(), nil
+}
+
+// numError strips the function name and input from strconv errors.
+func numError(err error) error {
+	if numErr, ok := err.(*strconv.NumError); ok {
+		return numErr.Err
+	}
+	return err
+}
@@ -0,0 +1,115 @@
+package tiledb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQueryCondition(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+
+	schema, err := array.Schema()
+	require.NoError(t, err)
+	defer schema.Free()
+
+	cases := []struct {
+		name           string
+		expr           string
+		expectedString string
+		expectedValues []int32
+	}{
+		{"Comparison", "a1 > 1", "a1 > 1", []int32{2, 3}},
+		{"Equals", "a1 = 2", "a1 == 2", []int32{2}},
+		{"String", `a2 == "ama"`, "a2 == 'ama'", []int32{2}},
+		{"Dimension", "rows >= 2 AND cols < 2", "rows >= 2 and cols < 2", []int32{2}},
+		{"Precedence", "a1 == 1 or a1 == 2 and a2 == 'i'", "a1 == 1 or a1 == 2 and a2 == 'i'", []int32{1}},
+		{"Parentheses", "(a1 == 1 or a1 == 2) and a2 != 'i'", "(a1 == 1 or a1 == 2) and a2 != 'i'", []int32{2}},
+		{"Not", "not (a1 > 1 or a1 < 1)", "not (a1 > 1 or a1 < 1)", []int32{1}},
+		{"In", "a1 > 1 and a2 in ['ama', 'string']", "a1 > 1 and a2 in ['ama', 'string']", []int32{2, 3}},
+		{"NotIn", "a1 not in [1, 3]", "a1 not in [1, 3]", []int32{2}},
+		{"QuotedField", "`a1` <= 0x2", "a1 <= 2", []int32{1, 2}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			qc, err := ParseQueryCondition(array.context, schema, c.expr)
+			require.NoError(t, err)
+			defer qc.Free()
+			assert.Equal(t, c.expectedString, qc.String())
+
+			// The rendered condition parses back to itself.
+			reparsed, err := ParseQueryCondition(array.context, schema, qc.String())
+			require.NoError(t, err)
+			defer reparsed.Free()
+			assert.Equal(t, qc.String(), reparsed.String())
+
+			query, err := NewQuery(array.context, array)
+			require.NoError(t, err)
+			defer query.Free()
+			require.NoError(t, query.SetLayout(TILEDB_ROW_MAJOR))
+
+			a1DataRead := make([]int32, 3)
+			_, err = query.SetDataBuffer("a1", a1DataRead)
+			require.NoError(t, err)
+			require.NoError(t, query.SetQueryCondition(qc))
+
+			require.NoError(t, query.Submit())
+			elements, err := query.ResultBufferElements()
+			require.NoError(t, err)
+			assert.Equal(t, c.expectedValues, a1DataRead[:elements["a1"][1]])
+		})
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+		errorCases := []struct {
+			expr   string
+			offset int
+		}{
+			{"a4 > 1", 0},
+			{"a1 > 'x'", 5},
+			{"a2 < 3", 5},
+			{"a1 > 1 and", 10},
+			{"a1 > 1)", 6},
+			{"(a1 > 1", 7},
+			{"a1 > 99999999999", 5},
+			{"a2 == 'ama", 6},
+			{"a1 is null", 0},
+			{"a1 in []", 7},
+			{"a1 ! 1", 3},
+			{"a1 1", 3},
+		}
+		for _, c := range errorCases {
+			_, err := ParseQueryCondition(array.context, schema, c.expr)
+			var parseErr *QueryConditionParseError
+			if assert.True(t, errors.As(err, &parseErr), "%q: %v", c.expr, err) {
+				assert.Equal(t, c.offset, parseErr.Offset, "%q: %v", c.expr, err)
+				assert.Equal(t, c.expr, parseErr.Expr)
+			}
+		}
+	})
+}
+
+func TestQueryConditionString(t *testing.T) {
+	context, err := NewContext(nil)
+	require.NoError(t, err)
+
+	a, err := NewQueryCondition(context, "a", TILEDB_QUERY_CONDITION_LT, float64(1.5))
+	require.NoError(t, err)
+	b, err := NewQueryCondition(context, "not", TILEDB_QUERY_CONDITION_EQ, "it's")
+	require.NoError(t, err)
+	c, err := NewQueryConditionCombination(context, a, TILEDB_QUERY_CONDITION_OR, b)
+	require.NoError(t, err)
+	d, err := NewQueryConditionNegated(context, a)
+	require.NoError(t, err)
+	e, err := NewQueryConditionCombination(context, c, TILEDB_QUERY_CONDITION_AND, d)
+	require.NoError(t, err)
+
+	assert.Equal(t, "a < 1.5", a.String())
+	assert.Equal(t, "`not` == 'it\\'s'", b.String())
+	assert.Equal(t, "(a < 1.5 or `not` == 'it\\'s') and not a < 1.5", e.String())
+}
@@ -20,7 +20,7 @@ type QueryStatusDetails struct {
 func (q *Query) RelevantFragmentNum() (uint64, error) {
 	var num C.uint64_t
 	if ret := C.tiledb_query_get_relevant_fragment_num(q.context.tiledbContext.Get(), q.tiledbQuery.Get(), &num); ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error getting relevant fragment num from query: %w", q.context.LastError())
+		return 0, fmt.Errorf("error getting relevant fragment num from query: %w", q.context.lastError("tiledb_query_get_relevant_fragment_num", ret))
 	}
 	runtime.KeepAlive(q)
 
@@ -32,7 +32,7 @@ func (q *Query) StatusDetails() (QueryStatusDetails, error) {
 	var details QueryStatusDetails
 	var cDetails C.tiledb_query_status_details_t
 	if ret := C.tiledb_query_get_status_details(q.context.tiledbContext.Get(), q.tiledbQuery.Get(), &cDetails); ret != C.TILEDB_OK {
-		return details, fmt.Errorf("error getting query status details: %w", q.context.LastError())
+		return details, fmt.Errorf("error getting query status details: %w", q.context.lastError("tiledb_query_get_status_details", ret))
 	}
 	runtime.KeepAlive(q)
 	details.IncompleteReason = QueryStatusDetailsReason(cDetails.incomplete_reason)
@@ -66,7 +66,7 @@ func (q *Query) GetPlan() (string, error) {
 
 	ret := C.tiledb_query_get_plan(q.context.tiledbContext.Get(), q.tiledbQuery.Get(), &plan)
 	if ret != C.TILEDB_OK {
-		return "", fmt.Errorf("error getting query plan: %w", q.context.LastError())
+		return "", fmt.Errorf("error getting query plan: %w", q.context.lastError("tiledb_query_get_plan", ret))
 	}
 	runtime.KeepAlive(q)
 	defer C.tiledb_string_free(&plan)
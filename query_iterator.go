@@ -0,0 +1,525 @@
+package tiledb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/TileDB-Inc/TileDB-Go/bytesizes"
+)
+
+// maxEmptySubmissions is the number of consecutive submissions of a TILEDB_INCOMPLETE query
+// that return no results, other than those after which the buffers grow, before a
+// ResultIterator gives up.
+const maxEmptySubmissions = 100
+
+// defaultIteratorBufferBytes limits the initial size of each buffer allocated by a ResultIterator
+// when it is sized from the query's result size estimates.
+const defaultIteratorBufferBytes = 10 * 1024 * 1024
+
+// ResultIteratorOption configures a ResultIterator.
+type ResultIteratorOption func(it *ResultIterator)
+
+// WithInitialBufferBytes sets the initial size in bytes of every buffer owned by the iterator.
+// By default the buffers are sized from the query's result size estimates, up to 10MiB each.
+func WithInitialBufferBytes(n uint64) ResultIteratorOption {
+	return func(it *ResultIterator) {
+		it.initialBytes = n
+	}
+}
+
+// WithMaxBufferBytes limits how large a single buffer may grow when TileDB reports that
+// the buffers are too small to hold even one cell. Zero, the default, means no limit.
+func WithMaxBufferBytes(n uint64) ResultIteratorOption {
+	return func(it *ResultIterator) {
+		it.maxBytes = n
+	}
+}
+
+// WithBufferPool draws the buffers of the iterator from pool. They are returned to the pool
+// when the buffers grow and by Release.
+func WithBufferPool(pool *BufferPool) ResultIteratorOption {
+	return func(it *ResultIterator) {
+		it.pool = pool
+	}
+}
+
+// iteratorField holds the buffers of one field read by a ResultIterator.
+type iteratorField struct {
+	fieldInfo
+	data     interface{}
+	offsets  []uint64
+	validity []uint8
+}
+
+/*
+ResultIterator drives a read query to completion, handling TILEDB_INCOMPLETE
+statuses on behalf of the caller.
+
+The iterator owns the buffers of the fields it reads. Each call to Next
+submits the query and, if TileDB could not fit a single cell in the buffers
+(TILEDB_REASON_USER_BUFFER_SIZE), grows them and resubmits. Other incomplete
+submissions without results are resubmitted up to 100 times in a row before
+Next fails. The batches
+returned by Batch alias these buffers, so their contents are only valid until
+the next call to Next; DetachBatch hands a batch over to the caller instead.
+
+	it, err := query.Iterate([]string{"rows", "cols", "a1"})
+	if err != nil {
+		return err
+	}
+	for it.Next() {
+		batch := it.Batch()
+		a1, err := ResultBatchData[int32](batch, "a1")
+		...
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+*/
+type ResultIterator struct {
+	query        *Query
+	fields       []*iteratorField
+	initialBytes uint64
+	maxBytes     uint64
+	pool         *BufferPool
+	batch        *ResultBatch
+	done         bool
+	err          error
+}
+
+// Iterate returns a ResultIterator reading the given attributes, dimensions or dimension labels.
+// If no field names are given, all dimensions and attributes of the array are read.
+// The layout, subarray and query condition must be set on the query before iterating.
+func (q *Query) Iterate(fieldNames []string, opts ...ResultIteratorOption) (*ResultIterator, error) {
+	queryType, err := q.Type()
+	if err != nil {
+		return nil, err
+	}
+	if queryType != TILEDB_READ {
+		return nil, errors.New("error creating result iterator: query must be a read query")
+	}
+
+	it := &ResultIterator{query: q}
+	for _, opt := range opts {
+		opt(it)
+	}
+
+	schema, err := q.array.Schema()
+	if err != nil {
+		return nil, fmt.Errorf("could not get array schema for Iterate: %w", err)
+	}
+	defer schema.Free()
+
+	if len(fieldNames) == 0 {
+		fieldNames, err = schemaFieldNames(schema)
+		if err != nil {
+			return nil, fmt.Errorf("could not get field names for Iterate: %w", err)
+		}
+	}
+
+	for _, name := range fieldNames {
+		info, err := schemaFieldInfo(schema, name)
+		if err != nil {
+			return nil, fmt.Errorf("could not get field %s for Iterate: %w", name, err)
+		}
+
+		field := &iteratorField{fieldInfo: info}
+		dataBytes, offsetsBytes, err := it.initialSizes(field)
+		if err != nil {
+			return nil, err
+		}
+		if err := allocateField(it.query, it.pool, field, dataBytes, offsetsBytes); err != nil {
+			return nil, err
+		}
+		it.fields = append(it.fields, field)
+	}
+
+	return it, nil
+}
+
+// initialSizes returns the initial data and offsets buffer sizes in bytes for a field,
+// capped at the maximum buffer size.
+func (it *ResultIterator) initialSizes(field *iteratorField) (uint64, uint64, error) {
+	limit := uint64(defaultIteratorBufferBytes)
+	if it.initialBytes > 0 {
+		limit = it.initialBytes
+	}
+	if it.maxBytes > 0 {
+		limit = min(limit, it.maxBytes)
+	}
+	if it.initialBytes > 0 {
+		return limit, limit, nil
+	}
+
+	dataBytes, offsetsBytes, _, err := estimateFieldBytes(it.query, field.fieldInfo)
+	if err != nil {
+		return 0, 0, err
+	}
+	return min(dataBytes, limit), min(offsetsBytes, limit), nil
+}
+
+// estimateFieldBytes returns the estimated sizes in bytes of the data, offsets and validity
+// buffers of a field read by the query.
+func estimateFieldBytes(q *Query, field fieldInfo) (uint64, uint64, uint64, error) {
+	switch {
+	case field.isVar() && field.nullable:
+		offsets, data, validity, err := q.EstResultSizeVarNullable(field.name)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		return *data, *offsets, *validity, nil
+	case field.isVar():
+		offsets, data, err := q.EstResultSizeVar(field.name)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		return *data, *offsets, 0, nil
+	case field.nullable:
+		data, validity, err := q.EstResultSizeNullable(field.name)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		return *data, 0, *validity, nil
+	default:
+		data, err := q.EstResultSize(field.name)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		return *data, 0, 0, nil
+	}
+}
+
+// allocateField allocates the buffers of a field from pool with room for at least one cell,
+// using the given sizes in bytes, and sets them on the query.
+func allocateField(q *Query, pool *BufferPool, field *iteratorField, dataBytes, offsetsBytes uint64) error {
+	cellValNum := uint64(1)
+	if !field.isVar() {
+		cellValNum = uint64(field.cellValNum)
+	}
+
+	// Allocate room for at least one cell.
+	dataElements := max(dataBytes/field.datatype.Size(), cellValNum)
+	cells := dataElements / cellValNum
+	if field.isVar() {
+		cells = max(offsetsBytes/bytesizes.Uint64, 1)
+	}
+
+	data, err := pool.Get(field.datatype, dataElements)
+	if err != nil {
+		return err
+	}
+	if _, err := q.SetDataBuffer(field.name, data); err != nil {
+		return err
+	}
+	field.data = data
+
+	if field.isVar() {
+		// The spare capacity lets the final end offset be appended without copying.
+		offsets, err := pool.Get(TILEDB_UINT64, cells+1)
+		if err != nil {
+			return err
+		}
+		field.offsets = offsets.([]uint64)[:cells]
+		if _, err := q.SetOffsetsBuffer(field.name, field.offsets); err != nil {
+			return err
+		}
+	}
+
+	if field.nullable {
+		validity, err := pool.Get(TILEDB_UINT8, cells)
+		if err != nil {
+			return err
+		}
+		field.validity = validity.([]uint8)
+		if _, err := q.SetValidityBuffer(field.name, field.validity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// release returns the buffers of the field to pool.
+func (f *iteratorField) release(pool *BufferPool) {
+	pool.Put(f.datatype, f.data)
+	if f.offsets != nil {
+		pool.Put(TILEDB_UINT64, f.offsets)
+	}
+	if f.validity != nil {
+		pool.Put(TILEDB_UINT8, f.validity)
+	}
+	f.data, f.offsets, f.validity = nil, nil, nil
+}
+
+// disown tells pool that the buffers of the field will not be returned to it.
+func (f *iteratorField) disown(pool *BufferPool) {
+	pool.disown(f.data)
+	if f.offsets != nil {
+		pool.disown(f.offsets)
+	}
+	if f.validity != nil {
+		pool.disown(f.validity)
+	}
+}
+
+// grow doubles the data buffers of every var-sized field, up to the maximum buffer size.
+// TileDB does not report which field could not hold a single cell. The buffers of
+// fixed-sized fields always hold at least one cell, so only the data buffers of var-sized
+// fields can be too small; the buffers of every field are grown only if none is var-sized.
+func (it *ResultIterator) grow() error {
+	overflowed := make([]*iteratorField, 0, len(it.fields))
+	for _, field := range it.fields {
+		if field.isVar() {
+			overflowed = append(overflowed, field)
+		}
+	}
+	if len(overflowed) == 0 {
+		overflowed = it.fields
+	}
+
+	for _, field := range overflowed {
+		// The offsets of a var-sized field already hold at least one cell and are kept.
+		dataBytes := it.growBytes(uint64(reflect.ValueOf(field.data).Len()) * field.datatype.Size())
+		offsetsBytes := uint64(len(field.offsets)) * bytesizes.Uint64
+		if dataBytes == 0 {
+			return errorf(ErrBufferTooSmall, "error growing buffers for %s: a single cell does not fit in %d bytes", field.name, it.maxBytes)
+		}
+		previous := *field
+		if err := allocateField(it.query, it.pool, field, dataBytes, offsetsBytes); err != nil {
+			return fmt.Errorf("error growing buffers for %s: %w", field.name, err)
+		}
+		previous.release(it.pool)
+	}
+	return nil
+}
+
+// growBytes returns twice n, capped at the maximum buffer size, or zero if n already
+// reached it.
+func (it *ResultIterator) growBytes(n uint64) uint64 {
+	if it.maxBytes == 0 {
+		return n * 2
+	}
+	if n >= it.maxBytes {
+		return 0
+	}
+	return min(n*2, it.maxBytes)
+}
+
+// Next submits the query until it produces results or completes. It returns false when
+// there are no more results or an error occurred; use Err to distinguish the two.
+func (it *ResultIterator) Next() bool {
+	it.batch = nil
+	if it.done || it.err != nil {
+		return false
+	}
+
+	empty := 0
+	for {
+		if err := it.query.Submit(); err != nil {
+			it.err = err
+			return false
+		}
+
+		status, err := it.query.Status()
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if status != TILEDB_INCOMPLETE {
+			it.done = true
+		}
+
+		elements, err := it.query.ResultBufferElements()
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		batch := it.makeBatch(elements)
+		if batch.NumCells() > 0 {
+			it.batch = batch
+			return true
+		}
+		if it.done {
+			return false
+		}
+
+		details, err := it.query.StatusDetails()
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if details.IncompleteReason == TILEDB_REASON_USER_BUFFER_SIZE {
+			if err := it.grow(); err != nil {
+				it.err = err
+				return false
+			}
+			continue
+		}
+
+		// When the memory budget was exceeded the query can simply be resubmitted, but a
+		// query that never produces results must not be resubmitted forever.
+		empty++
+		if empty >= maxEmptySubmissions {
+			it.err = fmt.Errorf("error reading results: query still incomplete (%v) without results after %d submissions", details.IncompleteReason, empty)
+			return false
+		}
+	}
+}
+
+// makeBatch builds a ResultBatch trimmed to the number of result elements of each field.
+func (it *ResultIterator) makeBatch(elements map[string][3]uint64) *ResultBatch {
+	return newResultBatch(it.fields, elements)
+}
+
+// newResultBatch builds a ResultBatch of the buffers of fields trimmed to the number of result
+// elements of each field.
+func newResultBatch(fields []*iteratorField, elements map[string][3]uint64) *ResultBatch {
+	batch := &ResultBatch{fields: make(map[string]resultBatchField, len(fields))}
+	for _, field := range fields {
+		counts := elements[field.name]
+		f := resultBatchField{
+			data:       reflect.ValueOf(field.data).Slice(0, int(counts[1])).Interface(),
+			cellValNum: field.cellValNum,
+		}
+		if field.offsets != nil {
+			f.offsets = field.offsets[:counts[0]]
+		}
+		if field.validity != nil {
+			f.validity = field.validity[:counts[2]]
+		}
+		batch.fields[field.name] = f
+		// The fields hold the same cells; the largest count keeps a batch from passing as empty.
+		batch.numCells = max(batch.numCells, f.numCells())
+		batch.names = append(batch.names, field.name)
+	}
+	return batch
+}
+
+// Batch returns the results of the last successful call to Next.
+// The batch is only valid until the next call to Next.
+func (it *ResultIterator) Batch() *ResultBatch {
+	return it.batch
+}
+
+// DetachBatch returns the results of the last successful call to Next like Batch, and gives
+// the iterator new buffers of the same sizes for the next call, so that the batch remains
+// valid after it. The buffers of a detached batch belong to the caller and are not returned
+// to the BufferPool of the iterator. It returns nil if there is no batch.
+func (it *ResultIterator) DetachBatch() (*ResultBatch, error) {
+	batch := it.batch
+	if batch == nil {
+		return nil, nil
+	}
+	for _, field := range it.fields {
+		previous := *field
+		if it.done {
+			// The query will not be submitted again, so no new buffers are needed.
+			field.data, field.offsets, field.validity = nil, nil, nil
+		} else {
+			dataBytes := uint64(reflect.ValueOf(field.data).Len()) * field.datatype.Size()
+			offsetsBytes := uint64(len(field.offsets)) * bytesizes.Uint64
+			if err := allocateField(it.query, it.pool, field, dataBytes, offsetsBytes); err != nil {
+				return nil, fmt.Errorf("error detaching buffers for %s: %w", field.name, err)
+			}
+		}
+		previous.disown(it.pool)
+	}
+	it.batch = nil
+	return batch, nil
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (it *ResultIterator) Err() error {
+	return it.err
+}
+
+// Release returns the buffers of the iterator to its BufferPool, if any. Neither the iterator,
+// its batches nor the query must be used afterwards.
+func (it *ResultIterator) Release() {
+	it.batch = nil
+	it.done = true
+	for _, field := range it.fields {
+		field.release(it.pool)
+	}
+}
+
+// resultBatchField holds the trimmed buffers of one field of a ResultBatch.
+type resultBatchField struct {
+	data       interface{}
+	offsets    []uint64
+	validity   []uint8
+	cellValNum uint32
+}
+
+// numCells returns the number of cells of the field: the number of offsets of a var-sized
+// field, and the number of values divided by the number of values per cell of a fixed-sized one.
+func (f resultBatchField) numCells() uint64 {
+	if f.cellValNum == TILEDB_VAR_NUM {
+		return uint64(len(f.offsets))
+	}
+	return uint64(reflect.ValueOf(f.data).Len()) / uint64(f.cellValNum)
+}
+
+// ResultBatch holds the results of a single submission of a read query.
+type ResultBatch struct {
+	names    []string
+	fields   map[string]resultBatchField
+	numCells uint64
+}
+
+// clone returns a copy of the batch that does not alias the buffers of the iterator.
+func (b *ResultBatch) clone() *ResultBatch {
+	c := &ResultBatch{names: b.names, fields: make(map[string]resultBatchField, len(b.fields)), numCells: b.numCells}
+	for name, f := range b.fields {
+		data := reflect.ValueOf(f.data)
+		dataCopy := reflect.MakeSlice(data.Type(), data.Len(), data.Len())
+		reflect.Copy(dataCopy, data)
+		f.data = dataCopy.Interface()
+		f.offsets = slices.Clone(f.offsets)
+		f.validity = slices.Clone(f.validity)
+		c.fields[name] = f
+	}
+	return c
+}
+
+// NumCells returns the number of cells in the batch.
+func (b *ResultBatch) NumCells() uint64 {
+	return b.numCells
+}
+
+// Fields returns the names of the fields in the batch.
+func (b *ResultBatch) Fields() []string {
+	return b.names
+}
+
+// Data returns the data of a field as a slice of the Go type matching its Datatype,
+// or nil if the field was not read.
+func (b *ResultBatch) Data(name string) interface{} {
+	return b.fields[name].data
+}
+
+// Offsets returns the offsets of a var-sized field, or nil if the field is not var-sized.
+func (b *ResultBatch) Offsets(name string) []uint64 {
+	return b.fields[name].offsets
+}
+
+// Validity returns the validity values of a nullable field, or nil if the field is not nullable.
+func (b *ResultBatch) Validity(name string) []uint8 {
+	return b.fields[name].validity
+}
+
+// ResultBatchData returns the data of a field of the batch as a []T.
+// It returns an error if the field was not read or T does not match the field's Datatype.
+func ResultBatchData[T any](b *ResultBatch, name string) ([]T, error) {
+	f, ok := b.fields[name]
+	if !ok {
+		return nil, errorf(ErrNotFound, "field %s is not part of the result batch", name)
+	}
+	data, ok := f.data.([]T)
+	if !ok {
+		return nil, errorf(ErrSchemaMismatch, "cannot get %T data of field %s as %v", f.data, name, reflect.TypeOf([]T(nil)))
+	}
+	return data, nil
+}
@@ -0,0 +1,34 @@
+//go:build go1.23
+
+package tiledb
+
+import "iter"
+
+// Batches returns an iterator over the result batches of a read query, for use with range-over-func:
+//
+//	for batch, err := range query.Batches([]string{"a1"}) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// Batches are only valid until the next iteration. See Iterate for the meaning of the arguments.
+func (q *Query) Batches(fieldNames []string, opts ...ResultIteratorOption) iter.Seq2[*ResultBatch, error] {
+	return func(yield func(*ResultBatch, error) bool) {
+		it, err := q.Iterate(fieldNames, opts...)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		defer it.Release()
+		for it.Next() {
+			if !yield(it.Batch(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+//go:build go1.23
+
+package tiledb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryBatches(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+
+	query, err := NewQuery(array.context, array)
+	require.NoError(t, err)
+	defer query.Free()
+	require.NoError(t, query.SetLayout(TILEDB_ROW_MAJOR))
+
+	var a3 []int64
+	for batch, err := range query.Batches([]string{"a3"}, WithInitialBufferBytes(8)) {
+		require.NoError(t, err)
+		data, err := ResultBatchData[int64](batch, "a3")
+		require.NoError(t, err)
+		a3 = append(a3, data...)
+	}
+	assert.Equal(t, testAttributeValues.Attribute3, a3)
+}
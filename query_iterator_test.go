@@ -0,0 +1,148 @@
+package tiledb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultIterator(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+
+	query, err := NewQuery(array.context, array)
+	require.NoError(t, err)
+	defer query.Free()
+	require.NoError(t, query.SetLayout(TILEDB_ROW_MAJOR))
+
+	// Buffers of one byte force the iterator to grow them and to resubmit the query.
+	it, err := query.Iterate([]string{"rows", "a1", "a2"}, WithInitialBufferBytes(1))
+	require.NoError(t, err)
+
+	var rows, a1 []int32
+	var a2 []string
+	for it.Next() {
+		batch := it.Batch()
+		assert.Equal(t, []string{"rows", "a1", "a2"}, batch.Fields())
+
+		rowsData, err := ResultBatchData[int32](batch, "rows")
+		require.NoError(t, err)
+		rows = append(rows, rowsData...)
+
+		a1Data, err := ResultBatchData[int32](batch, "a1")
+		require.NoError(t, err)
+		require.Len(t, a1Data, int(batch.NumCells()))
+		a1 = append(a1, a1Data...)
+
+		a2Data, err := ResultBatchData[uint8](batch, "a2")
+		require.NoError(t, err)
+		offsets := batch.Offsets("a2")
+		require.Len(t, offsets, int(batch.NumCells()))
+		for i, off := range offsets {
+			end := uint64(len(a2Data))
+			if i+1 < len(offsets) {
+				end = offsets[i+1]
+			}
+			a2 = append(a2, string(a2Data[off:end]))
+		}
+
+		_, err = ResultBatchData[int64](batch, "a1")
+		assert.Error(t, err)
+	}
+	require.NoError(t, it.Err())
+
+	assert.Equal(t, []int32{1, 2, 2}, rows)
+	assert.Equal(t, testAttributeValues.Attribute1, a1)
+	assert.Equal(t, []string{"i", "ama", "string"}, a2)
+	assert.False(t, it.Next())
+}
+
+func TestResultIteratorMaxBufferBytes(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+
+	query, err := NewQuery(array.context, array)
+	require.NoError(t, err)
+	defer query.Free()
+	require.NoError(t, query.SetLayout(TILEDB_ROW_MAJOR))
+
+	// "string" needs 6 bytes, so the buffers of a2 can never hold it.
+	it, err := query.Iterate([]string{"a1", "a2"}, WithInitialBufferBytes(1), WithMaxBufferBytes(4))
+	require.NoError(t, err)
+	var a2 []string
+	for it.Next() {
+		a2Data, err := ResultBatchData[uint8](it.Batch(), "a2")
+		require.NoError(t, err)
+		a2 = append(a2, string(a2Data))
+	}
+	require.ErrorIs(t, it.Err(), ErrBufferTooSmall)
+	assert.Equal(t, []string{"i", "ama"}, a2)
+
+	// Only the data buffer of a2 could overflow, so it is the only buffer grown.
+	assert.Len(t, it.fields[0].data, 1)
+	assert.Len(t, it.fields[1].data, 4)
+	assert.Len(t, it.fields[1].offsets, 1)
+}
+
+func TestResultIteratorDetachBatch(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+
+	query, err := NewQuery(array.context, array)
+	require.NoError(t, err)
+	defer query.Free()
+	require.NoError(t, query.SetLayout(TILEDB_ROW_MAJOR))
+
+	pool := NewBufferPool(1 << 20)
+	it, err := query.Iterate([]string{"a1"}, WithInitialBufferBytes(4), WithBufferPool(pool))
+	require.NoError(t, err)
+
+	// Detached batches keep their values after later calls to Next.
+	var batches []*ResultBatch
+	for it.Next() {
+		batch, err := it.DetachBatch()
+		require.NoError(t, err)
+		batches = append(batches, batch)
+		assert.Nil(t, it.Batch())
+	}
+	require.NoError(t, it.Err())
+	it.Release()
+
+	// The detached buffers are not returned to the pool, so reusing its buffers leaves them intact.
+	reused, err := pool.Get(TILEDB_INT32, 1)
+	require.NoError(t, err)
+	reused.([]int32)[0] = -1
+
+	var a1 []int32
+	for _, batch := range batches {
+		data, err := ResultBatchData[int32](batch, "a1")
+		require.NoError(t, err)
+		a1 = append(a1, data...)
+	}
+	assert.Equal(t, testAttributeValues.Attribute1, a1)
+
+	batch, err := it.DetachBatch()
+	require.NoError(t, err)
+	assert.Nil(t, batch)
+}
+
+func TestResultBatchNumCells(t *testing.T) {
+	fields := []*iteratorField{
+		{fieldInfo: fieldInfo{name: "a2", datatype: TILEDB_STRING_ASCII, cellValNum: TILEDB_VAR_NUM}, data: []uint8("amastring"), offsets: []uint64{0, 3}},
+		{fieldInfo: fieldInfo{name: "a3", datatype: TILEDB_INT32, cellValNum: 2}, data: []int32{1, 2, 3, 4}},
+	}
+
+	batch := newResultBatch(fields, map[string][3]uint64{"a2": {2, 9, 0}, "a3": {0, 4, 0}})
+	assert.Equal(t, uint64(2), batch.NumCells())
+
+	// The number of cells does not depend on the first field only.
+	batch = newResultBatch(fields, map[string][3]uint64{"a2": {0, 0, 0}, "a3": {0, 2, 0}})
+	assert.Equal(t, uint64(1), batch.NumCells())
+}
@@ -3,6 +3,7 @@ package tiledb
 import (
 	"os"
 	"testing"
+	"time"
 	"unsafe"
 
 	"github.com/stretchr/testify/assert"
@@ -3734,3 +3735,133 @@ func TestGetExpectedOffsetsBufferLength(t *testing.T) {
 		require.Equal(t, uint64(4), siz)
 	})
 }
+
+func TestQuerySubmitAsync(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+
+	queries := make([]*Query, 4)
+	buffers := make([][]int32, len(queries))
+	results := make([]<-chan QueryResult, len(queries))
+	for i := range queries {
+		queries[i], err = NewQuery(array.context, array)
+		require.NoError(t, err)
+		defer queries[i].Free()
+		buffers[i] = make([]int32, 3)
+		_, err = queries[i].SetDataBuffer("a1", buffers[i])
+		require.NoError(t, err)
+	}
+	for i, query := range queries {
+		results[i] = query.SubmitAsync()
+	}
+
+	for i, result := range results {
+		res := <-result
+		require.NoError(t, res.Err)
+		assert.Equal(t, TILEDB_COMPLETED, res.Status)
+		assert.Equal(t, [3]uint64{0, 3, 0}, res.Elements["a1"])
+		assert.Equal(t, testAttributeValues.Attribute1, buffers[i])
+
+		_, ok := <-result
+		assert.False(t, ok)
+	}
+
+	// The buffers of a query cannot be used while a submission is in progress.
+	queries[0].submitting.Store(true)
+	_, err = queries[0].SetDataBuffer("a1", buffers[0])
+	assert.ErrorIs(t, err, errSubmitInFlight)
+	_, err = queries[0].ResultBufferElements()
+	assert.ErrorIs(t, err, errSubmitInFlight)
+	queries[0].submitting.Store(false)
+}
+
+func TestQueryTimes(t *testing.T) {
+	arrPath := t.TempDir()
+	cfg, err := NewConfig()
+	require.NoError(t, err)
+	tdbCtx, err := NewContext(cfg)
+	require.NoError(t, err)
+	schema, err := NewArraySchema(tdbCtx, TILEDB_SPARSE)
+	require.NoError(t, err)
+	domain, err := NewDomain(tdbCtx)
+	require.NoError(t, err)
+	tDim, err := NewDimension(tdbCtx, "t", TILEDB_DATETIME_MS, []int64{0, 1 << 50}, int64(1<<20))
+	require.NoError(t, err)
+	require.NoError(t, domain.AddDimensions(tDim))
+	require.NoError(t, schema.SetDomain(domain))
+	dayAttr, err := NewAttribute(tdbCtx, "day", TILEDB_DATETIME_DAY)
+	require.NoError(t, err)
+	require.NoError(t, schema.AddAttributes(dayAttr))
+	require.NoError(t, CreateArray(tdbCtx, arrPath, schema))
+
+	start := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	times := []time.Time{start, start.Add(36 * time.Hour), start.Add(72 * time.Hour)}
+
+	wArr := openArray(t, arrPath, TILEDB_WRITE)
+	wq, err := NewQuery(tdbCtx, wArr)
+	require.NoError(t, err)
+	require.NoError(t, wq.SetLayout(TILEDB_UNORDERED))
+	_, err = wq.SetDataBuffer("t", times)
+	require.NoError(t, err)
+	_, err = wq.SetDataBuffer("day", times)
+	require.NoError(t, err)
+	require.NoError(t, wq.Submit())
+
+	rArr := openArray(t, arrPath, TILEDB_READ)
+	rq, err := NewQuery(tdbCtx, rArr)
+	require.NoError(t, err)
+	require.NoError(t, rq.SetLayout(TILEDB_ROW_MAJOR))
+	sa, err := rArr.NewSubarray()
+	require.NoError(t, err)
+	require.NoError(t, sa.AddRangeByName("t", MakeRange(start, start.Add(100*time.Hour))))
+	require.NoError(t, rq.SetSubarray(sa))
+	qc, err := NewQueryCondition(tdbCtx, "day", TILEDB_QUERY_CONDITION_GT, start)
+	require.NoError(t, err)
+	assert.Equal(t, "day > '2024-03-15T12:00:00Z'", qc.String())
+	require.NoError(t, rq.SetQueryCondition(qc))
+	_, err = rq.SetDataBuffer("t", make([]time.Time, 3))
+	require.NoError(t, err)
+	_, err = rq.SetDataBuffer("day", make([]int64, 3))
+	require.NoError(t, err)
+	require.NoError(t, rq.Submit())
+
+	gotTimes, err := rq.GetTimeResults("t")
+	require.NoError(t, err)
+	assert.Equal(t, times[1:], gotTimes)
+	gotDays, err := rq.GetTimeResults("day")
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 18, 0, 0, 0, 0, time.UTC)}, gotDays)
+
+	_, err = rq.GetTimeResults("missing")
+	assert.Error(t, err)
+
+	// A time condition combined with a plain one resolves after its parts are freed.
+	after, err := NewQueryCondition(tdbCtx, "day", TILEDB_QUERY_CONDITION_GT, start)
+	require.NoError(t, err)
+	before, err := NewQueryCondition(tdbCtx, "t", TILEDB_QUERY_CONDITION_LT, start.Add(50*time.Hour).UnixMilli())
+	require.NoError(t, err)
+	combined, err := NewQueryConditionCombination(tdbCtx, after, TILEDB_QUERY_CONDITION_AND, before)
+	require.NoError(t, err)
+	after.Free()
+	before.Free()
+	negated, err := NewQueryConditionNegated(tdbCtx, combined)
+	require.NoError(t, err)
+	combined.Free()
+	require.NoError(t, negated.UseEnumeration(false))
+	defer negated.Free()
+
+	rq2, err := NewQuery(tdbCtx, rArr)
+	require.NoError(t, err)
+	defer rq2.Free()
+	require.NoError(t, rq2.SetLayout(TILEDB_ROW_MAJOR))
+	require.NoError(t, rq2.SetSubarray(sa))
+	require.NoError(t, rq2.SetQueryCondition(negated))
+	_, err = rq2.SetDataBuffer("t", make([]time.Time, 3))
+	require.NoError(t, err)
+	require.NoError(t, rq2.Submit())
+	gotTimes, err = rq2.GetTimeResults("t")
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{times[0], times[2]}, gotTimes)
+}
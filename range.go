@@ -4,11 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 )
 
-// DimensionType is a constraint for the types allowed for a TileDB dimension
+// DimensionType is a constraint for the types allowed for a TileDB dimension.
+// time.Time is converted to the timestamps of datetime and time dimensions.
 type DimensionType interface {
-	~string | ~float32 | ~float64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~bool
+	~string | ~float32 | ~float64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~int | ~int8 | ~int16 | ~int32 | ~int64 | ~bool | time.Time
 }
 
 // Range is an 1D range along a subarray dimension
@@ -52,6 +54,24 @@ func (r Range) Endpoints() (start, end any) {
 	return r.start, r.end
 }
 
+// withTimestamps returns the range with time.Time endpoints converted to the timestamps of
+// datatype dt. Other ranges are returned unchanged.
+func (r Range) withTimestamps(dt Datatype) (Range, error) {
+	start, ok := r.start.(time.Time)
+	if !ok {
+		return r, nil
+	}
+	startTimestamp, err := TimestampFromTime(dt, start)
+	if err != nil {
+		return r, err
+	}
+	endTimestamp, err := TimestampFromTime(dt, r.end.(time.Time))
+	if err != nil {
+		return r, err
+	}
+	return MakeRange(startTimestamp, endTimestamp), nil
+}
+
 // assertCompatibility checks that the datatype of an array dimension are the same as the range's.
 func (r Range) assertCompatibility(dimType Datatype, dimIsVar bool) error {
 	dKind := dimType.ReflectKind()
@@ -62,13 +82,13 @@ func (r Range) assertCompatibility(dimType Datatype, dimIsVar bool) error {
 		return errors.New("only []byte var dimensions are supported")
 	}
 	if dimIsVar && !rIsVar {
-		return errors.New("dimension is of variable size but range is not")
+		return errorf(ErrSchemaMismatch, "dimension is of variable size but range is not")
 	}
 	if !dimIsVar && rIsVar {
-		return errors.New("range is of variable size but dimension is not")
+		return errorf(ErrSchemaMismatch, "range is of variable size but dimension is not")
 	}
 	if !dimIsVar && dKind != rKind {
-		return fmt.Errorf("dimension and range types mismatch, range: %s dimension: %s", rKind, dKind)
+		return errorf(ErrSchemaMismatch, "dimension and range types mismatch, range: %s dimension: %s", rKind, dKind)
 	}
 
 	return nil
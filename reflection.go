@@ -80,3 +80,137 @@ func addressableValue(val any) reflect.Value {
 	pointable.Elem().Set(valVal)
 	return pointable
 }
+
+// fieldInfo describes an attribute, dimension or dimension label of an array schema.
+type fieldInfo struct {
+	name        string
+	datatype    Datatype
+	cellValNum  uint32
+	nullable    bool
+	isDimension bool
+}
+
+// isVar returns whether the field is of variable size.
+func (f fieldInfo) isVar() bool {
+	return f.cellValNum == TILEDB_VAR_NUM
+}
+
+// schemaFieldInfo returns the fieldInfo for the attribute, dimension or dimension label named name.
+func schemaFieldInfo(schema *ArraySchema, name string) (fieldInfo, error) {
+	info := fieldInfo{name: name}
+
+	domain, err := schema.Domain()
+	if err != nil {
+		return info, err
+	}
+	defer domain.Free()
+
+	hasDim, err := domain.HasDimension(name)
+	if err != nil {
+		return info, err
+	}
+	if hasDim {
+		dimension, err := domain.DimensionFromName(name)
+		if err != nil {
+			return info, err
+		}
+		defer dimension.Free()
+
+		if info.datatype, err = dimension.Type(); err != nil {
+			return info, err
+		}
+		if info.cellValNum, err = dimension.CellValNum(); err != nil {
+			return info, err
+		}
+		info.isDimension = true
+		return info, nil
+	}
+
+	hasAttr, err := schema.HasAttribute(name)
+	if err != nil {
+		return info, err
+	}
+	if hasAttr {
+		attribute, err := schema.AttributeFromName(name)
+		if err != nil {
+			return info, err
+		}
+		defer attribute.Free()
+
+		if info.datatype, err = attribute.Type(); err != nil {
+			return info, err
+		}
+		if info.cellValNum, err = attribute.CellValNum(); err != nil {
+			return info, err
+		}
+		if info.nullable, err = attribute.Nullable(); err != nil {
+			return info, err
+		}
+		return info, nil
+	}
+
+	hasDimLabel, err := schema.HasDimensionLabel(name)
+	if err != nil {
+		return info, err
+	}
+	if hasDimLabel {
+		dimLabel, err := schema.DimensionLabelFromName(name)
+		if err != nil {
+			return info, err
+		}
+		defer dimLabel.Free()
+
+		if info.datatype, err = dimLabel.Type(); err != nil {
+			return info, err
+		}
+		if info.cellValNum, err = dimLabel.CellValNum(); err != nil {
+			return info, err
+		}
+		return info, nil
+	}
+
+	return info, errorf(ErrNotFound, "attribute/dimension/label %s does not exist", name)
+}
+
+// schemaFieldNames returns the names of all dimensions followed by the names of all attributes of the schema.
+func schemaFieldNames(schema *ArraySchema) ([]string, error) {
+	domain, err := schema.Domain()
+	if err != nil {
+		return nil, err
+	}
+	defer domain.Free()
+
+	ndim, err := domain.NDim()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, ndim)
+	for i := uint(0); i < ndim; i++ {
+		dimension, err := domain.DimensionFromIndex(i)
+		if err != nil {
+			return nil, err
+		}
+		name, err := dimension.Name()
+		dimension.Free()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	attributes, err := schema.Attributes()
+	if err != nil {
+		return nil, err
+	}
+	for _, attribute := range attributes {
+		name, err := attribute.Name()
+		attribute.Free()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	return names, nil
+}
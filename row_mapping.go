@@ -0,0 +1,374 @@
+package tiledb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// structTagKey is the struct tag used to map struct fields to TileDB attributes and dimensions.
+const structTagKey = "tiledb"
+
+// structField is a struct field carrying a `tiledb` tag.
+type structField struct {
+	index   []int
+	name    string
+	typ     reflect.Type
+	options map[string]string
+}
+
+// hasOption returns whether the tag of the field has the given flag or key.
+func (f structField) hasOption(key string) bool {
+	_, ok := f.options[key]
+	return ok
+}
+
+// parseStructFields returns the tagged fields of the struct type t, including promoted fields of embedded structs.
+func parseStructFields(t reflect.Type) ([]structField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot map type %v: not a struct", t)
+	}
+
+	var fields []structField
+	seen := make(map[string]bool)
+	for _, sf := range reflect.VisibleFields(t) {
+		if !sf.IsExported() {
+			continue
+		}
+		tag, ok := sf.Tag.Lookup(structTagKey)
+		if !ok || tag == "-" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		field := structField{
+			index:   sf.Index,
+			name:    strings.TrimSpace(parts[0]),
+			typ:     sf.Type,
+			options: make(map[string]string),
+		}
+		if field.name == "" {
+			field.name = sf.Name
+		}
+		for _, opt := range parts[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(opt), "=")
+			if key != "" {
+				field.options[key] = value
+			}
+		}
+
+		if seen[field.name] {
+			return nil, fmt.Errorf("cannot map type %v: %s is tagged more than once", t, field.name)
+		}
+		seen[field.name] = true
+		fields = append(fields, field)
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("cannot map type %v: no fields with a %q tag", t, structTagKey)
+	}
+
+	return fields, nil
+}
+
+// structColumn maps a struct field to an attribute or dimension of an array schema.
+type structColumn struct {
+	structField
+	info      fieldInfo
+	valueType reflect.Type // the field type with any pointer removed
+	elemType  reflect.Type // the Go type of the elements of the query buffer
+}
+
+// newStructColumns maps the tagged fields of t to the fields of schema, validating that their types are compatible.
+func newStructColumns(schema *ArraySchema, t reflect.Type) ([]*structColumn, error) {
+	fields, err := parseStructFields(t)
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]*structColumn, 0, len(fields))
+	for _, field := range fields {
+		info, err := schemaFieldInfo(schema, field.name)
+		if err != nil {
+			return nil, fmt.Errorf("cannot map field %s of %v: %w", field.name, t, err)
+		}
+		if field.hasOption("dim") && !info.isDimension {
+			return nil, fmt.Errorf("cannot map field %s of %v: tagged as dimension but it is not a dimension", field.name, t)
+		}
+
+		column := &structColumn{structField: field, info: info, valueType: field.typ}
+		if column.valueType.Kind() == reflect.Pointer {
+			if !info.nullable {
+				return nil, fmt.Errorf("cannot map field %s of %v: pointer fields require a nullable attribute", field.name, t)
+			}
+			column.valueType = column.valueType.Elem()
+		}
+
+		column.elemType = info.datatype.ReflectType()
+		if column.elemType == nil {
+			return nil, fmt.Errorf("cannot map field %s of %v: unsupported datatype %v", field.name, t, info.datatype)
+		}
+		if err := column.checkType(); err != nil {
+			return nil, fmt.Errorf("cannot map field %s of %v: %w", field.name, t, err)
+		}
+
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+// checkType validates the Go type of the field against the datatype and cell value number of the schema field.
+func (c *structColumn) checkType() error {
+	elemKind := c.elemType.Kind()
+	switch {
+	case c.info.isVar():
+		if c.valueType.Kind() == reflect.String {
+			if elemKind != reflect.Uint8 {
+				return errorf(ErrSchemaMismatch, "string fields require a var-sized 8-bit datatype, got %v", c.info.datatype)
+			}
+			return nil
+		}
+		if c.valueType.Kind() != reflect.Slice || c.valueType.Elem().Kind() != elemKind {
+			return errorf(ErrSchemaMismatch, "var-sized %v field requires a string or a slice of %v, got %v", c.info.datatype, elemKind, c.valueType)
+		}
+	case c.info.cellValNum > 1:
+		if c.valueType.Kind() != reflect.Array || c.valueType.Len() != int(c.info.cellValNum) || c.valueType.Elem().Kind() != elemKind {
+			return errorf(ErrSchemaMismatch, "field with %d %v values per cell requires [%d]%v, got %v", c.info.cellValNum, c.info.datatype, c.info.cellValNum, elemKind, c.valueType)
+		}
+	default:
+		if c.valueType.Kind() != elemKind {
+			return errorf(ErrSchemaMismatch, "%v field requires a %v, got %v", c.info.datatype, elemKind, c.valueType)
+		}
+	}
+	return nil
+}
+
+// value returns the value of the column in row, dereferencing pointers. It returns false for nil pointers.
+func (c *structColumn) value(row reflect.Value) (reflect.Value, bool) {
+	v := row.FieldByIndex(c.index)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
+
+// columnBuffers are the query buffers built from a column of rows.
+type columnBuffers struct {
+	data     reflect.Value
+	offsets  []uint64
+	validity []uint8
+}
+
+// buffers builds the data, offsets and validity buffers of the column for rows.
+func (c *structColumn) buffers(rows reflect.Value) columnBuffers {
+	n := rows.Len()
+	size := c.info.datatype.Size()
+	cellValNum := 1
+	if !c.info.isVar() {
+		cellValNum = int(c.info.cellValNum)
+	}
+
+	b := columnBuffers{data: reflect.MakeSlice(reflect.SliceOf(c.elemType), 0, n*cellValNum)}
+	if c.info.isVar() {
+		b.offsets = make([]uint64, 0, n)
+	}
+	if c.info.nullable {
+		b.validity = make([]uint8, 0, n)
+	}
+
+	for i := 0; i < n; i++ {
+		v, valid := c.value(rows.Index(i))
+		if c.info.isVar() {
+			b.offsets = append(b.offsets, uint64(b.data.Len())*size)
+		}
+		if c.info.nullable {
+			if valid {
+				b.validity = append(b.validity, 1)
+			} else {
+				b.validity = append(b.validity, 0)
+			}
+		}
+
+		switch {
+		case !valid:
+			// Fixed-size cells still need a placeholder value.
+			if !c.info.isVar() {
+				b.data = reflect.AppendSlice(b.data, reflect.MakeSlice(b.data.Type(), cellValNum, cellValNum))
+			}
+		case v.Kind() == reflect.String:
+			b.data = reflect.AppendSlice(b.data, reflect.ValueOf([]byte(v.String())).Convert(b.data.Type()))
+		case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+			for j := 0; j < v.Len(); j++ {
+				b.data = reflect.Append(b.data, v.Index(j).Convert(c.elemType))
+			}
+		default:
+			b.data = reflect.Append(b.data, v.Convert(c.elemType))
+		}
+	}
+
+	return b
+}
+
+// setCell sets the field of row from cell i of a ResultBatch.
+func (c *structColumn) setCell(row reflect.Value, batch *ResultBatch, i int) {
+	validity := batch.Validity(c.name)
+	if validity != nil && validity[i] == 0 {
+		// Leave nil pointers and zero values for null cells.
+		return
+	}
+
+	target := row.FieldByIndex(c.index)
+	if target.Kind() == reflect.Pointer {
+		target.Set(reflect.New(c.valueType))
+		target = target.Elem()
+	}
+
+	data := reflect.ValueOf(batch.Data(c.name))
+	switch {
+	case c.info.isVar():
+		offsets := batch.Offsets(c.name)
+		size := c.info.datatype.Size()
+		start, end := int(offsets[i]/size), data.Len()
+		if i+1 < len(offsets) {
+			end = int(offsets[i+1] / size)
+		}
+		cell := data.Slice(start, end)
+		if target.Kind() == reflect.String {
+			target.SetString(string(cell.Bytes()))
+			return
+		}
+		values := reflect.MakeSlice(target.Type(), cell.Len(), cell.Len())
+		for j := 0; j < cell.Len(); j++ {
+			values.Index(j).Set(cell.Index(j).Convert(target.Type().Elem()))
+		}
+		target.Set(values)
+	case target.Kind() == reflect.Array:
+		n := target.Len()
+		for j := 0; j < n; j++ {
+			target.Index(j).Set(data.Index(i*n + j).Convert(target.Type().Elem()))
+		}
+	default:
+		target.Set(data.Index(i).Convert(target.Type()))
+	}
+}
+
+// set sets the buffers on the query for the field named name.
+func (b columnBuffers) set(q *Query, name string) error {
+	if b.data.Len() > 0 {
+		if _, err := q.SetDataBuffer(name, b.data.Interface()); err != nil {
+			return err
+		}
+	} else if err := q.setEmptyDataBuffer(name); err != nil {
+		return err
+	}
+	if b.offsets != nil {
+		if _, err := q.SetOffsetsBuffer(name, b.offsets); err != nil {
+			return err
+		}
+	}
+	if b.validity != nil {
+		if _, err := q.SetValidityBuffer(name, b.validity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+WriteStructs sets the query buffers of every tagged field of T from rows and submits the query.
+The layout, and for dense arrays the subarray, must be set on the query beforehand. Global order
+writes must still be finalized by the caller.
+
+Fields are mapped with the `tiledb` struct tag. The tag value is the name of the attribute
+or dimension, optionally followed by comma-separated options; the `dim` option asserts that
+the field is a dimension. An empty name defaults to the Go field name, "-" skips the field,
+and fields without a tag are ignored.
+
+	type Row struct {
+		Row   int32    `tiledb:"rows,dim"`
+		Col   int32    `tiledb:"cols,dim"`
+		A1    int32    `tiledb:"a1"`
+		Label string   `tiledb:"label"`
+		Score *float64 `tiledb:"score"`
+	}
+
+Pointer fields map to nullable attributes, with nil written as null. Var-sized fields
+map to strings (for 8-bit datatypes) or slices, and fixed-size fields with more than one
+value per cell map to arrays of that length. SchemaFromStruct builds a schema matching
+the fields from the same tags.
+*/
+func WriteStructs[T any](q *Query, rows []T) error {
+	if len(rows) == 0 {
+		return errors.New("error writing structs: no rows to write")
+	}
+
+	schema, err := q.array.Schema()
+	if err != nil {
+		return fmt.Errorf("could not get array schema for WriteStructs: %w", err)
+	}
+	defer schema.Free()
+
+	columns, err := newStructColumns(schema, genericType[T]())
+	if err != nil {
+		return err
+	}
+
+	rowsValue := reflect.ValueOf(rows)
+	for _, column := range columns {
+		if err := column.buffers(rowsValue).set(q, column.name); err != nil {
+			return fmt.Errorf("error setting buffers for %s: %w", column.name, err)
+		}
+	}
+
+	return q.Submit()
+}
+
+// ReadStructs submits a read query until completion and returns its results as a []T,
+// reading every tagged field of T. See WriteStructs for how fields map to the schema.
+func ReadStructs[T any](q *Query, opts ...ResultIteratorOption) ([]T, error) {
+	schema, err := q.array.Schema()
+	if err != nil {
+		return nil, fmt.Errorf("could not get array schema for ReadStructs: %w", err)
+	}
+	defer schema.Free()
+
+	columns, err := newStructColumns(schema, genericType[T]())
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.name
+	}
+
+	it, err := q.Iterate(names, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// The rows are copied out of the batches.
+	defer it.Release()
+
+	var rows []T
+	for it.Next() {
+		batch := it.Batch()
+		start := len(rows)
+		rows = append(rows, make([]T, batch.NumCells())...)
+		for i := range batch.NumCells() {
+			row := reflect.ValueOf(&rows[start+int(i)]).Elem()
+			for _, column := range columns {
+				column.setCell(row, batch, int(i))
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
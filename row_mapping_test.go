@@ -0,0 +1,159 @@
+package tiledb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type basicTestRow struct {
+	Row     int32  `tiledb:"rows,dim"`
+	Col     int32  `tiledb:"cols,dim"`
+	A1      int32  `tiledb:"a1"`
+	A2      string `tiledb:"a2"`
+	A3      int64  `tiledb:"a3"`
+	Ignored string
+}
+
+func TestParseStructFields(t *testing.T) {
+	type embedded struct {
+		Label string `tiledb:"label"`
+	}
+	type row struct {
+		embedded
+		X       int32 `tiledb:"x,dim,extent=4"`
+		Y       int32 `tiledb:",dim"`
+		Skipped int32 `tiledb:"-"`
+		hidden  int32 `tiledb:"hidden"`
+	}
+
+	fields, err := parseStructFields(reflect.TypeOf(row{}))
+	require.NoError(t, err)
+	require.Len(t, fields, 3)
+
+	assert.Equal(t, "label", fields[0].name)
+	assert.Equal(t, "x", fields[1].name)
+	assert.True(t, fields[1].hasOption("dim"))
+	assert.Equal(t, "4", fields[1].options["extent"])
+	assert.Equal(t, "Y", fields[2].name)
+	assert.False(t, fields[0].hasOption("dim"))
+
+	type duplicate struct {
+		A int32 `tiledb:"a"`
+		B int32 `tiledb:"a"`
+	}
+	_, err = parseStructFields(reflect.TypeOf(duplicate{}))
+	assert.Error(t, err)
+
+	_, err = parseStructFields(reflect.TypeOf(struct{ A int32 }{}))
+	assert.Error(t, err)
+
+	_, err = parseStructFields(reflect.TypeOf(0))
+	assert.Error(t, err)
+}
+
+func TestReadStructs(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+
+	query, err := NewQuery(array.context, array)
+	require.NoError(t, err)
+	defer query.Free()
+	require.NoError(t, query.SetLayout(TILEDB_ROW_MAJOR))
+
+	rows, err := ReadStructs[basicTestRow](query)
+	require.NoError(t, err)
+	assert.Equal(t, []basicTestRow{
+		{Row: 1, Col: 1, A1: 1, A2: "i", A3: 1623763941},
+		{Row: 2, Col: 1, A1: 2, A2: "ama", A3: 1623762932},
+		{Row: 2, Col: 2, A1: 3, A2: "string", A3: 1623765583},
+	}, rows)
+}
+
+func TestReadStructsTypeMismatch(t *testing.T) {
+	array, err := createBasicTestArray(t)
+	require.NoError(t, err)
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+
+	query, err := NewQuery(array.context, array)
+	require.NoError(t, err)
+	defer query.Free()
+
+	_, err = ReadStructs[struct {
+		A1 int64 `tiledb:"a1"`
+	}](query)
+	assert.Error(t, err)
+
+	_, err = ReadStructs[struct {
+		A1 int32 `tiledb:"a1,dim"`
+	}](query)
+	assert.Error(t, err)
+
+	_, err = ReadStructs[struct {
+		A1 *int32 `tiledb:"a1"`
+	}](query)
+	assert.Error(t, err)
+}
+
+func TestWriteStructs(t *testing.T) {
+	context, err := NewContext(nil)
+	require.NoError(t, err)
+
+	domain, err := createDomain(context)
+	require.NoError(t, err)
+	attributes, err := createAttributes(context)
+	require.NoError(t, err)
+
+	a4, err := NewAttribute(context, "a4", TILEDB_FLOAT64)
+	require.NoError(t, err)
+	require.NoError(t, a4.SetNullable(true))
+	a5, err := NewAttribute(context, "a5", TILEDB_INT32)
+	require.NoError(t, err)
+	require.NoError(t, a5.SetCellValNum(2))
+	attributes = append(attributes, a4, a5)
+
+	schema, err := createSchema(context, domain, attributes)
+	require.NoError(t, err)
+
+	uri := t.TempDir()
+	require.NoError(t, CreateArray(context, uri, schema))
+
+	type row struct {
+		basicTestRow
+		A4 *float64 `tiledb:"a4"`
+		A5 [2]int32 `tiledb:"a5"`
+	}
+	score := 0.5
+	written := []row{
+		{basicTestRow: basicTestRow{Row: 1, Col: 1, A1: 10, A2: "x", A3: 1}, A4: &score, A5: [2]int32{1, 2}},
+		{basicTestRow: basicTestRow{Row: 3, Col: 4, A1: 20, A2: "", A3: 2}, A5: [2]int32{3, 4}},
+		{basicTestRow: basicTestRow{Row: 4, Col: 4, A1: 30, A2: "yz", A3: 3}, A4: &score, A5: [2]int32{5, 6}},
+	}
+
+	array, err := NewArray(context, uri)
+	require.NoError(t, err)
+	require.NoError(t, array.Open(TILEDB_WRITE))
+
+	query, err := NewQuery(context, array)
+	require.NoError(t, err)
+	require.NoError(t, query.SetLayout(TILEDB_UNORDERED))
+	require.NoError(t, WriteStructs(query, written))
+	require.NoError(t, query.Finalize())
+	require.NoError(t, array.Close())
+
+	require.NoError(t, array.Open(TILEDB_READ))
+	defer array.Close()
+
+	query, err = NewQuery(context, array)
+	require.NoError(t, err)
+	require.NoError(t, query.SetLayout(TILEDB_ROW_MAJOR))
+
+	read, err := ReadStructs[row](query)
+	require.NoError(t, err)
+	assert.Equal(t, written, read)
+}
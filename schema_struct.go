@@ -0,0 +1,464 @@
+package tiledb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// SchemaOption configures the ArraySchema built by SchemaFromStruct.
+type SchemaOption func(o *structSchemaOptions)
+
+// structSchemaOptions holds the schema settings that are not described by struct tags.
+type structSchemaOptions struct {
+	cellOrder    *Layout
+	tileOrder    *Layout
+	capacity     uint64
+	allowsDups   bool
+	enumerations []*Enumeration
+}
+
+// WithSchemaCellOrder sets the cell order of the schema.
+func WithSchemaCellOrder(layout Layout) SchemaOption {
+	return func(o *structSchemaOptions) {
+		o.cellOrder = &layout
+	}
+}
+
+// WithSchemaTileOrder sets the tile order of the schema.
+func WithSchemaTileOrder(layout Layout) SchemaOption {
+	return func(o *structSchemaOptions) {
+		o.tileOrder = &layout
+	}
+}
+
+// WithSchemaCapacity sets the tile capacity of a sparse schema.
+func WithSchemaCapacity(capacity uint64) SchemaOption {
+	return func(o *structSchemaOptions) {
+		o.capacity = capacity
+	}
+}
+
+// WithSchemaAllowsDups sets whether a sparse schema allows duplicate coordinates.
+func WithSchemaAllowsDups(allowsDups bool) SchemaOption {
+	return func(o *structSchemaOptions) {
+		o.allowsDups = allowsDups
+	}
+}
+
+// WithSchemaEnumerations adds enumerations to the schema, for the attributes whose tag
+// refers to them with the enum option. The enumerations are copied and remain owned by the
+// caller.
+func WithSchemaEnumerations(enumerations ...*Enumeration) SchemaOption {
+	return func(o *structSchemaOptions) {
+		o.enumerations = append(o.enumerations, enumerations...)
+	}
+}
+
+// schemaTagOptions are the tag options understood by SchemaFromStruct.
+var schemaTagOptions = map[string]bool{
+	"dim": true, "domain": true, "extent": true, "type": true, "nullable": true,
+	"var": true, "filters": true, "enum": true, "fill": true,
+}
+
+/*
+SchemaFromStruct builds an array schema from the tagged fields of T, with the same `tiledb`
+struct tags as WriteStructs and ReadStructs, so that a single Go type describes both the schema
+and its rows. Fields tagged with the dim option become the dimensions of the domain, in field
+order, and other tagged fields become attributes.
+
+The datatype is derived from the Go type of the field: sized integers, floats and bool map to
+the datatype of the same type, and strings map to TILEDB_STRING_ASCII dimensions or
+TILEDB_STRING_UTF8 attributes. Strings and slices are var-sized, arrays hold that many values per
+cell, and pointers make the attribute nullable. Tag options refine the mapping:
+
+  - dim: the field is a dimension.
+  - domain=lo:hi and extent=n: the domain and tile extent of a dimension, required except for
+    string dimensions.
+  - type=DATATYPE: the datatype, such as type=DATETIME_MS for an int64 field, which must have
+    the Go type of the datatype.
+  - nullable: the attribute is nullable, which is implied by pointer fields.
+  - var: the field is var-sized, which is implied by string and slice fields.
+  - filters=a|b: the filter pipeline, as filter type names with default options, such as
+    filters=byteshuffle|zstd.
+  - enum=name: the attribute uses the named enumeration, added with WithSchemaEnumerations.
+  - fill=value: the fill value of an attribute with a single value or a string per cell. The
+    fill value of a nullable attribute is valid.
+
+For example:
+
+	type Reading struct {
+		Sensor string     `tiledb:"sensor,dim"`
+		Time   int64      `tiledb:"time,dim,type=DATETIME_MS,domain=0:4102444800000,extent=86400000"`
+		Value  float64    `tiledb:"value,filters=byteshuffle|zstd"`
+		Status uint8      `tiledb:"status,enum=statuses"`
+		Note   *string    `tiledb:"note,fill=none"`
+		Pos    [2]float32 `tiledb:"pos"`
+	}
+
+	schema, err := tiledb.SchemaFromStruct[Reading](tdbCtx, tiledb.TILEDB_SPARSE,
+		tiledb.WithSchemaEnumerations(statuses), tiledb.WithSchemaCapacity(10000))
+
+The schema is checked before it is returned, and must be freed by the caller.
+*/
+func SchemaFromStruct[T any](tdbCtx *Context, arrayType ArrayType, opts ...SchemaOption) (*ArraySchema, error) {
+	t := genericType[T]()
+	fields, err := parseStructFields(t)
+	if err != nil {
+		return nil, err
+	}
+	var o structSchemaOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	schema, err := NewArraySchema(tdbCtx, arrayType)
+	if err != nil {
+		return nil, err
+	}
+	if err := buildStructSchema(tdbCtx, schema, fields, o); err != nil {
+		schema.Free()
+		return nil, fmt.Errorf("error creating schema from %v: %w", t, err)
+	}
+	return schema, nil
+}
+
+// buildStructSchema adds the domain and attributes described by fields to schema and applies
+// the options.
+func buildStructSchema(tdbCtx *Context, schema *ArraySchema, fields []structField, o structSchemaOptions) error {
+	domain, err := NewDomain(tdbCtx)
+	if err != nil {
+		return err
+	}
+	defer domain.Free()
+
+	// Attributes may refer to the enumerations, which must be added first.
+	for _, e := range o.enumerations {
+		if err := schema.AddEnumeration(e); err != nil {
+			return err
+		}
+	}
+
+	hasDimension := false
+	for _, field := range fields {
+		column, err := newSchemaColumn(field)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.name, err)
+		}
+		if column.info.isDimension {
+			err = column.addDimension(tdbCtx, domain)
+			hasDimension = true
+		} else {
+			err = column.addAttribute(tdbCtx, schema)
+		}
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.name, err)
+		}
+	}
+	if !hasDimension {
+		return errors.New("no fields are tagged as dimensions")
+	}
+	if err := schema.SetDomain(domain); err != nil {
+		return err
+	}
+
+	if o.cellOrder != nil {
+		if err := schema.SetCellOrder(*o.cellOrder); err != nil {
+			return err
+		}
+	}
+	if o.tileOrder != nil {
+		if err := schema.SetTileOrder(*o.tileOrder); err != nil {
+			return err
+		}
+	}
+	if o.capacity != 0 {
+		if err := schema.SetCapacity(o.capacity); err != nil {
+			return err
+		}
+	}
+	if o.allowsDups {
+		if err := schema.SetAllowsDups(true); err != nil {
+			return err
+		}
+	}
+	return schema.Check()
+}
+
+// newSchemaColumn derives the schema field described by a tagged struct field, validating the
+// Go type of the field against it as WriteStructs and ReadStructs do.
+func newSchemaColumn(field structField) (*structColumn, error) {
+	for key := range field.options {
+		if !schemaTagOptions[key] {
+			return nil, fmt.Errorf("unknown tag option %q", key)
+		}
+	}
+
+	column := &structColumn{
+		structField: field,
+		info:        fieldInfo{name: field.name, cellValNum: 1, isDimension: field.hasOption("dim")},
+		valueType:   field.typ,
+	}
+	if column.valueType.Kind() == reflect.Pointer {
+		column.valueType = column.valueType.Elem()
+		column.info.nullable = true
+	}
+	if field.hasOption("nullable") {
+		column.info.nullable = true
+	}
+
+	elemType := column.valueType
+	switch column.valueType.Kind() {
+	case reflect.String:
+		column.info.cellValNum = TILEDB_VAR_NUM
+	case reflect.Slice:
+		column.info.cellValNum = TILEDB_VAR_NUM
+		elemType = column.valueType.Elem()
+	case reflect.Array:
+		column.info.cellValNum = uint32(column.valueType.Len())
+		elemType = column.valueType.Elem()
+	}
+	if field.hasOption("var") && !column.info.isVar() {
+		return nil, fmt.Errorf("var-sized fields must be strings or slices, got %v", column.valueType)
+	}
+
+	if name, ok := field.options["type"]; ok {
+		datatype, err := DatatypeFromString(strings.ToUpper(name))
+		if err != nil {
+			return nil, err
+		}
+		column.info.datatype = datatype
+	} else {
+		datatype, ok := goTypeDatatype(elemType, column.info.isDimension)
+		if !ok {
+			return nil, fmt.Errorf("cannot derive a datatype from %v, set one with the type option", column.valueType)
+		}
+		column.info.datatype = datatype
+	}
+
+	column.elemType = column.info.datatype.ReflectType()
+	if column.elemType == nil {
+		return nil, fmt.Errorf("unsupported datatype %v", column.info.datatype)
+	}
+	if err := column.checkType(); err != nil {
+		return nil, err
+	}
+
+	if column.info.isDimension {
+		for _, key := range []string{"nullable", "enum", "fill"} {
+			if field.hasOption(key) {
+				return nil, fmt.Errorf("option %s does not apply to dimensions", key)
+			}
+		}
+		if column.info.nullable {
+			return nil, errors.New("dimensions cannot be nullable")
+		}
+		if column.info.cellValNum != 1 && column.valueType.Kind() != reflect.String {
+			return nil, fmt.Errorf("dimensions must hold a single value or a string, got %v", column.valueType)
+		}
+	}
+	return column, nil
+}
+
+// goTypeDatatype returns the datatype of values of Go type t. Strings map to
+// TILEDB_STRING_ASCII for dimensions, the only string datatype they support, and to
+// TILEDB_STRING_UTF8 for attributes.
+func goTypeDatatype(t reflect.Type, isDimension bool) (Datatype, bool) {
+	switch t.Kind() {
+	case reflect.Int8:
+		return TILEDB_INT8, true
+	case reflect.Int16:
+		return TILEDB_INT16, true
+	case reflect.Int32:
+		return TILEDB_INT32, true
+	case reflect.Int64:
+		return TILEDB_INT64, true
+	case reflect.Uint8:
+		return TILEDB_UINT8, true
+	case reflect.Uint16:
+		return TILEDB_UINT16, true
+	case reflect.Uint32:
+		return TILEDB_UINT32, true
+	case reflect.Uint64:
+		return TILEDB_UINT64, true
+	case reflect.Float32:
+		return TILEDB_FLOAT32, true
+	case reflect.Float64:
+		return TILEDB_FLOAT64, true
+	case reflect.Bool:
+		return TILEDB_BOOL, true
+	case reflect.String:
+		if isDimension {
+			return TILEDB_STRING_ASCII, true
+		}
+		return TILEDB_STRING_UTF8, true
+	}
+	return TILEDB_ANY, false
+}
+
+// addDimension adds the dimension described by the column to domain.
+func (c *structColumn) addDimension(tdbCtx *Context, domain *Domain) error {
+	var dimension *Dimension
+	var err error
+	if c.info.isVar() {
+		for _, key := range []string{"domain", "extent"} {
+			if c.hasOption(key) {
+				return fmt.Errorf("option %s does not apply to string dimensions", key)
+			}
+		}
+		dimension, err = NewStringDimension(tdbCtx, c.name)
+	} else {
+		lo, hi, ok := strings.Cut(c.options["domain"], ":")
+		if !ok || !c.hasOption("extent") {
+			return errors.New("dimensions require the domain=lo:hi and extent=n options")
+		}
+		bounds := reflect.MakeSlice(reflect.SliceOf(c.elemType), 2, 2)
+		for i, s := range []string{lo, hi} {
+			v, err := parseTagValue(s, c.elemType)
+			if err != nil {
+				return fmt.Errorf("invalid domain: %w", err)
+			}
+			bounds.Index(i).Set(v)
+		}
+		extent, err := parseTagValue(c.options["extent"], c.elemType)
+		if err != nil {
+			return fmt.Errorf("invalid extent: %w", err)
+		}
+		dimension, err = NewDimension(tdbCtx, c.name, c.info.datatype, bounds.Interface(), extent.Interface())
+	}
+	if err != nil {
+		return err
+	}
+	defer dimension.Free()
+
+	if c.hasOption("filters") {
+		filterList, err := tagFilterList(tdbCtx, c.options["filters"])
+		if err != nil {
+			return err
+		}
+		defer filterList.Free()
+		if err := dimension.SetFilterList(filterList); err != nil {
+			return err
+		}
+	}
+	return domain.AddDimensions(dimension)
+}
+
+// addAttribute adds the attribute described by the column to schema.
+func (c *structColumn) addAttribute(tdbCtx *Context, schema *ArraySchema) error {
+	for _, key := range []string{"domain", "extent"} {
+		if c.hasOption(key) {
+			return fmt.Errorf("option %s applies to dimensions only", key)
+		}
+	}
+
+	attribute, err := NewAttribute(tdbCtx, c.name, c.info.datatype)
+	if err != nil {
+		return err
+	}
+	defer attribute.Free()
+
+	if err := attribute.SetCellValNum(c.info.cellValNum); err != nil {
+		return err
+	}
+	if c.info.nullable {
+		if err := attribute.SetNullable(true); err != nil {
+			return err
+		}
+	}
+	if c.hasOption("filters") {
+		filterList, err := tagFilterList(tdbCtx, c.options["filters"])
+		if err != nil {
+			return err
+		}
+		defer filterList.Free()
+		if err := attribute.SetFilterList(filterList); err != nil {
+			return err
+		}
+	}
+	if name, ok := c.options["enum"]; ok {
+		if err := attribute.SetEnumerationName(name); err != nil {
+			return err
+		}
+	}
+	if c.hasOption("fill") {
+		if err := c.setFillValue(attribute); err != nil {
+			return err
+		}
+	}
+	return schema.AddAttributes(attribute)
+}
+
+// setFillValue sets the fill value of the fill option on attribute.
+func (c *structColumn) setFillValue(attribute *Attribute) error {
+	var value interface{}
+	switch {
+	case c.valueType.Kind() == reflect.String:
+		value = c.options["fill"]
+	case c.info.cellValNum == 1:
+		v, err := parseTagValue(c.options["fill"], c.elemType)
+		if err != nil {
+			return fmt.Errorf("invalid fill value: %w", err)
+		}
+		value = v.Interface()
+	default:
+		return fmt.Errorf("fill values apply to fields with a single value or a string per cell, got %v", c.valueType)
+	}
+	if c.info.nullable {
+		return attribute.SetFillValueNullable(value, true)
+	}
+	return attribute.SetFillValue(value)
+}
+
+// parseTagValue parses a number or bool of a tag option as a value of Go type t.
+func parseTagValue(s string, t reflect.Type) (reflect.Value, error) {
+	var v interface{}
+	var err error
+	switch t.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err = strconv.ParseInt(s, 0, t.Bits())
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err = strconv.ParseUint(s, 0, t.Bits())
+	case reflect.Float32, reflect.Float64:
+		v, err = strconv.ParseFloat(s, t.Bits())
+	case reflect.Bool:
+		v, err = strconv.ParseBool(s)
+	default:
+		return reflect.Value{}, fmt.Errorf("cannot parse %q as %v", s, t)
+	}
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("cannot parse %q as %v", s, t)
+	}
+	return reflect.ValueOf(v).Convert(t), nil
+}
+
+// tagFilterList builds the filter list of the filters option: filter type names separated by
+// "|", created with their default options.
+func tagFilterList(tdbCtx *Context, names string) (*FilterList, error) {
+	filterList, err := NewFilterList(tdbCtx)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range strings.Split(names, "|") {
+		if err := addTagFilter(tdbCtx, filterList, strings.TrimSpace(name)); err != nil {
+			filterList.Free()
+			return nil, err
+		}
+	}
+	return filterList, nil
+}
+
+func addTagFilter(tdbCtx *Context, filterList *FilterList, name string) error {
+	filterType, err := FilterTypeFromString(strings.ToUpper(name))
+	if err != nil {
+		return fmt.Errorf("unknown filter %q", name)
+	}
+	filter, err := NewFilter(tdbCtx, filterType)
+	if err != nil {
+		return err
+	}
+	defer filter.Free()
+	return filterList.AddFilter(filter)
+}
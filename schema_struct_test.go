@@ -0,0 +1,180 @@
+package tiledb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sensorTestRow struct {
+	Sensor string     `tiledb:"sensor,dim"`
+	Time   int64      `tiledb:"time,dim,type=DATETIME_MS,domain=0:1000000,extent=1000,filters=double_delta"`
+	Value  float64    `tiledb:"value,filters=byteshuffle|zstd"`
+	Status uint8      `tiledb:"status,enum=statuses"`
+	Note   *string    `tiledb:"note,fill=none"`
+	Count  int32      `tiledb:"count,fill=-1"`
+	Pos    [2]float32 `tiledb:"pos"`
+	Tags   []uint16   `tiledb:"tags,var"`
+}
+
+func TestSchemaFromStruct(t *testing.T) {
+	tdbCtx, err := NewContext(nil)
+	require.NoError(t, err)
+
+	statuses, err := NewOrderedEnumeration(tdbCtx, "statuses", []string{"ok", "failed"})
+	require.NoError(t, err)
+	t.Cleanup(statuses.Free)
+
+	schema, err := SchemaFromStruct[sensorTestRow](tdbCtx, TILEDB_SPARSE,
+		WithSchemaEnumerations(statuses), WithSchemaCapacity(500), WithSchemaAllowsDups(true))
+	require.NoError(t, err)
+	t.Cleanup(schema.Free)
+
+	capacity, err := schema.Capacity()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(500), capacity)
+	allowsDups, err := schema.AllowsDups()
+	require.NoError(t, err)
+	assert.True(t, allowsDups)
+
+	domain, err := schema.Domain()
+	require.NoError(t, err)
+	t.Cleanup(domain.Free)
+	timeDim, err := domain.DimensionFromName("time")
+	require.NoError(t, err)
+	t.Cleanup(timeDim.Free)
+	datatype, err := timeDim.Type()
+	require.NoError(t, err)
+	assert.Equal(t, TILEDB_DATETIME_MS, datatype)
+	bounds, err := timeDim.Domain()
+	require.NoError(t, err)
+	assert.Equal(t, []int64{0, 1000000}, bounds)
+	sensorDim, err := domain.DimensionFromName("sensor")
+	require.NoError(t, err)
+	t.Cleanup(sensorDim.Free)
+	datatype, err = sensorDim.Type()
+	require.NoError(t, err)
+	assert.Equal(t, TILEDB_STRING_ASCII, datatype)
+
+	for _, want := range []fieldInfo{
+		{name: "value", datatype: TILEDB_FLOAT64, cellValNum: 1},
+		{name: "status", datatype: TILEDB_UINT8, cellValNum: 1},
+		{name: "note", datatype: TILEDB_STRING_UTF8, cellValNum: TILEDB_VAR_NUM, nullable: true},
+		{name: "pos", datatype: TILEDB_FLOAT32, cellValNum: 2},
+		{name: "tags", datatype: TILEDB_UINT16, cellValNum: TILEDB_VAR_NUM},
+	} {
+		info, err := schemaFieldInfo(schema, want.name)
+		require.NoError(t, err)
+		assert.Equal(t, want, info)
+	}
+
+	value, err := schema.AttributeFromName("value")
+	require.NoError(t, err)
+	t.Cleanup(value.Free)
+	filterList, err := value.FilterList()
+	require.NoError(t, err)
+	t.Cleanup(filterList.Free)
+	nFilters, err := filterList.NFilters()
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), nFilters)
+
+	status, err := schema.AttributeFromName("status")
+	require.NoError(t, err)
+	t.Cleanup(status.Free)
+	enumName, err := status.GetEnumerationName()
+	require.NoError(t, err)
+	assert.Equal(t, "statuses", enumName)
+
+	count, err := schema.AttributeFromName("count")
+	require.NoError(t, err)
+	t.Cleanup(count.Free)
+	fill, _, err := count.GetFillValue()
+	require.NoError(t, err)
+	assert.Equal(t, int32(-1), fill)
+
+	// The rows of the struct round-trip through an array created from its schema.
+	uri := t.TempDir()
+	require.NoError(t, CreateArray(tdbCtx, uri, schema))
+	note := "calibrated"
+	written := []sensorTestRow{
+		{Sensor: "a", Time: 10, Value: 1.5, Status: 0, Note: &note, Count: 3, Pos: [2]float32{1, 2}, Tags: []uint16{7}},
+		{Sensor: "b", Time: 20, Value: 2.5, Status: 1, Count: 4, Pos: [2]float32{3, 4}, Tags: []uint16{8, 9}},
+	}
+	wArr := openArray(t, uri, TILEDB_WRITE)
+	wq, err := NewQuery(tdbCtx, wArr)
+	require.NoError(t, err)
+	require.NoError(t, wq.SetLayout(TILEDB_UNORDERED))
+	require.NoError(t, WriteStructs(wq, written))
+	require.NoError(t, wq.Finalize())
+
+	rArr := openArray(t, uri, TILEDB_READ)
+	rq, err := NewQuery(tdbCtx, rArr)
+	require.NoError(t, err)
+	require.NoError(t, rq.SetLayout(TILEDB_ROW_MAJOR))
+	read, err := ReadStructs[sensorTestRow](rq)
+	require.NoError(t, err)
+	assert.Equal(t, written, read)
+}
+
+func TestSchemaFromStructErrors(t *testing.T) {
+	tdbCtx, err := NewContext(nil)
+	require.NoError(t, err)
+
+	check := func(name string, build func() (*ArraySchema, error)) {
+		schema, err := build()
+		if !assert.Error(t, err, name) {
+			schema.Free()
+		}
+	}
+
+	check("no dimensions", func() (*ArraySchema, error) {
+		return SchemaFromStruct[struct {
+			A int32 `tiledb:"a"`
+		}](tdbCtx, TILEDB_SPARSE)
+	})
+	check("missing domain", func() (*ArraySchema, error) {
+		return SchemaFromStruct[struct {
+			D int32 `tiledb:"d,dim,extent=2"`
+		}](tdbCtx, TILEDB_DENSE)
+	})
+	check("unknown option", func() (*ArraySchema, error) {
+		return SchemaFromStruct[struct {
+			D int32 `tiledb:"d,dim,domain=0:9,extent=2,extnet=3"`
+		}](tdbCtx, TILEDB_DENSE)
+	})
+	check("unsized int", func() (*ArraySchema, error) {
+		return SchemaFromStruct[struct {
+			D int `tiledb:"d,dim,domain=0:9,extent=2"`
+		}](tdbCtx, TILEDB_DENSE)
+	})
+	check("nullable dimension", func() (*ArraySchema, error) {
+		return SchemaFromStruct[struct {
+			D *int32 `tiledb:"d,dim,domain=0:9,extent=2"`
+		}](tdbCtx, TILEDB_DENSE)
+	})
+	check("var array", func() (*ArraySchema, error) {
+		return SchemaFromStruct[struct {
+			D int32    `tiledb:"d,dim,domain=0:9,extent=2"`
+			A [2]int32 `tiledb:"a,var"`
+		}](tdbCtx, TILEDB_DENSE)
+	})
+	check("unknown filter", func() (*ArraySchema, error) {
+		return SchemaFromStruct[struct {
+			D int32 `tiledb:"d,dim,domain=0:9,extent=2"`
+			A int32 `tiledb:"a,filters=zzz"`
+		}](tdbCtx, TILEDB_DENSE)
+	})
+	check("invalid fill", func() (*ArraySchema, error) {
+		return SchemaFromStruct[struct {
+			D int32 `tiledb:"d,dim,domain=0:9,extent=2"`
+			A int8  `tiledb:"a,fill=300"`
+		}](tdbCtx, TILEDB_DENSE)
+	})
+
+	_, err = SchemaFromStruct[struct {
+		D int32 `tiledb:"d,dim,domain=0:9,extent=2"`
+		A int32 `tiledb:"a,type=FLOAT64"`
+	}](tdbCtx, TILEDB_DENSE)
+	assert.ErrorIs(t, err, ErrSchemaMismatch)
+}
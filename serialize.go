@@ -28,7 +28,7 @@ func SerializeArraySchemaToBuffer(schema *ArraySchema, serializationType Seriali
 	ret := C.tiledb_serialize_array_schema(schema.context.tiledbContext.Get(), schema.tiledbArraySchema.Get(), C.tiledb_serialization_type_t(serializationType), cClientSide, &bufferPtr)
 	runtime.KeepAlive(schema)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error serializing array schema: %w", schema.context.LastError())
+		return nil, fmt.Errorf("error serializing array schema: %w", schema.context.lastError("tiledb_serialize_array_schema", ret))
 	}
 
 	return newBufferFromHandle(schema.context, newBufferHandle(bufferPtr)), nil
@@ -59,7 +59,7 @@ func DeserializeArraySchema(buffer *Buffer, serializationType SerializationType,
 	ret := C.tiledb_deserialize_array_schema(buffer.context.tiledbContext.Get(), buffer.tiledbBuffer.Get(), C.tiledb_serialization_type_t(serializationType), cClientSide, &arraySchemaPtr)
 	runtime.KeepAlive(buffer)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error deserializing array schema: %w", buffer.context.LastError())
+		return nil, fmt.Errorf("error deserializing array schema: %w", buffer.context.lastError("tiledb_deserialize_array_schema", ret))
 	}
 
 	return newArraySchemaFromHandle(buffer.context, newArraySchemaHandle(arraySchemaPtr)), nil
@@ -79,12 +79,12 @@ func DeserializeArrayCreate(buffer *Buffer, serializationType SerializationType,
 	ret := C.tiledb_deserialize_array_create(buffer.context.tiledbContext.Get(), buffer.tiledbBuffer.Get(), C.tiledb_serialization_type_t(serializationType), cClientSide, &tdbString, &arraySchemaPtr)
 	runtime.KeepAlive(buffer)
 	if ret != C.TILEDB_OK {
-		return nil, "", fmt.Errorf("error deserializing array creation request: %w", buffer.context.LastError())
+		return nil, "", fmt.Errorf("error deserializing array creation request: %w", buffer.context.lastError("tiledb_deserialize_array_create", ret))
 	}
 
 	uri, err := stringHandleToString(tdbString)
 	if err != nil {
-		return nil, "", fmt.Errorf("error getting array create URI: %w", buffer.context.LastError())
+		return nil, "", fmt.Errorf("error getting array create URI: %w", err)
 	}
 	return newArraySchemaFromHandle(buffer.context, newArraySchemaHandle(arraySchemaPtr)), uri, nil
 }
@@ -107,7 +107,7 @@ func SerializeArraySchemaEvolutionToBuffer(arraySchemaEvolution *ArraySchemaEvol
 	runtime.KeepAlive(arraySchemaEvolution)
 	if ret != C.TILEDB_OK {
 		return nil, fmt.Errorf("error serializing array schem evolution: %w",
-			arraySchemaEvolution.context.LastError())
+			arraySchemaEvolution.context.lastError("tiledb_serialize_array_schema_evolution", ret))
 	}
 
 	return newBufferFromHandle(arraySchemaEvolution.context, newBufferHandle(bufferPtr)), nil
@@ -141,7 +141,7 @@ func DeserializeArraySchemaEvolution(buffer *Buffer, serializationType Serializa
 		cClientSide, &arraySchemaEvolutionPtr)
 	runtime.KeepAlive(buffer)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error deserializing array schema evolution: %w", buffer.context.LastError())
+		return nil, fmt.Errorf("error deserializing array schema evolution: %w", buffer.context.lastError("tiledb_deserialize_array_schema_evolution", ret))
 	}
 
 	return newArraySchemaEvolutionFromHandle(buffer.context, newArraySchemaEvolutionHandle(arraySchemaEvolutionPtr)), nil
@@ -171,14 +171,14 @@ func SerializeArrayNonEmptyDomainToBuffer(a *Array, serializationType Serializat
 	tmpDomain := make([]uint8, subarraySize)
 	ret := C.tiledb_array_get_non_empty_domain(a.context.tiledbContext.Get(), a.tiledbArray.Get(), slicePtr(tmpDomain), &isEmpty)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error serializing array nonempty domain: %w", a.context.LastError())
+		return nil, fmt.Errorf("error serializing array nonempty domain: %w", a.context.lastError("tiledb_array_get_non_empty_domain", ret))
 	}
 
 	var cClientSide = C.int32_t(0) // Currently this parameter is unused in libtiledb
 	var bufferPtr *C.tiledb_buffer_t
 	ret = C.tiledb_serialize_array_nonempty_domain(a.context.tiledbContext.Get(), a.tiledbArray.Get(), slicePtr(tmpDomain), isEmpty, C.tiledb_serialization_type_t(serializationType), cClientSide, &bufferPtr)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error serializing array nonempty domain: %w", a.context.LastError())
+		return nil, fmt.Errorf("error serializing array nonempty domain: %w", a.context.lastError("tiledb_serialize_array_nonempty_domain", ret))
 	}
 
 	runtime.KeepAlive(a)
@@ -227,7 +227,7 @@ func DeserializeArrayNonEmptyDomain(a *Array, buffer *Buffer, serializationType
 	runtime.KeepAlive(a)
 	runtime.KeepAlive(buffer)
 	if ret != C.TILEDB_OK {
-		return nil, false, fmt.Errorf("error serializing array nonempty domain: %w", a.context.LastError())
+		return nil, false, fmt.Errorf("error serializing array nonempty domain: %w", a.context.lastError("tiledb_deserialize_array_nonempty_domain", ret))
 	}
 
 	if isEmpty == 1 {
@@ -287,7 +287,7 @@ func SerializeArrayNonEmptyDomainAllDimensionsToBuffer(a *Array, serializationTy
 	var bufferPtr *C.tiledb_buffer_t
 	ret := C.tiledb_serialize_array_non_empty_domain_all_dimensions(a.context.tiledbContext.Get(), a.tiledbArray.Get(), C.tiledb_serialization_type_t(serializationType), cClientSide, &bufferPtr)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error serializing array nonempty domain: %w", a.context.LastError())
+		return nil, fmt.Errorf("error serializing array nonempty domain: %w", a.context.lastError("tiledb_serialize_array_non_empty_domain_all_dimensions", ret))
 	}
 
 	return newBufferFromHandle(a.context, newBufferHandle(bufferPtr)), nil
@@ -313,7 +313,7 @@ func DeserializeArrayNonEmptyDomainAllDimensions(a *Array, buffer *Buffer, seria
 	runtime.KeepAlive(a)
 	runtime.KeepAlive(buffer)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error deserializing array nonempty domain: %w", a.context.LastError())
+		return fmt.Errorf("error deserializing array nonempty domain: %w", a.context.lastError("tiledb_deserialize_array_non_empty_domain_all_dimensions", ret))
 	}
 
 	return nil
@@ -332,7 +332,7 @@ func SerializeQuery(query *Query, serializationType SerializationType, clientSid
 	ret := C.tiledb_serialize_query(query.context.tiledbContext.Get(), query.tiledbQuery.Get(), C.tiledb_serialization_type_t(serializationType), cClientSide, &bufferListPtr)
 	runtime.KeepAlive(query)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error serializing query: %w", query.context.LastError())
+		return nil, fmt.Errorf("error serializing query: %w", query.context.lastError("tiledb_serialize_query", ret))
 	}
 
 	return newBufferListFromHandle(query.context, newBufferListHandle(bufferListPtr)), nil
@@ -351,7 +351,7 @@ func DeserializeQuery(query *Query, buffer *Buffer, serializationType Serializat
 	runtime.KeepAlive(query)
 	runtime.KeepAlive(buffer)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error deserializing query: %w", query.context.LastError())
+		return fmt.Errorf("error deserializing query: %w", query.context.lastError("tiledb_deserialize_query", ret))
 	}
 	query.tiledbQuery.Pin(buffer)
 
@@ -364,7 +364,7 @@ func SerializeArrayMetadataToBuffer(a *Array, serializationType SerializationTyp
 	ret := C.tiledb_serialize_array_metadata(a.context.tiledbContext.Get(), a.tiledbArray.Get(), C.tiledb_serialization_type_t(serializationType), &bufferPtr)
 	runtime.KeepAlive(a)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error serializing array metadata: %w", a.context.LastError())
+		return nil, fmt.Errorf("error serializing array metadata: %w", a.context.lastError("tiledb_serialize_array_metadata", ret))
 	}
 
 	return newBufferFromHandle(a.context, newBufferHandle(bufferPtr)), nil
@@ -388,7 +388,7 @@ func DeserializeArrayMetadata(a *Array, buffer *Buffer, serializationType Serial
 	runtime.KeepAlive(a)
 	runtime.KeepAlive(buffer)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error deserializing array metadata: %w", a.context.LastError())
+		return fmt.Errorf("error deserializing array metadata: %w", a.context.lastError("tiledb_deserialize_array_metadata", ret))
 	}
 	return nil
 }
@@ -406,7 +406,7 @@ func SerializeQueryEstResultSizesToBuffer(q *Query, serializationType Serializat
 	ret := C.tiledb_serialize_query_est_result_sizes(q.context.tiledbContext.Get(), q.tiledbQuery.Get(), C.tiledb_serialization_type_t(serializationType), cClientSide, &bufferPtr)
 	runtime.KeepAlive(q)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error serializing query est buffer sizes: %w", q.context.LastError())
+		return nil, fmt.Errorf("error serializing query est buffer sizes: %w", q.context.lastError("tiledb_serialize_query_est_result_sizes", ret))
 	}
 
 	return newBufferFromHandle(q.context, newBufferHandle(bufferPtr)), nil
@@ -437,7 +437,7 @@ func DeserializeQueryEstResultSizes(q *Query, buffer *Buffer, serializationType
 	runtime.KeepAlive(q)
 	runtime.KeepAlive(buffer)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error deserializing query est buffer sizes: %w", q.context.LastError())
+		return fmt.Errorf("error deserializing query est buffer sizes: %w", q.context.lastError("tiledb_deserialize_query_est_result_sizes", ret))
 	}
 	return nil
 }
@@ -455,7 +455,7 @@ func SerializeArrayToBuffer(array *Array, serializationType SerializationType, c
 	ret := C.tiledb_serialize_array(array.context.tiledbContext.Get(), array.tiledbArray.Get(), C.tiledb_serialization_type_t(serializationType), cClientSide, &bufferPtr)
 	runtime.KeepAlive(array)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error serializing array: %w", array.context.LastError())
+		return nil, fmt.Errorf("error serializing array: %w", array.context.lastError("tiledb_serialize_array", ret))
 	}
 
 	return newBufferFromHandle(array.context, newBufferHandle(bufferPtr)), nil
@@ -489,7 +489,7 @@ func DeserializeArray(buffer *Buffer, serializationType SerializationType, clien
 	ret := C.tiledb_deserialize_array(buffer.context.tiledbContext.Get(), buffer.tiledbBuffer.Get(), C.tiledb_serialization_type_t(serializationType), cClientSide, cArrayURI, &arrayPtr)
 	runtime.KeepAlive(buffer)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error deserializing array: %w", buffer.context.LastError())
+		return nil, fmt.Errorf("error deserializing array: %w", buffer.context.lastError("tiledb_deserialize_array", ret))
 	}
 
 	return newArrayFromHandle(buffer.context, newArrayHandle(arrayPtr)), nil
@@ -508,7 +508,7 @@ func SerializeFragmentInfoToBuffer(fragmentInfo *FragmentInfo, serializationType
 	ret := C.tiledb_serialize_fragment_info(fragmentInfo.context.tiledbContext.Get(), fragmentInfo.tiledbFragmentInfo.Get(), C.tiledb_serialization_type_t(serializationType), cClientSide, &bufferPtr)
 	runtime.KeepAlive(fragmentInfo)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error serializing array: %w", fragmentInfo.context.LastError())
+		return nil, fmt.Errorf("error serializing array: %w", fragmentInfo.context.lastError("tiledb_serialize_fragment_info", ret))
 	}
 
 	return newBufferFromHandle(fragmentInfo.context, newBufferHandle(bufferPtr)), nil
@@ -542,7 +542,7 @@ func DeserializeFragmentInfo(fragmentInfo FragmentInfo, buffer *Buffer, arrayURI
 	runtime.KeepAlive(fragmentInfo)
 	runtime.KeepAlive(buffer)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error deserializing array: %w", fragmentInfo.context.LastError())
+		return fmt.Errorf("error deserializing array: %w", fragmentInfo.context.lastError("tiledb_deserialize_fragment_info", ret))
 	}
 
 	return nil
@@ -561,7 +561,7 @@ func SerializeFragmentInfoRequestToBuffer(fragmentInfo *FragmentInfo, serializat
 	ret := C.tiledb_serialize_fragment_info_request(fragmentInfo.context.tiledbContext.Get(), fragmentInfo.tiledbFragmentInfo.Get(), C.tiledb_serialization_type_t(serializationType), cClientSide, &bufferPtr)
 	runtime.KeepAlive(fragmentInfo)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error serializing array: %w", fragmentInfo.context.LastError())
+		return nil, fmt.Errorf("error serializing array: %w", fragmentInfo.context.lastError("tiledb_serialize_fragment_info_request", ret))
 	}
 
 	return newBufferFromHandle(fragmentInfo.context, newBufferHandle(bufferPtr)), nil
@@ -592,7 +592,7 @@ func DeserializeFragmentInfoRequest(fragmentInfo FragmentInfo, buffer *Buffer, s
 	runtime.KeepAlive(fragmentInfo)
 	runtime.KeepAlive(buffer)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error deserializing array: %w", fragmentInfo.context.LastError())
+		return fmt.Errorf("error deserializing array: %w", fragmentInfo.context.lastError("tiledb_deserialize_fragment_info_request", ret))
 	}
 
 	return nil
@@ -614,7 +614,7 @@ func DeserializeQueryAndArray(context *Context, buffer *Buffer, serializationTyp
 	ret := C.tiledb_deserialize_query_and_array(context.tiledbContext.Get(), buffer.tiledbBuffer.Get(), C.tiledb_serialization_type_t(serializationType), cClientSide, cArrayURI, &queryPtr, &arrayPtr)
 	runtime.KeepAlive(buffer)
 	if ret != C.TILEDB_OK {
-		return nil, nil, fmt.Errorf("error deserializing query: %w", context.LastError())
+		return nil, nil, fmt.Errorf("error deserializing query: %w", context.lastError("tiledb_deserialize_query_and_array", ret))
 	}
 
 	array := newArrayFromHandle(context, newArrayHandle(arrayPtr))
@@ -630,7 +630,7 @@ func SerializeGroupMetadataToBuffer(g *Group, serializationType SerializationTyp
 	ret := C.tiledb_serialize_group_metadata(g.context.tiledbContext.Get(), g.group.Get(), C.tiledb_serialization_type_t(serializationType), &bufferPtr)
 	runtime.KeepAlive(g)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error serializing group metadata: %w", g.context.LastError())
+		return nil, fmt.Errorf("error serializing group metadata: %w", g.context.lastError("tiledb_serialize_group_metadata", ret))
 	}
 
 	return newBufferFromHandle(g.context, newBufferHandle(bufferPtr)), nil
@@ -663,7 +663,7 @@ func DeserializeGroupMetadata(g *Group, buffer *Buffer, serializationType Serial
 	runtime.KeepAlive(g)
 	runtime.KeepAlive(buffer)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error deserializing group metadata: %w", g.context.LastError())
+		return fmt.Errorf("error deserializing group metadata: %w", g.context.lastError("tiledb_deserialize_group_metadata", ret))
 	}
 
 	return nil
@@ -692,7 +692,7 @@ func (g *Group) Deserialize(buffer *Buffer, serializationType SerializationType,
 	runtime.KeepAlive(g)
 	runtime.KeepAlive(buffer)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error deserializing group: %w", g.context.LastError())
+		return fmt.Errorf("error deserializing group: %w", g.context.lastError("tiledb_deserialize_group", ret))
 	}
 
 	return nil
@@ -704,7 +704,7 @@ func (g *Group) Deserialize(buffer *Buffer, serializationType SerializationType,
 func HandleLoadArraySchemaRequest(array *Array, request *Buffer, serializationType SerializationType) (*Buffer, error) {
 	response, err := NewBuffer(array.context)
 	if err != nil {
-		return nil, fmt.Errorf("error creating LoadArraySchemaResponse buffer: %w", array.context.LastError())
+		return nil, fmt.Errorf("error creating LoadArraySchemaResponse buffer: %w", err)
 	}
 
 	ret := C.tiledb_handle_load_array_schema_request(array.context.tiledbContext.Get(), array.tiledbArray.Get(),
@@ -712,7 +712,7 @@ func HandleLoadArraySchemaRequest(array *Array, request *Buffer, serializationTy
 	runtime.KeepAlive(array)
 	runtime.KeepAlive(request)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error handling LoadArraySchemaRequset: %w", array.context.LastError())
+		return nil, fmt.Errorf("error handling LoadArraySchemaRequset: %w", array.context.lastError("tiledb_handle_load_array_schema_request", ret))
 	}
 
 	return response, nil
@@ -725,7 +725,7 @@ func HandleArrayDeleteFragmentsTimestampsRequest(context *Context, array *Array,
 	runtime.KeepAlive(array)
 	runtime.KeepAlive(buffer)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error deserializing delete fragments timestamps: %w", context.LastError())
+		return fmt.Errorf("error deserializing delete fragments timestamps: %w", context.lastError("tiledb_handle_array_delete_fragments_timestamps_request", ret))
 	}
 
 	return nil
@@ -738,7 +738,7 @@ func HandleArrayDeleteFragmentsListRequest(context *Context, array *Array, buffe
 	runtime.KeepAlive(array)
 	runtime.KeepAlive(buffer)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error deserializing delete fragments list: %w", context.LastError())
+		return fmt.Errorf("error deserializing delete fragments list: %w", context.lastError("tiledb_handle_array_delete_fragments_list_request", ret))
 	}
 
 	return nil
@@ -751,7 +751,7 @@ func HandleQueryPlanRequest(array *Array, serializationType SerializationType, r
 
 	response, err := NewBuffer(opContext)
 	if err != nil {
-		return nil, fmt.Errorf("error allocating tiledb buffer: %w", opContext.LastError())
+		return nil, fmt.Errorf("error allocating tiledb buffer: %w", err)
 	}
 
 	ret := C.tiledb_handle_query_plan_request(opContext.tiledbContext.Get(), array.tiledbArray.Get(), C.tiledb_serialization_type_t(serializationType),
@@ -759,7 +759,7 @@ func HandleQueryPlanRequest(array *Array, serializationType SerializationType, r
 	runtime.KeepAlive(array)
 	runtime.KeepAlive(request)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error handling query plan request: %w", opContext.LastError())
+		return nil, fmt.Errorf("error handling query plan request: %w", opContext.lastError("tiledb_handle_query_plan_request", ret))
 	}
 
 	return response, nil
@@ -772,7 +772,7 @@ func HandleConsolidationPlanRequest(array *Array, serializationType Serializatio
 
 	response, err := NewBuffer(opContext)
 	if err != nil {
-		return nil, fmt.Errorf("error allocating tiledb buffer: %w", opContext.LastError())
+		return nil, fmt.Errorf("error allocating tiledb buffer: %w", err)
 	}
 
 	ret := C.tiledb_handle_consolidation_plan_request(opContext.tiledbContext.Get(), array.tiledbArray.Get(), C.tiledb_serialization_type_t(serializationType),
@@ -780,7 +780,7 @@ func HandleConsolidationPlanRequest(array *Array, serializationType Serializatio
 	runtime.KeepAlive(array)
 	runtime.KeepAlive(request)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error handling consolidation plan request: %w", opContext.LastError())
+		return nil, fmt.Errorf("error handling consolidation plan request: %w", opContext.lastError("tiledb_handle_consolidation_plan_request", ret))
 	}
 
 	return response, nil
@@ -790,7 +790,7 @@ func HandleConsolidationPlanRequest(array *Array, serializationType Serializatio
 func DeserializeLoadEnumerationsRequest(array *Array, serializationType SerializationType, request *Buffer) (*Buffer, error) {
 	response, err := NewBuffer(array.context)
 	if err != nil {
-		return nil, fmt.Errorf("error deserializing load enumerations request: %w", array.context.LastError())
+		return nil, fmt.Errorf("error deserializing load enumerations request: %w", err)
 	}
 
 	ret := C.tiledb_handle_load_enumerations_request(array.context.tiledbContext.Get(), array.tiledbArray.Get(), C.tiledb_serialization_type_t(serializationType),
@@ -798,7 +798,7 @@ func DeserializeLoadEnumerationsRequest(array *Array, serializationType Serializ
 	runtime.KeepAlive(array)
 	runtime.KeepAlive(request)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error deserializing load enumerations request: %w", array.context.LastError())
+		return nil, fmt.Errorf("error deserializing load enumerations request: %w", array.context.lastError("tiledb_handle_load_enumerations_request", ret))
 	}
 
 	return response, nil
@@ -43,7 +43,7 @@ func (a *Array) NewSubarray() (*Subarray, error) {
 
 	ret := C.tiledb_subarray_alloc(a.context.tiledbContext.Get(), a.tiledbArray.Get(), &sa)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error creating Subarray: %w", a.context.LastError())
+		return nil, fmt.Errorf("error creating Subarray: %w", a.context.lastError("tiledb_subarray_alloc", ret))
 	}
 
 	return newSubarrayFromHandle(a.context, a, newSubarrayHandle(sa)), nil
@@ -55,7 +55,7 @@ func (sa *Subarray) SetConfig(cfg *Config) error {
 	runtime.KeepAlive(sa)
 	runtime.KeepAlive(cfg)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting Config: %w", sa.context.LastError())
+		return fmt.Errorf("error setting Config: %w", sa.context.lastError("tiledb_subarray_set_config", ret))
 	}
 	return nil
 }
@@ -161,7 +161,7 @@ func (sa *Subarray) SetSubArray(subArray interface{}) error {
 	runtime.KeepAlive(sa)
 	// csubarray is being kept alive by passing it to cgo call.
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting subarray: %w", sa.context.LastError())
+		return fmt.Errorf("error setting subarray: %w", sa.context.lastError("tiledb_subarray_set_subarray", ret))
 	}
 	return nil
 }
@@ -177,7 +177,7 @@ func (sa *Subarray) SetCoalesceRanges(b bool) error {
 	ret := C.tiledb_subarray_set_coalesce_ranges(sa.context.tiledbContext.Get(), sa.subarray.Get(), coalesce)
 	runtime.KeepAlive(sa)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error setting coalesce ranges on subarray: %w", sa.context.LastError())
+		return fmt.Errorf("error setting coalesce ranges on subarray: %w", sa.context.lastError("tiledb_subarray_set_coalesce_ranges", ret))
 	}
 
 	return nil
@@ -190,6 +190,10 @@ func (sa *Subarray) AddRange(dimIdx uint32, r Range) error {
 	if err != nil {
 		return err
 	}
+	r, err = r.withTimestamps(dt)
+	if err != nil {
+		return err
+	}
 	if err := r.assertCompatibility(dt, isVar); err != nil {
 		return err
 	}
@@ -209,7 +213,7 @@ func (sa *Subarray) AddRange(dimIdx uint32, r Range) error {
 	runtime.KeepAlive(sa)
 	// The start and end pointers are being kept alive by passing them to cgo calls.
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error adding subarray range: %w", sa.context.LastError())
+		return fmt.Errorf("error adding subarray range: %w", sa.context.lastError("tiledb_subarray_add_range", ret))
 	}
 
 	return nil
@@ -222,6 +226,10 @@ func (sa *Subarray) AddRangeByName(dimName string, r Range) error {
 	if err != nil {
 		return err
 	}
+	r, err = r.withTimestamps(dt)
+	if err != nil {
+		return err
+	}
 	if err := r.assertCompatibility(dt, isVar); err != nil {
 		return err
 	}
@@ -244,7 +252,7 @@ func (sa *Subarray) AddRangeByName(dimName string, r Range) error {
 	runtime.KeepAlive(sa)
 	// The start and end pointers are being kept alive by passing them to cgo calls.
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error adding subarray range: %w", sa.context.LastError())
+		return fmt.Errorf("error adding subarray range: %w", sa.context.lastError("tiledb_subarray_add_range_by_name", ret))
 	}
 
 	return nil
@@ -257,7 +265,7 @@ func (sa *Subarray) GetRangeNum(dimIdx uint32) (uint64, error) {
 	ret := C.tiledb_subarray_get_range_num(sa.context.tiledbContext.Get(), sa.subarray.Get(), C.uint32_t(dimIdx), (*C.uint64_t)(unsafe.Pointer(&rangeNum)))
 	runtime.KeepAlive(sa)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error retrieving subarray range num: %w", sa.context.LastError())
+		return 0, fmt.Errorf("error retrieving subarray range num: %w", sa.context.lastError("tiledb_subarray_get_range_num", ret))
 	}
 
 	return rangeNum, nil
@@ -273,7 +281,7 @@ func (sa *Subarray) GetRangeNumFromName(dimName string) (uint64, error) {
 	ret := C.tiledb_subarray_get_range_num_from_name(sa.context.tiledbContext.Get(), sa.subarray.Get(), cDimName, (*C.uint64_t)(unsafe.Pointer(&rangeNum)))
 	runtime.KeepAlive(sa)
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error retrieving subarray range num: %w", sa.context.LastError())
+		return 0, fmt.Errorf("error retrieving subarray range num: %w", sa.context.lastError("tiledb_subarray_get_range_num_from_name", ret))
 	}
 
 	return rangeNum, nil
@@ -394,7 +402,7 @@ func (sa *Subarray) GetRange(dimIdx uint32, rangeNum uint64) (Range, error) {
 	}
 	runtime.KeepAlive(sa)
 	if ret != C.TILEDB_OK {
-		return Range{}, fmt.Errorf("error retrieving subarray range for dimension %d and range num %d: %w", dimIdx, rangeNum, sa.context.LastError())
+		return Range{}, fmt.Errorf("error retrieving subarray range for dimension %d and range num %d: %w", dimIdx, rangeNum, sa.context.lastError("tiledb_subarray_get_range", ret))
 	}
 
 	return r, err
@@ -445,7 +453,7 @@ func (sa *Subarray) GetRangeFromName(dimName string, rangeNum uint64) (Range, er
 	}
 	runtime.KeepAlive(sa)
 	if ret != C.TILEDB_OK {
-		return Range{}, fmt.Errorf("error retrieving subarray range for dimension %s and range num %d: %w", dimName, rangeNum, sa.context.LastError())
+		return Range{}, fmt.Errorf("error retrieving subarray range for dimension %s and range num %d: %w", dimName, rangeNum, sa.context.lastError("tiledb_subarray_get_range_from_name", ret))
 	}
 
 	return r, err
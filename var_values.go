@@ -0,0 +1,297 @@
+package tiledb
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// varCell returns the bounds in bytes of var-sized cell i, given the byte offsets of the cells
+// and the size of their data. The last cell ends at the end of the data.
+func varCell(offsets []uint64, i int, dataBytes uint64) (uint64, uint64) {
+	if i+1 < len(offsets) {
+		return offsets[i], offsets[i+1]
+	}
+	return offsets[i], dataBytes
+}
+
+// PackStrings packs values into the data and offsets buffers of a var-sized string field.
+func PackStrings(values []string) ([]byte, []uint64) {
+	n := 0
+	for _, v := range values {
+		n += len(v)
+	}
+	data := make([]byte, 0, n)
+	offsets := make([]uint64, len(values))
+	for i, v := range values {
+		offsets[i] = uint64(len(data))
+		data = append(data, v...)
+	}
+	return data, offsets
+}
+
+// UnpackStrings returns the strings of the data and offsets buffers of a var-sized string field.
+func UnpackStrings(data []byte, offsets []uint64) []string {
+	values := make([]string, len(offsets))
+	for i := range offsets {
+		start, end := varCell(offsets, i, uint64(len(data)))
+		values[i] = string(data[start:end])
+	}
+	return values
+}
+
+// PackNullableStrings packs values into the data, offsets and validity buffers of a nullable
+// var-sized string field. Nil values are written as null.
+func PackNullableStrings(values []*string) ([]byte, []uint64, []uint8) {
+	n := 0
+	for _, v := range values {
+		if v != nil {
+			n += len(*v)
+		}
+	}
+	data := make([]byte, 0, n)
+	offsets := make([]uint64, len(values))
+	validity := make([]uint8, len(values))
+	for i, v := range values {
+		offsets[i] = uint64(len(data))
+		if v != nil {
+			data = append(data, *v...)
+			validity[i] = 1
+		}
+	}
+	return data, offsets, validity
+}
+
+// UnpackNullableStrings returns the strings of the data, offsets and validity buffers of a nullable
+// var-sized string field, with nil for null cells.
+func UnpackNullableStrings(data []byte, offsets []uint64, validity []uint8) []*string {
+	values := make([]*string, len(offsets))
+	for i := range offsets {
+		if validity[i] == 0 {
+			continue
+		}
+		start, end := varCell(offsets, i, uint64(len(data)))
+		v := string(data[start:end])
+		values[i] = &v
+	}
+	return values
+}
+
+// PackVar packs the cells of a var-sized field into its data and offsets buffers.
+func PackVar[T any](values [][]T) ([]T, []uint64) {
+	size := uint64(unsafe.Sizeof(*new(T)))
+	n := 0
+	for _, v := range values {
+		n += len(v)
+	}
+	data := make([]T, 0, n)
+	offsets := make([]uint64, len(values))
+	for i, v := range values {
+		offsets[i] = uint64(len(data)) * size
+		data = append(data, v...)
+	}
+	return data, offsets
+}
+
+// UnpackVar returns the cells of the data and offsets buffers of a var-sized field.
+// The cells alias data.
+func UnpackVar[T any](data []T, offsets []uint64) [][]T {
+	size := uint64(unsafe.Sizeof(*new(T)))
+	values := make([][]T, len(offsets))
+	for i := range offsets {
+		start, end := varCell(offsets, i, uint64(len(data))*size)
+		values[i] = data[start/size : end/size : end/size]
+	}
+	return values
+}
+
+// PackNullable packs values into the data and validity buffers of a nullable fixed-size field
+// with one value per cell. Nil values are written as null.
+func PackNullable[T any](values []*T) ([]T, []uint8) {
+	data := make([]T, len(values))
+	validity := make([]uint8, len(values))
+	for i, v := range values {
+		if v != nil {
+			data[i] = *v
+			validity[i] = 1
+		}
+	}
+	return data, validity
+}
+
+// UnpackNullable returns the values of the data and validity buffers of a nullable fixed-size
+// field with one value per cell, with nil for null cells.
+func UnpackNullable[T any](data []T, validity []uint8) []*T {
+	values := make([]*T, len(data))
+	for i := range data {
+		if validity[i] != 0 {
+			v := data[i]
+			values[i] = &v
+		}
+	}
+	return values
+}
+
+// PackCells returns the values of cells of a field with more than one value per cell, such as
+// [3]float32 cells of a float32 attribute with CellValNum 3. C must be an array of T. The
+// values alias cells.
+func PackCells[T, C any](cells []C) ([]T, error) {
+	n, err := cellLength[C](reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	if len(cells) == 0 {
+		return []T{}, nil
+	}
+	return unsafe.Slice((*T)(unsafe.Pointer(unsafe.SliceData(cells))), len(cells)*n), nil
+}
+
+// UnpackCells returns the values of a field with more than one value per cell as cells of C,
+// such as [3]float32 for a float32 attribute with CellValNum 3. C must be an array of T.
+// The cells alias data.
+func UnpackCells[C, T any](data []T) ([]C, error) {
+	n, err := cellLength[C](reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+	if len(data)%n != 0 {
+		return nil, fmt.Errorf("cannot unpack %d values into cells of %d values", len(data), n)
+	}
+	if len(data) == 0 {
+		return []C{}, nil
+	}
+	return unsafe.Slice((*C)(unsafe.Pointer(unsafe.SliceData(data))), len(data)/n), nil
+}
+
+// cellLength returns the number of values of cells of type C, which must be an array of elem.
+func cellLength[C any](elem reflect.Type) (int, error) {
+	cellType := reflect.TypeFor[C]()
+	if cellType.Kind() != reflect.Array || cellType.Elem() != elem || cellType.Len() == 0 {
+		return 0, errorf(ErrSchemaMismatch, "cells of %v values must be non-empty arrays of %v", cellType, elem)
+	}
+	return cellType.Len(), nil
+}
+
+// SetStringBuffer packs values into the data and offsets buffers of a var-sized string
+// attribute or dimension and sets them on the query.
+func (q *Query) SetStringBuffer(attributeOrDimension string, values []string) error {
+	data, offsets := PackStrings(values)
+	return columnBuffers{data: reflect.ValueOf(data), offsets: offsets}.set(q, attributeOrDimension)
+}
+
+// SetNullableStringBuffer packs values into the data, offsets and validity buffers of a nullable
+// var-sized string attribute and sets them on the query. Nil values are written as null.
+func (q *Query) SetNullableStringBuffer(attribute string, values []*string) error {
+	data, offsets, validity := PackNullableStrings(values)
+	return columnBuffers{data: reflect.ValueOf(data), offsets: offsets, validity: validity}.set(q, attribute)
+}
+
+// SetVarBuffer packs values into the data and offsets buffers of a var-sized attribute or
+// dimension and sets them on the query.
+func SetVarBuffer[T any](q *Query, attributeOrDimension string, values [][]T) error {
+	data, offsets := PackVar(values)
+	return columnBuffers{data: reflect.ValueOf(data), offsets: offsets}.set(q, attributeOrDimension)
+}
+
+// SetNullableBuffer packs values into the data and validity buffers of a nullable fixed-size
+// attribute with one value per cell and sets them on the query. Nil values are written as null.
+func SetNullableBuffer[T any](q *Query, attribute string, values []*T) error {
+	data, validity := PackNullable(values)
+	return columnBuffers{data: reflect.ValueOf(data), validity: validity}.set(q, attribute)
+}
+
+// GetStringResults returns the strings read into the buffers of a var-sized string attribute
+// or dimension by the last submission of the query.
+func (q *Query) GetStringResults(attributeOrDimension string) ([]string, error) {
+	data, offsets, err := varResults[uint8](q, attributeOrDimension)
+	if err != nil {
+		return nil, err
+	}
+	return UnpackStrings(data, offsets), nil
+}
+
+// GetNullableStringResults returns the strings read into the buffers of a nullable var-sized
+// string attribute by the last submission of the query, with nil for null cells.
+func (q *Query) GetNullableStringResults(attribute string) ([]*string, error) {
+	data, offsets, err := varResults[uint8](q, attribute)
+	if err != nil {
+		return nil, err
+	}
+	validity, err := q.GetValidityBuffer(attribute)
+	if err != nil {
+		return nil, err
+	}
+	return UnpackNullableStrings(data, offsets, validity), nil
+}
+
+// GetVarResults returns the cells read into the buffers of a var-sized attribute or dimension
+// by the last submission of the query. The cells alias the data buffer of the query.
+func GetVarResults[T any](q *Query, attributeOrDimension string) ([][]T, error) {
+	data, offsets, err := varResults[T](q, attributeOrDimension)
+	if err != nil {
+		return nil, err
+	}
+	return UnpackVar(data, offsets), nil
+}
+
+// GetNullableResults returns the values read into the buffers of a nullable fixed-size attribute
+// with one value per cell by the last submission of the query, with nil for null cells.
+func GetNullableResults[T any](q *Query, attribute string) ([]*T, error) {
+	data, err := dataResults[T](q, attribute)
+	if err != nil {
+		return nil, err
+	}
+	validity, err := q.GetValidityBuffer(attribute)
+	if err != nil {
+		return nil, err
+	}
+	return UnpackNullable(data, validity), nil
+}
+
+// GetCellResults returns the cells read into the data buffer of an attribute with more than one
+// value per cell by the last submission of the query, as cells of C such as [3]float32.
+// The cells alias the data buffer of the query.
+func GetCellResults[C any](q *Query, attribute string) ([]C, error) {
+	data, err := q.GetDataBuffer(attribute)
+	if err != nil {
+		return nil, err
+	}
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("no data buffer is set for %s", attribute)
+	}
+	n, err := cellLength[C](v.Type().Elem())
+	if err != nil {
+		return nil, fmt.Errorf("error getting cells of %s: %w", attribute, err)
+	}
+	if v.Len() == 0 {
+		return []C{}, nil
+	}
+	return unsafe.Slice((*C)(v.UnsafePointer()), v.Len()/n), nil
+}
+
+// dataResults returns the data read into the data buffer of a field as a []T.
+func dataResults[T any](q *Query, attributeOrDimension string) ([]T, error) {
+	data, err := q.GetDataBuffer(attributeOrDimension)
+	if err != nil {
+		return nil, err
+	}
+	typed, ok := data.([]T)
+	if !ok {
+		return nil, errorf(ErrSchemaMismatch, "cannot get %T data of %s as %v", data, attributeOrDimension, reflect.TypeFor[[]T]())
+	}
+	return typed, nil
+}
+
+// varResults returns the data and offsets read into the buffers of a var-sized field.
+func varResults[T any](q *Query, attributeOrDimension string) ([]T, []uint64, error) {
+	data, err := dataResults[T](q, attributeOrDimension)
+	if err != nil {
+		return nil, nil, err
+	}
+	offsets, err := q.GetOffsetsBuffer(attributeOrDimension)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, offsets, nil
+}
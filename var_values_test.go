@@ -0,0 +1,122 @@
+package tiledb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackStrings(t *testing.T) {
+	data, offsets := PackStrings([]string{"a", "", "bcd"})
+	assert.Equal(t, []byte("abcd"), data)
+	assert.Equal(t, []uint64{0, 1, 1}, offsets)
+	assert.Equal(t, []string{"a", "", "bcd"}, UnpackStrings(data, offsets))
+
+	b := "b"
+	data, offsets, validity := PackNullableStrings([]*string{nil, &b})
+	assert.Equal(t, []byte("b"), data)
+	assert.Equal(t, []uint64{0, 0}, offsets)
+	assert.Equal(t, []uint8{0, 1}, validity)
+	assert.Equal(t, []*string{nil, &b}, UnpackNullableStrings(data, offsets, validity))
+}
+
+func TestPackVar(t *testing.T) {
+	values, offsets := PackVar([][]int32{{1, 2}, {}, {3}})
+	assert.Equal(t, []int32{1, 2, 3}, values)
+	assert.Equal(t, []uint64{0, 8, 8}, offsets)
+	assert.Equal(t, [][]int32{{1, 2}, {}, {3}}, UnpackVar(values, offsets))
+
+	x := 1.5
+	data, validity := PackNullable([]*float64{&x, nil})
+	assert.Equal(t, []float64{1.5, 0}, data)
+	assert.Equal(t, []uint8{1, 0}, validity)
+	assert.Equal(t, []*float64{&x, nil}, UnpackNullable(data, validity))
+}
+
+func TestPackCells(t *testing.T) {
+	cells, err := UnpackCells[[2]uint16]([]uint16{1, 2, 3, 4})
+	require.NoError(t, err)
+	assert.Equal(t, [][2]uint16{{1, 2}, {3, 4}}, cells)
+
+	values, err := PackCells[uint16](cells)
+	require.NoError(t, err)
+	assert.Equal(t, []uint16{1, 2, 3, 4}, values)
+
+	_, err = UnpackCells[[3]uint16]([]uint16{1, 2, 3, 4})
+	assert.Error(t, err)
+	_, err = UnpackCells[[2]int32]([]uint16{1, 2})
+	assert.ErrorIs(t, err, ErrSchemaMismatch)
+}
+
+func TestQueryVarValues(t *testing.T) {
+	tdbCtx, err := NewContext(nil)
+	require.NoError(t, err)
+	arrPath := t.TempDir()
+
+	schema, err := NewArraySchema(tdbCtx, TILEDB_SPARSE)
+	require.NoError(t, err)
+	domain, err := createDomain(tdbCtx)
+	require.NoError(t, err)
+	require.NoError(t, schema.SetDomain(domain))
+	name, err := NewAttribute(tdbCtx, "name", TILEDB_STRING_UTF8)
+	require.NoError(t, err)
+	require.NoError(t, name.SetCellValNum(TILEDB_VAR_NUM))
+	require.NoError(t, name.SetNullable(true))
+	tags, err := NewAttribute(tdbCtx, "tags", TILEDB_INT32)
+	require.NoError(t, err)
+	require.NoError(t, tags.SetCellValNum(TILEDB_VAR_NUM))
+	point, err := NewAttribute(tdbCtx, "point", TILEDB_FLOAT32)
+	require.NoError(t, err)
+	require.NoError(t, point.SetCellValNum(2))
+	score, err := NewAttribute(tdbCtx, "score", TILEDB_FLOAT64)
+	require.NoError(t, err)
+	require.NoError(t, score.SetNullable(true))
+	require.NoError(t, schema.AddAttributes(name, tags, point, score))
+	require.NoError(t, CreateArray(tdbCtx, arrPath, schema))
+
+	alice, high := "alice", 0.9
+	names := []*string{&alice, nil}
+	scores := []*float64{nil, &high}
+	points := [][2]float32{{1, 2}, {3, 4}}
+
+	arr := openArray(t, arrPath, TILEDB_WRITE)
+	q, err := NewQuery(arr.context, arr)
+	require.NoError(t, err)
+	require.NoError(t, q.SetLayout(TILEDB_UNORDERED))
+	_, err = q.SetDataBuffer("rows", []int32{1, 2})
+	require.NoError(t, err)
+	_, err = q.SetDataBuffer("cols", []int32{1, 2})
+	require.NoError(t, err)
+	require.NoError(t, q.SetNullableStringBuffer("name", names))
+	require.NoError(t, SetVarBuffer(q, "tags", [][]int32{{7}, {8, 9}}))
+	pointValues, err := PackCells[float32](points)
+	require.NoError(t, err)
+	_, err = q.SetDataBuffer("point", pointValues)
+	require.NoError(t, err)
+	require.NoError(t, SetNullableBuffer(q, "score", scores))
+	require.NoError(t, q.Submit())
+
+	arr = openArray(t, arrPath, TILEDB_READ)
+	q, err = NewQuery(arr.context, arr)
+	require.NoError(t, err)
+	require.NoError(t, q.SetLayout(TILEDB_ROW_MAJOR))
+	_, err = q.AllocateBuffers([]string{"name", "tags", "point", "score"})
+	require.NoError(t, err)
+	require.NoError(t, q.Submit())
+
+	readNames, err := q.GetNullableStringResults("name")
+	require.NoError(t, err)
+	assert.Equal(t, names, readNames)
+	readTags, err := GetVarResults[int32](q, "tags")
+	require.NoError(t, err)
+	assert.Equal(t, [][]int32{{7}, {8, 9}}, readTags)
+	readPoints, err := GetCellResults[[2]float32](q, "point")
+	require.NoError(t, err)
+	assert.Equal(t, points, readPoints)
+	readScores, err := GetNullableResults[float64](q, "score")
+	require.NoError(t, err)
+	assert.Equal(t, scores, readScores)
+	_, err = q.GetStringResults("tags")
+	assert.ErrorIs(t, err, ErrSchemaMismatch)
+}
@@ -117,7 +117,7 @@ func NewVFS(context *Context, config *Config) (*VFS, error) {
 	runtime.KeepAlive(context)
 	runtime.KeepAlive(config)
 	if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("error creating tiledb VFS: %w", context.LastError())
+		return nil, fmt.Errorf("error creating tiledb VFS: %w", context.lastError("tiledb_vfs_alloc", ret))
 	}
 
 	return newVfsFromHandle(context, newVfsHandle(vfsPtr)), nil
@@ -160,7 +160,7 @@ func (v *VFS) CreateBucket(uri string) error {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error in creating s3 bucket %s: %w", uri, v.context.LastError())
+		return fmt.Errorf("error in creating s3 bucket %s: %w", uri, v.context.lastError("tiledb_vfs_create_bucket", ret))
 	}
 
 	return nil
@@ -174,7 +174,7 @@ func (v *VFS) RemoveBucket(uri string) error {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error in removing s3 bucket %s: %w", uri, v.context.LastError())
+		return fmt.Errorf("error in removing s3 bucket %s: %w", uri, v.context.lastError("tiledb_vfs_remove_bucket", ret))
 	}
 
 	return nil
@@ -188,7 +188,7 @@ func (v *VFS) EmptyBucket(uri string) error {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error in emptying s3 bucket %s: %w", uri, v.context.LastError())
+		return fmt.Errorf("error in emptying s3 bucket %s: %w", uri, v.context.lastError("tiledb_vfs_empty_bucket", ret))
 	}
 
 	return nil
@@ -203,7 +203,7 @@ func (v *VFS) IsEmptyBucket(uri string) (bool, error) {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return false, fmt.Errorf("error in checking if s3 bucket %s is empty: %w", uri, v.context.LastError())
+		return false, fmt.Errorf("error in checking if s3 bucket %s is empty: %w", uri, v.context.lastError("tiledb_vfs_is_empty_bucket", ret))
 	}
 
 	if isEmpty == 1 {
@@ -222,7 +222,7 @@ func (v *VFS) IsBucket(uri string) (bool, error) {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return false, fmt.Errorf("error in checking if %s is a s3 bucket: %w", uri, v.context.LastError())
+		return false, fmt.Errorf("error in checking if %s is a s3 bucket: %w", uri, v.context.lastError("tiledb_vfs_is_bucket", ret))
 	}
 
 	if isBucket == 1 {
@@ -240,7 +240,7 @@ func (v *VFS) CreateDir(uri string) error {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error in creating directory %s: %w", uri, v.context.LastError())
+		return fmt.Errorf("error in creating directory %s: %w", uri, v.context.lastError("tiledb_vfs_create_dir", ret))
 	}
 
 	return nil
@@ -255,7 +255,7 @@ func (v *VFS) IsDir(uri string) (bool, error) {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return false, fmt.Errorf("error in checking if %s is a directory: %w", uri, v.context.LastError())
+		return false, fmt.Errorf("error in checking if %s is a directory: %w", uri, v.context.lastError("tiledb_vfs_is_dir", ret))
 	}
 
 	if isDir == 1 {
@@ -273,7 +273,7 @@ func (v *VFS) RemoveDir(uri string) error {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error in removing directory %s: %w", uri, v.context.LastError())
+		return fmt.Errorf("error in removing directory %s: %w", uri, v.context.lastError("tiledb_vfs_remove_dir", ret))
 	}
 
 	return nil
@@ -288,7 +288,7 @@ func (v *VFS) IsFile(uri string) (bool, error) {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return false, fmt.Errorf("error in checking if %s is a file: %w", uri, v.context.LastError())
+		return false, fmt.Errorf("error in checking if %s is a file: %w", uri, v.context.lastError("tiledb_vfs_is_file", ret))
 	}
 
 	if isFile == 1 {
@@ -306,7 +306,7 @@ func (v *VFS) RemoveFile(uri string) error {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error in removing file %s: %w", uri, v.context.LastError())
+		return fmt.Errorf("error in removing file %s: %w", uri, v.context.lastError("tiledb_vfs_remove_file", ret))
 	}
 
 	return nil
@@ -321,7 +321,7 @@ func (v *VFS) FileSize(uri string) (uint64, error) {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error in getting file size %s: %w", uri, v.context.LastError())
+		return 0, fmt.Errorf("error in getting file size %s: %w", uri, v.context.lastError("tiledb_vfs_file_size", ret))
 	}
 
 	return uint64(cfsize), nil
@@ -338,7 +338,7 @@ func (v *VFS) MoveFile(oldURI string, newURI string) error {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error in moving file %s to %s: %w", oldURI, newURI, v.context.LastError())
+		return fmt.Errorf("error in moving file %s to %s: %w", oldURI, newURI, v.context.lastError("tiledb_vfs_move_file", ret))
 	}
 
 	return nil
@@ -355,7 +355,7 @@ func (v *VFS) CopyFile(oldURI string, newURI string) error {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error in copying file %s to %s: %w", oldURI, newURI, v.context.LastError())
+		return fmt.Errorf("error in copying file %s to %s: %w", oldURI, newURI, v.context.lastError("tiledb_vfs_copy_file", ret))
 	}
 
 	return nil
@@ -372,7 +372,7 @@ func (v *VFS) MoveDir(oldURI string, newURI string) error {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error in moving directory %s to %s: %w", oldURI, newURI, v.context.LastError())
+		return fmt.Errorf("error in moving directory %s to %s: %w", oldURI, newURI, v.context.lastError("tiledb_vfs_move_dir", ret))
 	}
 
 	return nil
@@ -388,9 +388,15 @@ func (v *VFS) Open(uri string, mode VFSMode) (*VFSfh, error) {
 	runtime.KeepAlive(v)
 
 	if ret == C.TILEDB_OOM {
-		return nil, fmt.Errorf("out of Memory error in VFS.Open: %w", v.context.LastError())
+		return nil, fmt.Errorf("out of Memory error in VFS.Open: %w", v.context.lastError("tiledb_vfs_open", ret))
 	} else if ret != C.TILEDB_OK {
-		return nil, fmt.Errorf("unknown error in VFS.Open: %w", v.context.LastError())
+		err := v.context.lastError("tiledb_vfs_open", ret)
+		if mode == TILEDB_VFS_READ {
+			if isFile, isErr := v.IsFile(uri); isErr == nil && !isFile {
+				return nil, errorf(ErrNotFound, "unknown error in VFS.Open: %w", err)
+			}
+		}
+		return nil, fmt.Errorf("unknown error in VFS.Open: %w", err)
 	}
 
 	return newVfsFhFromHandle(v.context, v, uri, newVfsFhHandle(fhPtr)), nil
@@ -404,7 +410,7 @@ func (v *VFS) Close(fh *VFSfh) error {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("unknown error in VFS.Close: %w", v.context.LastError())
+		return fmt.Errorf("unknown error in VFS.Close: %w", v.context.lastError("tiledb_vfs_close", ret))
 	}
 
 	fh.Free()
@@ -420,7 +426,7 @@ func (v *VFS) Read(fh *VFSfh, offset uint64, nbytes uint64) ([]byte, error) {
 	runtime.KeepAlive(fh)
 
 	if ret != C.TILEDB_OK {
-		return []byte{}, fmt.Errorf("unknown error in VFS.Read: %w", v.context.LastError())
+		return []byte{}, fmt.Errorf("unknown error in VFS.Read: %w", v.context.lastError("tiledb_vfs_read", ret))
 	}
 
 	return bytes, nil
@@ -437,7 +443,7 @@ func (v *VFS) Write(fh *VFSfh, bytes []byte) error {
 	runtime.KeepAlive(fh)
 
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("unknown error in VFS.Write: %w", v.context.LastError())
+		return fmt.Errorf("unknown error in VFS.Write: %w", v.context.lastError("tiledb_vfs_write", ret))
 	}
 
 	return nil
@@ -450,7 +456,7 @@ func (v *VFS) Sync(fh *VFSfh) error {
 	runtime.KeepAlive(fh)
 
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("unknown error in VFS.Sync: %w", v.context.LastError())
+		return fmt.Errorf("unknown error in VFS.Sync: %w", v.context.lastError("tiledb_vfs_sync", ret))
 	}
 
 	return nil
@@ -464,7 +470,7 @@ func (v *VFS) Touch(uri string) error {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error in touching %s: %w", uri, v.context.LastError())
+		return fmt.Errorf("error in touching %s: %w", uri, v.context.lastError("tiledb_vfs_touch", ret))
 	}
 
 	return nil
@@ -479,7 +485,7 @@ func (v *VFS) DirSize(uri string) (uint64, error) {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error in getting dir size %s: %w", uri, v.context.LastError())
+		return 0, fmt.Errorf("error in getting dir size %s: %w", uri, v.context.lastError("tiledb_vfs_dir_size", ret))
 	}
 
 	return uint64(cfsize), nil
@@ -525,7 +531,7 @@ func (v *VFS) NumOfFragmentsInPath(path string) (int, error) {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("error in getting dir list %s: %w", path, v.context.LastError())
+		return 0, fmt.Errorf("error in getting dir list %s: %w", path, v.context.lastError("tiledb_vfs_ls", ret))
 	}
 
 	return numOfFragmentsData.NumOfFolders, nil
@@ -540,7 +546,7 @@ func (v *VFSfh) Close() error {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("unknown error in VFS.Close: %w", v.context.LastError())
+		return fmt.Errorf("unknown error in VFS.Close: %w", v.context.lastError("tiledb_vfs_close", ret))
 	}
 
 	v.Free()
@@ -573,7 +579,7 @@ func (v *VFSfh) Read(p []byte) (int, error) {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("unknown error in VFS.Read: %w", v.context.LastError())
+		return 0, fmt.Errorf("unknown error in VFS.Read: %w", v.context.lastError("tiledb_vfs_read", ret))
 	}
 
 	v.offset += nbytes
@@ -616,7 +622,7 @@ func (v *VFSfh) ReadAt(p []byte, off int64) (int, error) {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("unknown error in VFS.Read: %w", v.context.LastError())
+		return 0, fmt.Errorf("unknown error in VFS.Read: %w", v.context.lastError("tiledb_vfs_read", ret))
 	}
 
 	return int(nbytes), err
@@ -634,7 +640,7 @@ func (v *VFSfh) Write(bytes []byte) (int, error) {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return 0, fmt.Errorf("unknown error in VFS.Write: %w", v.context.LastError())
+		return 0, fmt.Errorf("unknown error in VFS.Write: %w", v.context.lastError("tiledb_vfs_write", ret))
 	}
 
 	return len(bytes), nil
@@ -646,7 +652,7 @@ func (v *VFSfh) Sync() error {
 	runtime.KeepAlive(v)
 
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("unknown error in VFS.Sync: %w", v.context.LastError())
+		return fmt.Errorf("unknown error in VFS.Sync: %w", v.context.lastError("tiledb_vfs_sync", ret))
 	}
 
 	return nil
@@ -742,7 +748,7 @@ func (v *VFS) List(path string) ([]string, []string, error) {
 	ret := C._vfs_ls(v.context.tiledbContext.Get(), v.tiledbVFS.Get(), cpath, data)
 	runtime.KeepAlive(v)
 	if ret != C.TILEDB_OK {
-		return nil, nil, fmt.Errorf("error in getting path listing %s: %w", path, v.context.LastError())
+		return nil, nil, fmt.Errorf("error in getting path listing %s: %w", path, v.context.lastError("tiledb_vfs_ls", ret))
 	}
 
 	return folderData.Folders, folderData.Files, nil
@@ -801,7 +807,7 @@ func (v *VFS) VisitRecursive(path string, callback VisitRecursiveCallback) error
 	ret := C._vfs_ls_recursive(v.context.tiledbContext.Get(), v.tiledbVFS.Get(), cpath, data)
 	runtime.KeepAlive(v)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error in recursively listing path %s: %w", path, v.context.LastError())
+		return fmt.Errorf("error in recursively listing path %s: %w", path, v.context.lastError("tiledb_vfs_ls_recursive", ret))
 	}
 
 	return state.lastError
@@ -853,7 +859,7 @@ func (v *VFS) VisitRecursiveV2(path string, callback VisitRecursiveCallbackV2) e
 	ret := C._vfs_ls_recursive_v2(v.context.tiledbContext.Get(), v.tiledbVFS.Get(), cpath, data)
 	runtime.KeepAlive(v)
 	if ret != C.TILEDB_OK {
-		return fmt.Errorf("error in recursively listing path %s: %w", path, v.context.LastError())
+		return fmt.Errorf("error in recursively listing path %s: %w", path, v.context.lastError("tiledb_vfs_ls_recursive_v2", ret))
 	}
 
 	return state.lastError
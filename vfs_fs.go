@@ -0,0 +1,317 @@
+package tiledb
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// FS returns a read-only file system for the tree of files rooted at rootURI.
+// rootURI can use any scheme supported by TileDB, such as a local path, s3://, gcs://
+// or azure://. The returned file system implements fs.ReadDirFS, fs.ReadFileFS and
+// fs.StatFS, and the files it opens implement io.Seeker and io.ReaderAt, so it can be
+// used with http.FS, template.ParseFS or fs.WalkDir.
+//
+// Paths that do not exist, including paths removed while they are used, are reported
+// with errors matching fs.ErrNotExist. File modification times are not available and
+// are reported as the zero time.
+func (v *VFS) FS(rootURI string) fs.FS {
+	// The trailing slash of a root such as "/" is kept, it is the root itself.
+	root := rootURI
+	if len(root) > 1 {
+		root = strings.TrimSuffix(root, "/")
+	}
+	return &vfsFS{vfs: v, root: root}
+}
+
+// vfsFS is the fs.FS returned by VFS.FS.
+type vfsFS struct {
+	vfs  *VFS
+	root string
+}
+
+var (
+	_ fs.ReadDirFS  = (*vfsFS)(nil)
+	_ fs.ReadFileFS = (*vfsFS)(nil)
+	_ fs.StatFS     = (*vfsFS)(nil)
+)
+
+// uri returns the URI of the file named name.
+func (f *vfsFS) uri(op, name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return f.root, nil
+	}
+	if strings.HasSuffix(f.root, "/") {
+		return f.root + name, nil
+	}
+	return f.root + "/" + name, nil
+}
+
+// pathError returns the error of op on the file named name, at uri, caused by err.
+// The error matches fs.ErrNotExist if err matches ErrNotFound or uri no longer exists.
+func (f *vfsFS) pathError(op, name, uri string, err error) error {
+	if !errors.Is(err, ErrNotFound) {
+		isDir, dirErr := f.vfs.IsDir(uri)
+		isFile, fileErr := f.vfs.IsFile(uri)
+		if dirErr != nil || fileErr != nil || isDir || isFile {
+			return &fs.PathError{Op: op, Path: name, Err: err}
+		}
+	}
+	return &fs.PathError{Op: op, Path: name, Err: fmt.Errorf("%w: %w", fs.ErrNotExist, err)}
+}
+
+// stat returns the file info of the file named name, at uri.
+func (f *vfsFS) stat(op, name, uri string) (*vfsFileInfo, error) {
+	isDir, err := f.vfs.IsDir(uri)
+	if err != nil {
+		return nil, &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	if isDir {
+		return &vfsFileInfo{name: path.Base(name), isDir: true}, nil
+	}
+
+	isFile, err := f.vfs.IsFile(uri)
+	if err != nil {
+		return nil, &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	if !isFile {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+
+	size, err := f.vfs.FileSize(uri)
+	if err != nil {
+		return nil, f.pathError(op, name, uri, err)
+	}
+	return &vfsFileInfo{name: path.Base(name), size: int64(size)}, nil
+}
+
+// Stat implements fs.StatFS.
+func (f *vfsFS) Stat(name string) (fs.FileInfo, error) {
+	uri, err := f.uri("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return f.stat("stat", name, uri)
+}
+
+// Open implements fs.FS.
+func (f *vfsFS) Open(name string) (fs.File, error) {
+	uri, err := f.uri("open", name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.stat("open", name, uri)
+	if err != nil {
+		return nil, err
+	}
+	if info.isDir {
+		return &vfsDir{fsys: f, name: name, uri: uri, info: info}, nil
+	}
+
+	fh, err := f.vfs.Open(uri, TILEDB_VFS_READ)
+	if err != nil {
+		return nil, f.pathError("open", name, uri, err)
+	}
+	size := uint64(info.size)
+	fh.size = &size
+	return &vfsFile{fh: fh, name: name, info: info}, nil
+}
+
+// ReadDir implements fs.ReadDirFS.
+func (f *vfsFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	uri, err := f.uri("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.stat("readdir", name, uri)
+	if err != nil {
+		return nil, err
+	}
+	if !info.isDir {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errors.New("not a directory")}
+	}
+	return f.readDir(name, uri)
+}
+
+func (f *vfsFS) readDir(name, uri string) ([]fs.DirEntry, error) {
+	folders, files, err := f.vfs.List(uri)
+	if err != nil {
+		return nil, f.pathError("readdir", name, uri, err)
+	}
+
+	entries := make([]fs.DirEntry, 0, len(folders)+len(files))
+	for _, folder := range folders {
+		entries = append(entries, &vfsDirEntry{fsys: f, uri: folder, name: path.Base(strings.TrimSuffix(folder, "/")), isDir: true})
+	}
+	for _, file := range files {
+		entries = append(entries, &vfsDirEntry{fsys: f, uri: file, name: path.Base(file)})
+	}
+	slices.SortFunc(entries, func(a, b fs.DirEntry) int {
+		return strings.Compare(a.Name(), b.Name())
+	})
+	return entries, nil
+}
+
+// ReadFile implements fs.ReadFileFS.
+func (f *vfsFS) ReadFile(name string) ([]byte, error) {
+	uri, err := f.uri("readfile", name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.stat("readfile", name, uri)
+	if err != nil {
+		return nil, err
+	}
+	if info.isDir {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: errors.New("is a directory")}
+	}
+	if info.size == 0 {
+		return []byte{}, nil
+	}
+
+	fh, err := f.vfs.Open(uri, TILEDB_VFS_READ)
+	if err != nil {
+		return nil, f.pathError("readfile", name, uri, err)
+	}
+	defer fh.Close()
+
+	data, err := f.vfs.Read(fh, 0, uint64(info.size))
+	if err != nil {
+		return nil, f.pathError("readfile", name, uri, err)
+	}
+	return data, nil
+}
+
+// vfsFileInfo implements fs.FileInfo.
+type vfsFileInfo struct {
+	name  string
+	size  int64
+	isDir bool
+}
+
+func (i *vfsFileInfo) Name() string       { return i.name }
+func (i *vfsFileInfo) Size() int64        { return i.size }
+func (i *vfsFileInfo) ModTime() time.Time { return time.Time{} }
+func (i *vfsFileInfo) IsDir() bool        { return i.isDir }
+func (i *vfsFileInfo) Sys() any           { return nil }
+
+func (i *vfsFileInfo) Mode() fs.FileMode {
+	if i.isDir {
+		return fs.ModeDir | 0o555
+	}
+	return 0o444
+}
+
+// vfsDirEntry implements fs.DirEntry.
+type vfsDirEntry struct {
+	fsys  *vfsFS
+	uri   string
+	name  string
+	isDir bool
+}
+
+func (e *vfsDirEntry) Name() string { return e.name }
+func (e *vfsDirEntry) IsDir() bool  { return e.isDir }
+
+func (e *vfsDirEntry) Type() fs.FileMode {
+	if e.isDir {
+		return fs.ModeDir
+	}
+	return 0
+}
+
+// Info returns the file info of the entry. The size of files is fetched when Info is called.
+func (e *vfsDirEntry) Info() (fs.FileInfo, error) {
+	if e.isDir {
+		return &vfsFileInfo{name: e.name, isDir: true}, nil
+	}
+	size, err := e.fsys.vfs.FileSize(e.uri)
+	if err != nil {
+		return nil, e.fsys.pathError("stat", e.name, e.uri, err)
+	}
+	return &vfsFileInfo{name: e.name, size: int64(size)}, nil
+}
+
+// vfsFile is a file opened by vfsFS.Open.
+type vfsFile struct {
+	fh   *VFSfh
+	name string
+	info *vfsFileInfo
+}
+
+var (
+	_ io.Seeker   = (*vfsFile)(nil)
+	_ io.ReaderAt = (*vfsFile)(nil)
+)
+
+func (f *vfsFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+
+func (f *vfsFile) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	return f.fh.Read(p)
+}
+
+func (f *vfsFile) ReadAt(p []byte, off int64) (int, error) {
+	return f.fh.ReadAt(p, off)
+}
+
+func (f *vfsFile) Seek(offset int64, whence int) (int64, error) {
+	return f.fh.Seek(offset, whence)
+}
+
+func (f *vfsFile) Close() error {
+	return f.fh.Close()
+}
+
+// vfsDir is a directory opened by vfsFS.Open.
+type vfsDir struct {
+	fsys    *vfsFS
+	name    string
+	uri     string
+	info    *vfsFileInfo
+	entries []fs.DirEntry
+	listed  bool
+}
+
+func (d *vfsDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+
+func (d *vfsDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: errors.New("is a directory")}
+}
+
+func (d *vfsDir) Close() error { return nil }
+
+// ReadDir implements fs.ReadDirFile. The directory is listed on the first call.
+func (d *vfsDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !d.listed {
+		entries, err := d.fsys.readDir(d.name, d.uri)
+		if err != nil {
+			return nil, err
+		}
+		d.entries = entries
+		d.listed = true
+	}
+
+	if n <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(d.entries))
+	entries := d.entries[:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
@@ -0,0 +1,70 @@
+package tiledb
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVFSFS(t *testing.T) {
+	context, err := NewContext(nil)
+	require.NoError(t, err)
+	vfs, err := NewVFS(context, nil)
+	require.NoError(t, err)
+
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "dir", "subdir"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "a.txt"), []byte("hello"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "dir", "b.txt"), []byte("tiledb"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "dir", "subdir", "empty"), nil, 0o644))
+
+	fsys := vfs.FS(root)
+	require.NoError(t, fstest.TestFS(fsys, "a.txt", "dir/b.txt", "dir/subdir/empty"))
+
+	data, err := fs.ReadFile(fsys, "dir/b.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "tiledb", string(data))
+
+	info, err := fs.Stat(fsys, "a.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "a.txt", info.Name())
+	assert.Equal(t, int64(5), info.Size())
+	assert.False(t, info.IsDir())
+
+	entries, err := fs.ReadDir(fsys, "dir")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "b.txt", entries[0].Name())
+	assert.Equal(t, "subdir", entries[1].Name())
+	assert.True(t, entries[1].IsDir())
+
+	var walked []string
+	require.NoError(t, fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		walked = append(walked, path)
+		return err
+	}))
+	assert.Equal(t, []string{".", "a.txt", "dir", "dir/b.txt", "dir/subdir", "dir/subdir/empty"}, walked)
+
+	_, err = fsys.Open("missing")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	_, err = fs.ReadDir(fsys, "dir/missing")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	_, err = fs.Stat(fsys, "/a.txt")
+	assert.ErrorIs(t, err, fs.ErrInvalid)
+
+	// The root "/" keeps its slash.
+	data, err = fs.ReadFile(vfs.FS("/"), filepath.ToSlash(strings.TrimPrefix(root, "/"))+"/a.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+
+	// Files removed after they were listed are reported as not existing.
+	require.NoError(t, os.Remove(filepath.Join(root, "dir", "b.txt")))
+	_, err = entries[0].Info()
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}